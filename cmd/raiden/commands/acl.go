@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/sev-2/raiden/pkg/cli"
+	"github.com/sev-2/raiden/pkg/cli/acl"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func AclCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Inspect access control",
+		Long:  "Inspect row level security and privilege of each role",
+	}
+
+	cmd.AddCommand(AclReportCommand())
+	return cmd
+}
+
+type AclReportFlags struct {
+	cli.LogFlags
+	Report acl.Flags
+}
+
+func AclReportCommand() *cobra.Command {
+	f := AclReportFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Report effective access per role",
+		Long:    "Report effective command, combined using and check expression of each role to table and bucket",
+		Example: "raiden acl report --role authenticated --format markdown --output acl.md",
+		PreRun:  PreRun(&f.LogFlags, acl.PreRun),
+		Run: func(cmd *cobra.Command, args []string) {
+			f.CheckAndActivateDebug(cmd)
+
+			// get current directory
+			currentDir, errCurDir := utils.GetCurrentDirectory()
+			if errCurDir != nil {
+				acl.AclLogger.Error(errCurDir.Error())
+				return
+			}
+
+			if err := acl.Run(&f.Report, currentDir); err != nil {
+				acl.AclLogger.Error(err.Error())
+			}
+		},
+	}
+
+	f.Report.Bind(cmd)
+	return cmd
+}
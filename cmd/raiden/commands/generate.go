@@ -68,5 +68,48 @@ func GenerateCommand() *cobra.Command {
 	}
 
 	f.Generate.Bind(cmd)
+	cmd.AddCommand(GenerateClientCommand())
+	return cmd
+}
+
+type GenerateClientFlags struct {
+	cli.LogFlags
+	Client generate.ClientFlags
+}
+
+func GenerateClientCommand() *cobra.Command {
+	f := GenerateClientFlags{}
+
+	cmd := &cobra.Command{
+		Use:     "client",
+		Short:   "Generate typed client sdk",
+		Long:    "Generate typed client sdk for routes, rest model and rpc",
+		Example: "raiden generate client --lang ts --output web/src/raiden.ts",
+		PreRun:  PreRun(&f.LogFlags, generate.PreRun),
+		Run: func(cmd *cobra.Command, args []string) {
+			f.CheckAndActivateDebug(cmd)
+
+			// get current directory
+			currentDir, errCurDir := utils.GetCurrentDirectory()
+			if errCurDir != nil {
+				generate.GenerateLogger.Error(errCurDir.Error())
+				return
+			}
+
+			// load config
+			configFilePath := configure.GetConfigFilePath(currentDir)
+			config, err := raiden.LoadConfig(&configFilePath)
+			if err != nil {
+				generate.GenerateLogger.Error(err.Error())
+				return
+			}
+
+			if err = generate.RunClient(&f.Client, config, currentDir); err != nil {
+				generate.GenerateLogger.Error(err.Error())
+			}
+		},
+	}
+
+	f.Client.Bind(cmd)
 	return cmd
 }
@@ -13,6 +13,7 @@ func main() {
 	rootCmd := &cobra.Command{Use: "raiden"}
 
 	rootCmd.AddCommand(
+		commands.AclCommand(),
 		commands.ApplyCommand(),
 		commands.BuildCommand(),
 		commands.ConfigureCommand(),
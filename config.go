@@ -51,6 +51,8 @@ type Config struct {
 	PostgRestUrl             string           `mapstructure:"POSTGREST_URL"`
 	ProjectId                string           `mapstructure:"PROJECT_ID"`
 	ProjectName              string           `mapstructure:"PROJECT_NAME"`
+	RestPlanDebug            bool             `mapstructure:"REST_PLAN_DEBUG"`
+	RestPlanThreshold        int              `mapstructure:"REST_PLAN_THRESHOLD"`
 	ServiceKey               string           `mapstructure:"SERVICE_KEY"`
 	ServerHost               string           `mapstructure:"SERVER_HOST"`
 	ServerPort               string           `mapstructure:"SERVER_PORT"`
@@ -61,6 +63,9 @@ type Config struct {
 	SupabaseApiTokenType     string           `mapstructure:"SUPABASE_API_TOKEN_TYPE"`
 	SupabasePublicUrl        string           `mapstructure:"SUPABASE_PUBLIC_URL"`
 	ScheduleStatus           ScheduleStatus   `mapstructure:"SCHEDULE_STATUS"`
+	TenantClaim              string           `mapstructure:"TENANT_CLAIM"`
+	TenantColumn             string           `mapstructure:"TENANT_COLUMN"`
+	TenantHeader             string           `mapstructure:"TENANT_HEADER"`
 	TraceEnable              bool             `mapstructure:"TRACE_ENABLE"`
 	TraceCollector           string           `mapstructure:"TRACE_COLLECTOR"`
 	TraceCollectorEndpoint   string           `mapstructure:"TRACE_COLLECTOR_ENDPOINT"`
@@ -793,15 +793,22 @@ func RestProxy(appCtx Context, TableName string) error {
 		proxyUrl = fmt.Sprintf("%s?%s", proxyUrl, queryParam)
 	}
 
+	proxyUrl, err := applyRestProxyTenant(appCtx, req, TableName, proxyUrl)
+	if err != nil {
+		return err
+	}
+
 	req.SetRequestURI(proxyUrl)
 
 	resp := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseResponse(resp)
 
 	restProxyLogger.Debug("forward request", "method", string(req.Header.Method()), "uri", string(req.URI().FullURI()), "header", string(req.Header.RawHeaders()), "body", string(appCtx.RequestContext().Request.Body()))
+	start := time.Now()
 	if err := restProxyDoTimeout(req, resp, 30*time.Second); err != nil {
 		return err
 	}
+	duration := time.Since(start)
 
 	resp.Header.VisitAll(func(k, v []byte) {
 		appCtx.RequestContext().Response.Header.SetBytesKV(k, v)
@@ -812,9 +819,64 @@ func RestProxy(appCtx Context, TableName string) error {
 
 	restProxyLogger.Debug("response", "method", resp.StatusCode(), "uri", string(req.URI().FullURI()), "body", string(resp.Body()))
 
+	if shouldLogRestPlan(appCtx.Config(), req, duration) {
+		planReq := fasthttp.AcquireRequest()
+		req.CopyTo(planReq)
+		go logRestProxyPlan(planReq, duration)
+	}
+
 	return nil
 }
 
+// DefaultRestPlanThreshold is minimum duration of rest proxy request that the plan is logged
+// when REST_PLAN_DEBUG is enabled and REST_PLAN_THRESHOLD is not set
+const DefaultRestPlanThreshold = 500 * time.Millisecond
+
+// shouldLogRestPlan check plan debug is enabled and request is a slow read request
+func shouldLogRestPlan(config *Config, req *fasthttp.Request, duration time.Duration) bool {
+	if config == nil || !config.RestPlanDebug {
+		return false
+	}
+	return duration >= getRestPlanThreshold(config) && (req.Header.IsGet() || req.Header.IsHead())
+}
+
+func getRestPlanThreshold(config *Config) time.Duration {
+	if config.RestPlanThreshold <= 0 {
+		return DefaultRestPlanThreshold
+	}
+	return time.Duration(config.RestPlanThreshold) * time.Millisecond
+}
+
+// logRestProxyPlan resend slow read request with postgrest plan media type and log the plan,
+// the plan is estimated without analyze so the statement is not executed again,
+// request is a copy owned by this function and released after the plan is logged
+func logRestProxyPlan(req *fasthttp.Request, duration time.Duration) {
+	defer fasthttp.ReleaseRequest(req)
+
+	planResp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(planResp)
+
+	mediaType := string(req.Header.Peek(fasthttp.HeaderAccept))
+	if mediaType == "" || mediaType == "*/*" {
+		mediaType = "application/json"
+	}
+
+	uri := string(req.URI().FullURI())
+	method := string(req.Header.Method())
+	req.Header.Set(fasthttp.HeaderAccept, fmt.Sprintf("application/vnd.pgrst.plan+json; for=%q", mediaType))
+	if err := restProxyDoTimeout(req, planResp, 30*time.Second); err != nil {
+		restProxyLogger.Warn("fail explain slow request", "uri", uri, "error", err.Error())
+		return
+	}
+
+	if planResp.StatusCode() < 200 || planResp.StatusCode() > 299 {
+		restProxyLogger.Warn("fail explain slow request", "uri", uri, "status", planResp.StatusCode(), "body", string(planResp.Body()))
+		return
+	}
+
+	restProxyLogger.Info("slow request plan", "method", method, "uri", uri, "duration", duration.String(), "plan", string(planResp.Body()))
+}
+
 var storageProxyLogger = logger.HcLog().Named("raiden.controller.storage-proxy")
 
 func StorageProxy(appCtx Context, bucketName string, routePath string) error {
@@ -0,0 +1,23 @@
+package raiden
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestShouldLogRestPlan(t *testing.T) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	req.Header.SetMethod(fasthttp.MethodGet)
+
+	assert.False(t, shouldLogRestPlan(nil, req, time.Hour))
+	assert.False(t, shouldLogRestPlan(&Config{}, req, time.Hour))
+	assert.False(t, shouldLogRestPlan(&Config{RestPlanDebug: true}, req, time.Millisecond))
+	assert.True(t, shouldLogRestPlan(&Config{RestPlanDebug: true}, req, DefaultRestPlanThreshold))
+
+	req.Header.SetMethod(fasthttp.MethodPost)
+	assert.False(t, shouldLogRestPlan(&Config{RestPlanDebug: true}, req, DefaultRestPlanThreshold))
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -312,6 +314,50 @@ func TestRestController_HeadUsesRestProxy(t *testing.T) {
 	assert.Equal(t, "http://supabase.local/rest/v1/test_table?select=*", receivedPath)
 }
 
+type mockPostgrestTodo struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement"`
+	Title string `json:"title,omitempty" column:"name:title;type:text"`
+	Done  bool   `json:"done" column:"name:done;type:boolean;default:false"`
+
+	Metadata string `json:"-" schema:"public" tableName:"todos"`
+}
+
+func TestRestProxy_WithMockPostgrest(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed([]mockPostgrestTodo{
+		{Title: "write docs"},
+		{Title: "release", Done: true},
+		{Title: "review pr"},
+	}))
+
+	restore := raiden.SetRestProxyDoTimeout(pg.DoTimeout)
+	defer restore()
+
+	cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local"}
+	controller := raiden.RestController{Controller: &raiden.ControllerBase{}, TableName: "todos"}
+
+	ctx := raiden.NewCtx(cfg, nil, nil)
+	ctx.RequestCtx = &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/rest/v1/todos?select=id,title&done=is.false&order=id.desc")
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+
+	assert.NoError(t, controller.Get(&ctx))
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+	assert.JSONEq(t, `[{"id":3,"title":"review pr"},{"id":1,"title":"write docs"}]`, string(ctx.Response.Body()))
+
+	ctx = raiden.NewCtx(cfg, nil, nil)
+	ctx.RequestCtx = &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/rest/v1/todos")
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.Header.Set("Prefer", "return=representation")
+	ctx.Request.SetBodyString(`{"title":"deploy"}`)
+
+	assert.NoError(t, raiden.RestProxy(&ctx, "todos"))
+	assert.Equal(t, fasthttp.StatusCreated, ctx.Response.StatusCode())
+	assert.JSONEq(t, `[{"id":4,"title":"deploy","done":false}]`, string(ctx.Response.Body()))
+	assert.Len(t, pg.Rows("todos"), 4)
+}
+
 func TestRestController_HeadUsesRestProxyWhenControllerNil(t *testing.T) {
 	calledProxy := false
 	restore := raiden.SetRestProxyDoTimeout(func(req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error {
@@ -396,6 +442,83 @@ func TestRestController_HeadReturnsProxyError(t *testing.T) {
 	assert.EqualError(t, err, "proxy failed")
 }
 
+func TestRestProxy_LogSlowRequestPlan(t *testing.T) {
+	var mu sync.Mutex
+	var acceptHeaders []string
+	getAcceptHeaders := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, acceptHeaders...)
+	}
+	resetAcceptHeaders := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		acceptHeaders = nil
+	}
+
+	restore := raiden.SetRestProxyDoTimeout(func(req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error {
+		accept := string(req.Header.Peek(fasthttp.HeaderAccept))
+		mu.Lock()
+		acceptHeaders = append(acceptHeaders, accept)
+		mu.Unlock()
+
+		if !strings.HasPrefix(accept, "application/vnd.pgrst.plan+json") {
+			time.Sleep(2 * time.Millisecond)
+			resp.SetStatusCode(fasthttp.StatusOK)
+			resp.SetBodyString(`[{"id":1}]`)
+			return nil
+		}
+
+		resp.SetStatusCode(fasthttp.StatusOK)
+		resp.SetBodyString(`[{"Plan":{"Node Type":"Seq Scan"}}]`)
+		return nil
+	})
+	defer restore()
+
+	newCtx := func(cfg *raiden.Config, method string) *raiden.Ctx {
+		ctx := raiden.NewCtx(cfg, nil, nil)
+		ctx.RequestCtx = &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/rest/v1/test_table?select=*")
+		ctx.Request.Header.SetMethod(method)
+		return &ctx
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		resetAcceptHeaders()
+		cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local"}
+		assert.NoError(t, raiden.RestProxy(newCtx(cfg, fasthttp.MethodGet), "test_table"))
+		time.Sleep(20 * time.Millisecond)
+		assert.Len(t, getAcceptHeaders(), 1)
+	})
+
+	t.Run("faster than threshold", func(t *testing.T) {
+		resetAcceptHeaders()
+		cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", RestPlanDebug: true}
+		assert.NoError(t, raiden.RestProxy(newCtx(cfg, fasthttp.MethodGet), "test_table"))
+		time.Sleep(20 * time.Millisecond)
+		assert.Len(t, getAcceptHeaders(), 1)
+	})
+
+	t.Run("slow read request", func(t *testing.T) {
+		resetAcceptHeaders()
+		cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", RestPlanDebug: true, RestPlanThreshold: 1}
+		ctx := newCtx(cfg, fasthttp.MethodGet)
+		ctx.Request.Header.Set(fasthttp.HeaderAccept, "application/vnd.pgrst.object+json")
+		assert.NoError(t, raiden.RestProxy(ctx, "test_table"))
+		assert.Equal(t, `[{"id":1}]`, string(ctx.Response.Body()))
+		assert.Eventually(t, func() bool { return len(getAcceptHeaders()) == 2 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, `application/vnd.pgrst.plan+json; for="application/vnd.pgrst.object+json"`, getAcceptHeaders()[1])
+	})
+
+	t.Run("slow write request is not explained", func(t *testing.T) {
+		resetAcceptHeaders()
+		cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", RestPlanDebug: true, RestPlanThreshold: 1}
+		assert.NoError(t, raiden.RestProxy(newCtx(cfg, fasthttp.MethodPatch), "test_table"))
+		time.Sleep(20 * time.Millisecond)
+		assert.Len(t, getAcceptHeaders(), 1)
+	})
+}
+
 // Test StorageController methods
 func TestStorageController(t *testing.T) {
 	ctx := newMockCtx()
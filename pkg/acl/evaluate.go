@@ -0,0 +1,245 @@
+package acl
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// Principal is simulated requester of policy evaluation
+type Principal struct {
+	// Role is database role of request, like anon or authenticated,
+	// role inherited by the role is resolved from role registered with Evaluator.WithRoles
+	Role string
+
+	// Claims is jwt claims of request, sub claim is returned by auth.uid()
+	Claims map[string]any
+
+	// Settings override value of current_setting
+	Settings map[string]string
+
+	// BypassRls simulate role with BYPASSRLS attribute like service_role
+	BypassRls bool
+}
+
+// Decision is result of policy evaluation
+type Decision struct {
+	Allowed bool
+
+	// Policies is name of policy that grant the access
+	Policies []string
+
+	// Reason explain why access is denied
+	Reason string
+}
+
+// Evaluator check row level security policy offline without database,
+// it follow postgres semantic where permissive policy is combined with OR,
+// restrictive policy is combined with AND and access is denied when no permissive policy match.
+// Using clause and check clause is combined separately, so update is allowed when
+// one permissive policy pass the using clause and another one pass the check clause.
+//
+// example :
+//
+//	evaluator, err := acl.NewEvaluator(&models.Article{})
+//	owner := acl.Principal{Role: "authenticated", Claims: map[string]any{"sub": article.AuthorId}}
+//	allowed, err := evaluator.Can(owner, raiden.CommandUpdate, article, updatedArticle)
+type Evaluator struct {
+	policies   objects.Policies
+	rlsEnabled bool
+	roles      map[string]objects.Role
+
+	// Exists evaluate EXISTS sub query of policy
+	Exists func(query string, env builder.EvalEnv) (bool, error)
+}
+
+// NewEvaluator create evaluator from acl rule of model,
+// ConfigureAcl of model is called to collect the rule
+func NewEvaluator(model any) (*Evaluator, error) {
+	acl := state.GetModelAcl(model)
+	if acl == nil {
+		return nil, fmt.Errorf("model %T doesn't have acl", model)
+	}
+
+	schema, table := builder.TableFromModel(model)
+	policies, err := acl.BuildPolicies(schema, table)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Evaluator{policies: policies, rlsEnabled: acl.IsEnable()}, nil
+}
+
+// NewPolicyEvaluator create evaluator from existing policy, row level security is treated as enabled
+func NewPolicyEvaluator(policies objects.Policies) *Evaluator {
+	return &Evaluator{policies: policies, rlsEnabled: true}
+}
+
+// WithRoles register role used to resolve membership of principal role,
+// policy of role that is inherited by principal role is applied like postgres does
+func (e *Evaluator) WithRoles(roles ...objects.Role) *Evaluator {
+	if e.roles == nil {
+		e.roles = make(map[string]objects.Role, len(roles))
+	}
+	for _, r := range roles {
+		e.roles[r.Name] = r
+	}
+	return e
+}
+
+// Can return true when principal can run command to row,
+// update command require new row as the last argument to evaluate the check clause
+func (e *Evaluator) Can(principal Principal, command raiden.Command, row any, newRow ...any) (bool, error) {
+	decision, err := e.Decide(principal, command, row, newRow...)
+	if err != nil {
+		return false, err
+	}
+	return decision.Allowed, nil
+}
+
+// Decide evaluate policy of command and return the decision with policy that grant the access
+func (e *Evaluator) Decide(principal Principal, command raiden.Command, row any, newRow ...any) (Decision, error) {
+	if command == "" || command == raiden.CommandAll {
+		return Decision{}, fmt.Errorf("command must be SELECT, INSERT, UPDATE or DELETE")
+	}
+
+	if !e.rlsEnabled {
+		return Decision{Allowed: true, Reason: "row level security is disabled"}, nil
+	}
+
+	if principal.BypassRls {
+		return Decision{Allowed: true, Reason: "role bypass row level security"}, nil
+	}
+
+	current := builder.RowFromModel(row)
+	next := current
+	if command == raiden.CommandUpdate && len(newRow) > 0 {
+		next = builder.RowFromModel(newRow[len(newRow)-1])
+	}
+
+	permissive, restrictive := e.applicablePolicies(principal.Role, command)
+	if len(permissive) == 0 {
+		return Decision{Reason: fmt.Sprintf("no permissive policy for %s to %s", principal.Role, command)}, nil
+	}
+
+	env := builder.EvalEnv{
+		Claims:   principal.Claims,
+		Role:     principal.Role,
+		Settings: principal.Settings,
+		Exists:   e.Exists,
+	}
+
+	granted := make([]string, 0)
+
+	// using clause is applied to existing row, insert doesn't have existing row
+	if command != raiden.CommandInsert {
+		env.Row = current
+		names, decision, err := e.evaluateGroup(permissive, restrictive, env, usingClause, "using")
+		if err != nil || !decision.Allowed {
+			return decision, err
+		}
+		granted = appendPolicyNames(granted, names)
+	}
+
+	// check clause is applied to new row of insert and update
+	if command == raiden.CommandInsert || command == raiden.CommandUpdate {
+		env.Row = next
+		names, decision, err := e.evaluateGroup(permissive, restrictive, env, checkClause, "check")
+		if err != nil || !decision.Allowed {
+			return decision, err
+		}
+		granted = appendPolicyNames(granted, names)
+	}
+
+	return Decision{Allowed: true, Policies: granted}, nil
+}
+
+// evaluateGroup evaluate one kind of clause of every applicable policy,
+// at least one permissive policy and every restrictive policy must be satisfied
+func (e *Evaluator) evaluateGroup(permissive, restrictive objects.Policies, env builder.EvalEnv, clause func(objects.Policy) string, kind string) ([]string, Decision, error) {
+	granted := make([]string, 0)
+	for _, p := range permissive {
+		ok, err := evaluateClause(p, clause(p), env, kind)
+		if err != nil {
+			return nil, Decision{}, err
+		}
+		if ok {
+			granted = append(granted, p.Name)
+		}
+	}
+
+	if len(granted) == 0 {
+		return nil, Decision{Reason: "no permissive policy is satisfied"}, nil
+	}
+
+	for _, p := range restrictive {
+		ok, err := evaluateClause(p, clause(p), env, kind)
+		if err != nil {
+			return nil, Decision{}, err
+		}
+		if !ok {
+			return nil, Decision{Reason: fmt.Sprintf("restrictive policy %s is not satisfied", p.Name)}, nil
+		}
+	}
+
+	return granted, Decision{Allowed: true}, nil
+}
+
+func (e *Evaluator) applicablePolicies(role string, command raiden.Command) (permissive, restrictive objects.Policies) {
+	principalRole, exist := e.roles[role]
+	if !exist {
+		principalRole = objects.Role{Name: role, InheritRole: true}
+	}
+	effectiveRoles := append([]string{role}, inheritedRoles(principalRole, e.roles)...)
+
+	for _, p := range e.policies {
+		if p.Command != objects.PolicyCommandAll && p.Command != command.ToSupabaseCommand() {
+			continue
+		}
+
+		if !policyAppliesTo(p, effectiveRoles) {
+			continue
+		}
+
+		if p.Action == raiden.AclModeRestrictive.ActionString() {
+			restrictive = append(restrictive, p)
+			continue
+		}
+		permissive = append(permissive, p)
+	}
+	return
+}
+
+func usingClause(p objects.Policy) string {
+	return p.Definition
+}
+
+// checkClause return check clause of policy,
+// policy without check clause reuse using clause for the new row like postgres does
+func checkClause(p objects.Policy) string {
+	if p.Check != nil {
+		return *p.Check
+	}
+	return p.Definition
+}
+
+func evaluateClause(p objects.Policy, clause string, env builder.EvalEnv, kind string) (bool, error) {
+	ok, err := builder.Evaluate(builder.Clause(clause), env)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate %s clause of policy %s : %w", kind, p.Name, err)
+	}
+	return ok, nil
+}
+
+func appendPolicyNames(names []string, added []string) []string {
+	for _, n := range added {
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
@@ -0,0 +1,187 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	evalAuthor = "8f14e45f-ceea-467a-9575-6ab5f1a0e2b1"
+	evalReader = "c9f0f895-fb98-4b91-8a3e-5b2b4d3c7a10"
+	evalOrg    = "1f0e3dad-9990-4734-b2a5-e6bd9c5f1a2d"
+)
+
+type evalNote struct {
+	raiden.ModelBase
+	Id       int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	AuthorId string `json:"author_id,omitempty" column:"name:author_id;type:uuid"`
+	OrgId    string `json:"org_id,omitempty" column:"name:org_id;type:uuid"`
+	Public   bool   `json:"public,omitempty" column:"name:public;type:boolean"`
+
+	Metadata string `json:"-" schema:"public" tableName:"notes"`
+	Acl      raiden.Acl
+}
+
+func (n *evalNote) ConfigureAcl() {
+	n.Acl.Enable().Define(
+		raiden.Rule("notes_read").For("authenticated").To(raiden.CommandSelect).
+			Using(builder.Or(builder.IsTrue("public"), builder.OwnerIsAuth("author_id"))),
+		raiden.Rule("notes_write").For("authenticated").To(raiden.CommandUpdate).
+			Using(builder.OwnerIsAuth("author_id")).
+			Check(builder.OwnerIsAuth("author_id")),
+		raiden.Rule("notes_create").For("authenticated").To(raiden.CommandInsert).
+			Check(builder.OwnerIsAuth("author_id")),
+		raiden.Rule("notes_tenant").To(raiden.CommandAll).WithRestrictive().
+			Using(builder.TenantClaimMatch("org_id", "org_id", "uuid")).
+			Check(builder.TenantClaimMatch("org_id", "org_id", "uuid")),
+	)
+}
+
+func TestEvaluator_Can(t *testing.T) {
+	evaluator, err := NewEvaluator(&evalNote{})
+	require.NoError(t, err)
+
+	author := Principal{Role: "authenticated", Claims: map[string]any{"sub": evalAuthor, "org_id": evalOrg}}
+	reader := Principal{Role: "authenticated", Claims: map[string]any{"sub": evalReader, "org_id": evalOrg}}
+	anon := Principal{Role: "anon"}
+
+	note := &evalNote{Id: 1, AuthorId: evalAuthor, OrgId: evalOrg, Public: true}
+
+	ok, err := evaluator.Can(reader, raiden.CommandSelect, note)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = evaluator.Can(reader, raiden.CommandUpdate, note, note)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = evaluator.Can(author, raiden.CommandUpdate, note, note)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// check clause reject update that transfer ownership
+	transferred := &evalNote{Id: 1, AuthorId: evalReader, OrgId: evalOrg, Public: true}
+	ok, err = evaluator.Can(author, raiden.CommandUpdate, note, transferred)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = evaluator.Can(anon, raiden.CommandSelect, note)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = evaluator.Can(author, raiden.CommandDelete, note)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = evaluator.Can(Principal{Role: "service_role", BypassRls: true}, raiden.CommandDelete, note)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestEvaluator_Decide(t *testing.T) {
+	evaluator, err := NewEvaluator(&evalNote{})
+	require.NoError(t, err)
+
+	author := Principal{Role: "authenticated", Claims: map[string]any{"sub": evalAuthor, "org_id": evalOrg}}
+	note := &evalNote{Id: 1, AuthorId: evalAuthor, OrgId: evalOrg}
+
+	decision, err := evaluator.Decide(author, raiden.CommandSelect, note)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, []string{"notes_read"}, decision.Policies)
+
+	decision, err = evaluator.Decide(author, raiden.CommandInsert, note)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	otherOrg := &evalNote{Id: 1, AuthorId: evalAuthor, OrgId: evalReader}
+	decision, err = evaluator.Decide(author, raiden.CommandSelect, otherOrg)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "restrictive policy notes_tenant is not satisfied", decision.Reason)
+
+	decision, err = evaluator.Decide(Principal{Role: "anon"}, raiden.CommandSelect, note)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "no permissive policy for anon to SELECT", decision.Reason)
+
+	_, err = evaluator.Decide(author, raiden.CommandAll, note)
+	require.Error(t, err)
+}
+
+func TestEvaluator_UpdateSplitPolicy(t *testing.T) {
+	// using clause is only satisfied by owner policy and check clause only by published policy,
+	// postgres combine every using clause and every check clause separately so update is allowed
+	owner, published := "author_id = auth.uid()", "public = true"
+	evaluator := NewPolicyEvaluator(objects.Policies{
+		{Name: "notes_update_owner", Command: objects.PolicyCommandUpdate, Action: "PERMISSIVE", Definition: owner, Check: &owner},
+		{Name: "notes_update_published", Command: objects.PolicyCommandUpdate, Action: "PERMISSIVE", Definition: published, Check: &published},
+	})
+
+	author := Principal{Role: "authenticated", Claims: map[string]any{"sub": evalAuthor}}
+	current := map[string]any{"author_id": evalAuthor, "public": false}
+	updated := map[string]any{"author_id": evalReader, "public": true}
+
+	decision, err := evaluator.Decide(author, raiden.CommandUpdate, current, updated)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, []string{"notes_update_owner", "notes_update_published"}, decision.Policies)
+
+	// new row doesn't pass check clause of any permissive policy
+	decision, err = evaluator.Decide(author, raiden.CommandUpdate, current, map[string]any{"author_id": evalReader, "public": false})
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "no permissive policy is satisfied", decision.Reason)
+}
+
+func TestEvaluator_RoleMembership(t *testing.T) {
+	evaluator := NewPolicyEvaluator(objects.Policies{
+		{Name: "notes_review", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Roles: []string{"reviewer"}, Definition: "true"},
+	})
+
+	editor := Principal{Role: "editor"}
+	ok, err := evaluator.Can(editor, raiden.CommandSelect, map[string]any{"id": 1})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// editor inherit policy of reviewer through chief role
+	evaluator.WithRoles(
+		objects.Role{Name: "editor", InheritRole: true, InheritRoles: []*objects.Role{{Name: "chief"}}},
+		objects.Role{Name: "chief", InheritRole: true, InheritRoles: []*objects.Role{{Name: "reviewer"}}},
+		objects.Role{Name: "auditor", InheritRole: false, InheritRoles: []*objects.Role{{Name: "reviewer"}}},
+	)
+
+	decision, err := evaluator.Decide(editor, raiden.CommandSelect, map[string]any{"id": 1})
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, []string{"notes_review"}, decision.Policies)
+
+	// privilege of member role is not inherited by role with NOINHERIT
+	ok, err = evaluator.Can(Principal{Role: "auditor"}, raiden.CommandSelect, map[string]any{"id": 1})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestNewPolicyEvaluator(t *testing.T) {
+	evaluator := NewPolicyEvaluator(objects.Policies{
+		{Name: "members", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Definition: `EXISTS (SELECT 1 FROM members m WHERE m.user_id = auth.uid())`},
+	})
+
+	principal := Principal{Role: "authenticated", Claims: map[string]any{"sub": evalAuthor}}
+	_, err := evaluator.Can(principal, raiden.CommandSelect, map[string]any{"id": 1})
+	require.ErrorContains(t, err, "failed to evaluate using clause of policy members")
+
+	evaluator.Exists = func(query string, env builder.EvalEnv) (bool, error) {
+		return env.Claims["sub"] == evalAuthor, nil
+	}
+	ok, err := evaluator.Can(principal, raiden.CommandSelect, map[string]any{"id": 1})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = NewEvaluator(&struct{ Id int }{})
+	require.Error(t, err)
+}
@@ -0,0 +1,489 @@
+package acl
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// Preset is built-in library of common policy shape, each preset expand to named rule
+// and can be combined with custom rule in ConfigureAcl
+//
+// example :
+//
+//	func (p *Projects) ConfigureAcl() {
+//		p.Acl.Enable().Use(
+//			acl.Preset.TenantIsolation(p, &p.OrgId, "org_id"),
+//			acl.Preset.OwnerCRUD(p, &p.UserId, "authenticated"),
+//			acl.Preset.ServiceRoleAll(p),
+//		).Define(
+//			raiden.Rule("projects_owner_delete").For("admin").To(raiden.CommandDelete),
+//		)
+//	}
+var Preset presets
+
+type presets struct{}
+
+const (
+	PresetOwnerCRUD       = "OwnerCRUD"
+	PresetPublicRead      = "PublicRead"
+	PresetServiceRoleAll  = "ServiceRoleAll"
+	PresetTenantIsolation = "TenantIsolation"
+)
+
+// OwnerCRUD allow select, insert, update and delete of row owned by current user,
+// rule is named `<table>_owner_<command>`
+func (presets) OwnerCRUD(model any, ownerField any, roles ...string) raiden.AclPreset {
+	_, table := builder.TableFromModel(model)
+	return ownerCRUD(table, builder.ColOf(model, ownerField), roles...)
+}
+
+// PublicRead allow select of every row, rule is named `<table>_public_read`
+func (presets) PublicRead(model any, roles ...string) raiden.AclPreset {
+	_, table := builder.TableFromModel(model)
+	return publicRead(table, roles...)
+}
+
+// ServiceRoleAll allow every command for service_role, rule is named `<table>_service_role_all`
+func (presets) ServiceRoleAll(model any) raiden.AclPreset {
+	_, table := builder.TableFromModel(model)
+	return serviceRoleAll(table)
+}
+
+// TenantIsolation restrict every command to row of tenant in jwt claim,
+// claim can be nested like `app_metadata.org_id` and rule is named `<table>_tenant_isolation`
+func (presets) TenantIsolation(model any, tenantField any, claim string) raiden.AclPreset {
+	_, table := builder.TableFromModel(model)
+	column := builder.ColOf(model, tenantField)
+	return tenantIsolation(table, column, claim, tenantCast(columnType(model, column)))
+}
+
+// Compose combine multiple preset into single preset
+func Compose(presets ...raiden.AclPreset) raiden.AclPreset {
+	return func(a *raiden.Acl) {
+		a.Use(presets...)
+	}
+}
+
+func ownerCRUD(table, column string, roles ...string) raiden.AclPreset {
+	return func(a *raiden.Acl) {
+		owner := builder.OwnerIsAuth(column)
+		a.Define(
+			raiden.Rule(table+"_owner_select").For(roles...).To(raiden.CommandSelect).Using(owner),
+			raiden.Rule(table+"_owner_insert").For(roles...).To(raiden.CommandInsert).Check(owner),
+			raiden.Rule(table+"_owner_update").For(roles...).To(raiden.CommandUpdate).Using(owner).Check(owner),
+			raiden.Rule(table+"_owner_delete").For(roles...).To(raiden.CommandDelete).Using(owner),
+		)
+	}
+}
+
+func publicRead(table string, roles ...string) raiden.AclPreset {
+	return func(a *raiden.Acl) {
+		a.Define(raiden.Rule(table + "_public_read").For(roles...).To(raiden.CommandSelect).Using(builder.True))
+	}
+}
+
+func serviceRoleAll(table string) raiden.AclPreset {
+	return func(a *raiden.Acl) {
+		a.Define(raiden.Rule(table + "_service_role_all").For("service_role").To(raiden.CommandAll).Using(builder.True).Check(builder.True))
+	}
+}
+
+func tenantIsolation(table, column, claim, cast string) raiden.AclPreset {
+	if claim == "" {
+		claim = raiden.DefaultTenantClaim
+	}
+	return func(a *raiden.Acl) {
+		match := builder.TenantClaimMatch(column, claim, cast)
+		a.Define(raiden.Rule(table + "_tenant_isolation").To(raiden.CommandAll).Using(match).Check(match).WithRestrictive())
+	}
+}
+
+// columnType return type in column tag of model field
+func columnType(model any, column string) string {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("column")
+		if tag == "" {
+			continue
+		}
+		ct := raiden.UnmarshalColumnTag(tag)
+		if ct.Name == column {
+			return ct.Type
+		}
+	}
+	return ""
+}
+
+// tenantCast return cast of jwt claim, text column is compared without cast
+func tenantCast(dataType string) string {
+	switch postgres.DataType(dataType) {
+	case postgres.TextType, postgres.VarcharType, postgres.VarcharTypeAlias, postgres.CharType, postgres.BpcharType:
+		return ""
+	default:
+		return dataType
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Preset recognition
+// ----------------------------------------------------------------------------
+
+// PresetMatch is preset that produce a group of existing policy
+type PresetMatch struct {
+	Preset string
+	Column string
+	Claim  string
+	Roles  []string
+
+	// Policies is name of policy covered by the preset
+	Policies []string
+}
+
+var claimKeyPattern = regexp.MustCompile(`->>?\s*'([^']*)'`)
+
+// MatchPresets find preset that produce exactly the same policy as existing policy of table,
+// used by generator to write preset instead of each rule
+func MatchPresets(table objects.Table, policies objects.Policies) []PresetMatch {
+	byName := make(map[string]objects.Policy, len(policies))
+	for _, p := range policies {
+		byName[p.Name] = p
+	}
+
+	qualifier := builder.ClauseQualifier{Schema: table.Schema, Table: table.Name}
+	matches := make([]PresetMatch, 0)
+
+	// tenant policy that doesn't compare column with jwt claim, like `auth.uid()` or sub query,
+	// can't be produced by preset so it is kept as rule
+	if p, ok := byName[table.Name+"_tenant_isolation"]; ok {
+		claim := claimFromDefinition(p.Definition, qualifier)
+		column, found := columnFromDefinition(p.Definition, qualifier, table.Columns)
+		if found && claim != "" {
+			m := PresetMatch{Preset: PresetTenantIsolation, Column: column.Name, Claim: claim}
+			if presetMatches(tenantIsolation(table.Name, column.Name, claim, tenantCast(column.DataType)), table, byName, qualifier, &m) {
+				matches = append(matches, m)
+			}
+		}
+	}
+
+	if p, ok := byName[table.Name+"_owner_select"]; ok {
+		for _, c := range table.Columns {
+			m := PresetMatch{Preset: PresetOwnerCRUD, Column: c.Name, Roles: presetRoles(p.Roles)}
+			if presetMatches(ownerCRUD(table.Name, c.Name, m.Roles...), table, byName, qualifier, &m) {
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+
+	if p, ok := byName[table.Name+"_public_read"]; ok {
+		m := PresetMatch{Preset: PresetPublicRead, Roles: presetRoles(p.Roles)}
+		if presetMatches(publicRead(table.Name, m.Roles...), table, byName, qualifier, &m) {
+			matches = append(matches, m)
+		}
+	}
+
+	if _, ok := byName[table.Name+"_service_role_all"]; ok {
+		m := PresetMatch{Preset: PresetServiceRoleAll}
+		if presetMatches(serviceRoleAll(table.Name), table, byName, qualifier, &m) {
+			matches = append(matches, m)
+		}
+	}
+
+	return matches
+}
+
+// presetMatches expand preset and compare every produced policy with existing one,
+// name of covered policy is stored to match
+func presetMatches(preset raiden.AclPreset, table objects.Table, existing map[string]objects.Policy, qualifier builder.ClauseQualifier, m *PresetMatch) bool {
+	expanded := &raiden.Acl{}
+	expanded.Use(preset)
+	policies, err := expanded.BuildPolicies(table.Schema, table.Name)
+	if err != nil || len(policies) == 0 {
+		return false
+	}
+
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		current, ok := existing[p.Name]
+		if !ok || !samePolicy(p, current, qualifier) {
+			return false
+		}
+		names = append(names, p.Name)
+	}
+
+	slices.Sort(names)
+	m.Policies = names
+	return true
+}
+
+func samePolicy(a, b objects.Policy, qualifier builder.ClauseQualifier) bool {
+	if a.Command != b.Command || !strings.EqualFold(policyAction(a), policyAction(b)) {
+		return false
+	}
+
+	if !slices.Equal(normalizeRoles(a.Roles), normalizeRoles(b.Roles)) {
+		return false
+	}
+
+	if clauseKey(a.Definition, qualifier) != clauseKey(b.Definition, qualifier) {
+		return false
+	}
+
+	checkA, checkB := "", ""
+	if a.Check != nil {
+		checkA = *a.Check
+	}
+	if b.Check != nil {
+		checkB = *b.Check
+	}
+	return clauseKey(checkA, qualifier) == clauseKey(checkB, qualifier)
+}
+
+func policyAction(p objects.Policy) string {
+	if p.Action == "" {
+		return raiden.AclModePermissive.ActionString()
+	}
+	return p.Action
+}
+
+// normalizeRoles return sorted role, empty role is equal to public
+func normalizeRoles(roles []string) []string {
+	if len(roles) == 0 {
+		return []string{"public"}
+	}
+	normalized := slices.Clone(roles)
+	slices.Sort(normalized)
+	return normalized
+}
+
+// presetRoles return role argument of preset, public role is the default so it is omitted
+func presetRoles(roles []string) []string {
+	result := make([]string, 0, len(roles))
+	for _, r := range roles {
+		if r == "public" {
+			continue
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// clauseKey normalize clause so local and postgres deparsed clause can be compared,
+// parentheses that change the meaning of clause are kept
+func clauseKey(sql string, qualifier builder.ClauseQualifier) string {
+	normalized := strings.ToLower(builder.NormalizeClauseSQL(sql, qualifier))
+	normalized = strings.Join(strings.Fields(normalized), " ")
+	normalized = strings.NewReplacer("( ", "(", " )", ")").Replace(normalized)
+	return logicalKey(normalized)
+}
+
+// logicalKey rebuild and / or expression with only the parentheses required by operator precedence
+func logicalKey(expr string) string {
+	expr = stripOuterParens(expr)
+	if parts := splitTopLevel(expr, " or "); len(parts) > 1 {
+		for i := range parts {
+			parts[i] = logicalKey(parts[i])
+		}
+		return strings.Join(parts, " or ")
+	}
+
+	if parts := splitTopLevel(expr, " and "); len(parts) > 1 {
+		for i := range parts {
+			parts[i] = logicalKey(parts[i])
+			if len(splitTopLevel(parts[i], " or ")) > 1 {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, " and ")
+	}
+
+	return operandKey(expr)
+}
+
+// operandKey remove parentheses postgres add when deparse an operand,
+// like `((current_setting('x')) -> 'a') ->> 'b'`
+func operandKey(expr string) string {
+	for {
+		next := stripRedundantOperandParens(expr)
+		if next == expr {
+			return expr
+		}
+		expr = next
+	}
+}
+
+// stripRedundantOperandParens remove the first redundant parentheses pair found in expression
+func stripRedundantOperandParens(expr string) string {
+	for open := 0; open < len(expr); open++ {
+		if expr[open] != '(' || inQuote(expr, open) {
+			continue
+		}
+		closing := matchingParen(expr, open)
+		if closing < 0 {
+			return expr
+		}
+
+		inner := expr[open+1 : closing]
+		prefix := strings.TrimRight(expr[:open], " ")
+		suffix := strings.TrimLeft(expr[closing+1:], " ")
+
+		// parentheses of function call argument
+		if prefix != "" && isIdentifierChar(prefix[len(prefix)-1]) && len(prefix) == len(expr[:open]) {
+			continue
+		}
+
+		redundant := false
+		switch {
+		case enclosedByParens(inner):
+			// doubled parentheses, `((x))`
+			redundant = true
+		case isFunctionCall(inner):
+			// wrapped function call, `(f(x))`
+			redundant = true
+		case (prefix == "" || strings.HasSuffix(prefix, "(")) && isJsonAccess(inner) &&
+			(strings.HasPrefix(suffix, "->") || suffix == "" || strings.HasPrefix(suffix, ")")):
+			// left operand of left associative json operator
+			redundant = true
+		}
+
+		if redundant {
+			return expr[:open] + inner + expr[closing+1:]
+		}
+	}
+	return expr
+}
+
+// stripOuterParens remove parentheses that enclose whole expression
+func stripOuterParens(expr string) string {
+	expr = strings.TrimSpace(expr)
+	for enclosedByParens(expr) {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	return expr
+}
+
+func enclosedByParens(expr string) bool {
+	return strings.HasPrefix(expr, "(") && matchingParen(expr, 0) == len(expr)-1
+}
+
+// splitTopLevel split expression by operator that is not inside parentheses or quote
+func splitTopLevel(expr, operator string) []string {
+	var parts []string
+	depth, start := 0, 0
+	quoted := false
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.HasPrefix(expr[i:], operator):
+			parts = append(parts, expr[start:i])
+			start = i + len(operator)
+			i = start - 1
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// matchingParen return index of parenthesis that close the one at open, or -1
+func matchingParen(expr string, open int) int {
+	depth := 0
+	quoted := false
+	for i := open; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func inQuote(expr string, index int) bool {
+	return strings.Count(expr[:index], "'")%2 == 1
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// isFunctionCall check expression is a single call, like `auth.uid()`
+func isFunctionCall(expr string) bool {
+	open := strings.Index(expr, "(")
+	if open <= 0 || !strings.HasSuffix(expr, ")") || matchingParen(expr, open) != len(expr)-1 {
+		return false
+	}
+	for i := 0; i < open; i++ {
+		if !isIdentifierChar(expr[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isJsonAccess check top level operator of expression are only json access operator
+func isJsonAccess(expr string) bool {
+	if len(splitTopLevel(expr, "->")) < 2 {
+		return false
+	}
+	rest := strings.NewReplacer("->>", " ", "->", " ").Replace(expr)
+	for _, operator := range []string{"=", "<", ">", "!", "+", "-", "*", "/", "|", " and ", " or ", " is ", " in "} {
+		if len(splitTopLevel(rest, operator)) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// columnFromDefinition return column compared by equality clause like `org_id = <claim>`
+func columnFromDefinition(definition string, qualifier builder.ClauseQualifier, columns []objects.Column) (objects.Column, bool) {
+	operands := splitTopLevel(stripOuterParens(builder.NormalizeClauseSQL(definition, qualifier)), " = ")
+	if len(operands) != 2 {
+		return objects.Column{}, false
+	}
+
+	for _, operand := range operands {
+		name := stripOuterParens(operand)
+		for _, c := range columns {
+			if c.Name == name {
+				return c, true
+			}
+		}
+	}
+	return objects.Column{}, false
+}
+
+// claimFromDefinition return jwt claim path of tenant clause, like `app_metadata.org_id`
+func claimFromDefinition(definition string, qualifier builder.ClauseQualifier) string {
+	found := claimKeyPattern.FindAllStringSubmatch(builder.NormalizeClauseSQL(definition, qualifier), -1)
+	keys := make([]string, 0, len(found))
+	for _, f := range found {
+		keys = append(keys, f[1])
+	}
+	return strings.Join(keys, ".")
+}
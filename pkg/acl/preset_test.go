@@ -0,0 +1,207 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/require"
+)
+
+type presetProject struct {
+	raiden.ModelBase
+	Id     int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	UserId string `json:"user_id,omitempty" column:"name:user_id;type:uuid"`
+	OrgId  string `json:"org_id,omitempty" column:"name:org_id;type:uuid"`
+	Slug   string `json:"slug,omitempty" column:"name:slug;type:text"`
+
+	Metadata string `json:"-" schema:"public" tableName:"projects"`
+	Acl      raiden.Acl
+}
+
+func (p *presetProject) ConfigureAcl() {
+	p.Acl.Enable().Use(
+		Preset.TenantIsolation(p, &p.OrgId, "org_id"),
+		Compose(
+			Preset.OwnerCRUD(p, &p.UserId, "authenticated"),
+			Preset.ServiceRoleAll(p),
+		),
+	).Define(
+		raiden.Rule("projects_owner_delete").For("admin").To(raiden.CommandDelete).Using(builder.True),
+	)
+}
+
+func buildPresetPolicies(t *testing.T) map[string]objects.Policy {
+	p := &presetProject{}
+	p.ConfigureAcl()
+
+	policies, err := p.Acl.BuildPolicies("public", "projects")
+	require.NoError(t, err)
+
+	byName := make(map[string]objects.Policy)
+	for _, policy := range policies {
+		byName[policy.Name] = policy
+	}
+	return byName
+}
+
+func TestPreset_Expand(t *testing.T) {
+	policies := buildPresetPolicies(t)
+	require.Len(t, policies, 6)
+
+	selectPolicy := policies["projects_owner_select"]
+	require.Equal(t, objects.PolicyCommandSelect, selectPolicy.Command)
+	require.Equal(t, []string{"authenticated"}, selectPolicy.Roles)
+	require.Equal(t, `"user_id" = auth.uid()`, selectPolicy.Definition)
+
+	insertPolicy := policies["projects_owner_insert"]
+	require.NotNil(t, insertPolicy.Check)
+	require.Equal(t, `"user_id" = auth.uid()`, *insertPolicy.Check)
+
+	// rule defined after preset replace the rule with the same name
+	deletePolicy := policies["projects_owner_delete"]
+	require.Equal(t, []string{"admin"}, deletePolicy.Roles)
+	require.Equal(t, "TRUE", deletePolicy.Definition)
+
+	tenantPolicy := policies["projects_tenant_isolation"]
+	require.Equal(t, "RESTRICTIVE", tenantPolicy.Action)
+	require.Equal(t, objects.PolicyCommandAll, tenantPolicy.Command)
+	require.Equal(t, builder.TenantClaimMatch("org_id", "org_id", "uuid").String(), tenantPolicy.Definition)
+
+	require.Equal(t, []string{"service_role"}, policies["projects_service_role_all"].Roles)
+}
+
+func TestPreset_TextTenantColumn(t *testing.T) {
+	p := &presetProject{}
+	a := &raiden.Acl{}
+	a.Use(Preset.TenantIsolation(p, &p.Slug, ""), Preset.PublicRead(p))
+
+	policies, err := a.BuildPolicies("public", "projects")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	for _, policy := range policies {
+		switch policy.Name {
+		case "projects_tenant_isolation":
+			require.Equal(t, builder.TenantClaimMatch("slug", raiden.DefaultTenantClaim, "").String(), policy.Definition)
+		case "projects_public_read":
+			require.Equal(t, objects.PolicyCommandSelect, policy.Command)
+			require.Equal(t, "TRUE", policy.Definition)
+		default:
+			t.Fatalf("unexpected policy %s", policy.Name)
+		}
+	}
+}
+
+func TestMatchPresets(t *testing.T) {
+	table := objects.Table{
+		Schema: "public",
+		Name:   "projects",
+		Columns: []objects.Column{
+			{Name: "id", DataType: string(postgres.BigIntType)},
+			{Name: "user_id", DataType: string(postgres.UuidType)},
+			{Name: "org_id", DataType: string(postgres.UuidType)},
+		},
+	}
+
+	byName := buildPresetPolicies(t)
+	policies := make(objects.Policies, 0, len(byName))
+	for _, p := range byName {
+		policies = append(policies, p)
+	}
+
+	matches := MatchPresets(table, policies)
+	require.Len(t, matches, 2)
+
+	require.Equal(t, PresetTenantIsolation, matches[0].Preset)
+	require.Equal(t, "org_id", matches[0].Column)
+	require.Equal(t, "org_id", matches[0].Claim)
+	require.Equal(t, []string{"projects_tenant_isolation"}, matches[0].Policies)
+
+	// owner delete is redefined, so owner crud preset is not recognized
+	require.Equal(t, PresetServiceRoleAll, matches[1].Preset)
+
+	// definition as returned by postgres
+	check := "(user_id = auth.uid())"
+	nested := "(org_id = ((((current_setting('request.jwt.claims'::text))::jsonb -> 'app_metadata'::text) ->> 'org_id'::text))::uuid)"
+	matches = MatchPresets(table, objects.Policies{
+		{Name: "projects_owner_select", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Roles: []string{"public"}, Definition: "(user_id = auth.uid())"},
+		{Name: "projects_owner_insert", Command: objects.PolicyCommandInsert, Action: "PERMISSIVE", Roles: []string{"public"}, Check: &check},
+		{Name: "projects_owner_update", Command: objects.PolicyCommandUpdate, Action: "PERMISSIVE", Roles: []string{"public"}, Definition: "(user_id = auth.uid())", Check: &check},
+		{Name: "projects_owner_delete", Command: objects.PolicyCommandDelete, Action: "PERMISSIVE", Roles: []string{"public"}, Definition: "(user_id = auth.uid())"},
+		{Name: "projects_tenant_isolation", Command: objects.PolicyCommandAll, Action: "RESTRICTIVE", Roles: []string{"public"}, Definition: nested, Check: &nested},
+	})
+	require.Len(t, matches, 2)
+	require.Equal(t, "app_metadata.org_id", matches[0].Claim)
+	require.Equal(t, PresetOwnerCRUD, matches[1].Preset)
+	require.Equal(t, "user_id", matches[1].Column)
+	require.Empty(t, matches[1].Roles)
+	require.Len(t, matches[1].Policies, 4)
+}
+
+func TestClauseKey_KeepParentheses(t *testing.T) {
+	qualifier := builder.ClauseQualifier{Schema: "public", Table: "projects"}
+
+	// redundant parentheses added by postgres are ignored
+	require.Equal(t,
+		clauseKey("(a = 1 OR b = 2) AND c = 3", qualifier),
+		clauseKey("(((a = 1) OR (b = 2)) AND (c = 3))", qualifier),
+	)
+
+	// parentheses that change precedence are kept
+	require.NotEqual(t,
+		clauseKey("(a = 1 OR b = 2) AND c = 3", qualifier),
+		clauseKey("a = 1 OR (b = 2 AND c = 3)", qualifier),
+	)
+
+	// json operator is left associative, grouping the right operand is a different claim
+	require.NotEqual(t,
+		clauseKey(builder.TenantClaimMatch("org_id", "app_metadata.org_id", "uuid").String(), qualifier),
+		clauseKey("(org_id = ((current_setting('request.jwt.claims'::text))::jsonb -> ('app_metadata'::text ->> 'org_id'::text))::uuid)", qualifier),
+	)
+
+	existing := "((user_id = auth.uid()) OR (slug = 'public'::text)) AND (org_id IS NOT NULL)"
+	local := objects.Policy{Name: "p", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Definition: "user_id = auth.uid() OR (slug = 'public' AND org_id IS NOT NULL)"}
+	remote := objects.Policy{Name: "p", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Definition: existing}
+	require.False(t, samePolicy(local, remote, qualifier))
+}
+
+func TestMatchPresets_TenantNotRecognized(t *testing.T) {
+	table := objects.Table{
+		Schema: "public",
+		Name:   "projects",
+		Columns: []objects.Column{
+			{Name: "id", DataType: string(postgres.BigIntType)},
+			{Name: "user_id", DataType: string(postgres.UuidType)},
+			{Name: "org_id", DataType: string(postgres.UuidType)},
+		},
+	}
+
+	tenantPolicy := func(action, definition string) objects.Policies {
+		return objects.Policies{
+			{Name: "projects_tenant_isolation", Command: objects.PolicyCommandAll, Action: action, Roles: []string{"public"}, Definition: definition, Check: &definition},
+		}
+	}
+
+	// column is taken from definition, not from the first column of table
+	claim := "(org_id = ((current_setting('request.jwt.claims'::text))::jsonb ->> 'org_id'::text))::uuid"
+	matches := MatchPresets(table, tenantPolicy("RESTRICTIVE", claim))
+	require.Len(t, matches, 1)
+	require.Equal(t, "org_id", matches[0].Column)
+
+	for name, definition := range map[string]string{
+		"auth uid":         "(org_id = auth.uid())",
+		"sub query":        "(org_id IN ( SELECT members.org_id FROM members WHERE (members.user_id = auth.uid())))",
+		"unknown column":   "(team_id = ((current_setting('request.jwt.claims'::text))::jsonb ->> 'org_id'::text))::uuid",
+		"parenthesization": "(org_id = ((current_setting('request.jwt.claims'::text))::jsonb -> ('app_metadata'::text ->> 'org_id'::text))::uuid)",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Empty(t, MatchPresets(table, tenantPolicy("RESTRICTIVE", definition)))
+		})
+	}
+
+	t.Run("permissive", func(t *testing.T) {
+		require.Empty(t, MatchPresets(table, tenantPolicy("PERMISSIVE", claim)))
+	})
+}
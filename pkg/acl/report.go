@@ -0,0 +1,549 @@
+package acl
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/postgres/roles"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/constants"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatText     ReportFormat = "text"
+	ReportFormatJSON     ReportFormat = "json"
+	ReportFormatMarkdown ReportFormat = "markdown"
+)
+
+const (
+	ResourceTypeTable  = "table"
+	ResourceTypeBucket = "bucket"
+)
+
+var reportCommands = []raiden.Command{raiden.CommandSelect, raiden.CommandInsert, raiden.CommandUpdate, raiden.CommandDelete}
+
+// Report is effective access of each role to table and bucket
+type Report struct {
+	Roles  []RoleReport  `json:"roles"`
+	Issues []ReportIssue `json:"issues"`
+}
+
+type RoleReport struct {
+	Role string `json:"role"`
+
+	// InheritRoles is all role inherited by the role, directly or through other role
+	InheritRoles []string         `json:"inherit_roles,omitempty"`
+	BypassRls    bool             `json:"bypass_rls"`
+	Resources    []ResourceAccess `json:"resources"`
+}
+
+type ResourceAccess struct {
+	Type       string          `json:"type"`
+	Schema     string          `json:"schema"`
+	Name       string          `json:"name"`
+	RlsEnabled bool            `json:"rls_enabled"`
+	Commands   []CommandAccess `json:"commands"`
+}
+
+// CommandAccess is effective access of command,
+// using and check is combined expression of all applicable policy
+type CommandAccess struct {
+	Command  raiden.Command `json:"command"`
+	Allowed  bool           `json:"allowed"`
+	Using    string         `json:"using,omitempty"`
+	Check    string         `json:"check,omitempty"`
+	Policies []string       `json:"policies,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// ReportIssue is misconfiguration of row level security that is found when building report
+type ReportIssue struct {
+	Type    string `json:"type"`
+	Schema  string `json:"schema"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// ReportOptions filter role in report, all role is reported when Roles is empty
+type ReportOptions struct {
+	Roles []string
+}
+
+// GetReport build effective access report from local state
+func GetReport(opts ReportOptions) (Report, error) {
+	currentState, err := state.Load()
+	if err != nil {
+		return Report{}, err
+	}
+	return BuildReport(currentState, opts), nil
+}
+
+// BuildReport compute effective command of each role to table and bucket,
+// permissive policy is combined with OR and restrictive policy is combined with AND
+// like postgres do when evaluate row level security.
+func BuildReport(st *state.State, opts ReportOptions) Report {
+	report := Report{Roles: []RoleReport{}, Issues: []ReportIssue{}}
+	if st == nil {
+		return report
+	}
+
+	resources := collectReportResources(st)
+	for _, r := range resources {
+		report.Issues = append(report.Issues, r.issues()...)
+	}
+
+	mapRole := make(map[string]objects.Role)
+	for _, rs := range st.Roles {
+		mapRole[rs.Role.Name] = rs.Role
+	}
+
+	for _, name := range reportRoleNames(st, opts) {
+		role, exist := mapRole[name]
+		if !exist {
+			role = objects.Role{Name: name, InheritRole: true}
+		}
+
+		roleReport := RoleReport{
+			Role:         name,
+			InheritRoles: inheritedRoles(role, mapRole),
+			BypassRls:    role.CanBypassRLS,
+			Resources:    []ResourceAccess{},
+		}
+
+		effectiveRoles := append([]string{name}, roleReport.InheritRoles...)
+		for _, r := range resources {
+			access := r.access(effectiveRoles, role.CanBypassRLS)
+			if access.isEmpty() {
+				continue
+			}
+			roleReport.Resources = append(roleReport.Resources, access)
+		}
+		report.Roles = append(report.Roles, roleReport)
+	}
+
+	return report
+}
+
+// reportRoleNames return role in option or user role and api role in state
+func reportRoleNames(st *state.State, opts ReportOptions) []string {
+	if len(opts.Roles) > 0 {
+		return opts.Roles
+	}
+
+	apiRoles := []string{(&roles.Anon{}).Name(), (&roles.Authenticated{}).Name(), (&roles.ServiceRole{}).Name()}
+	names := make([]string, 0)
+	for _, rs := range st.Roles {
+		if rs.IsNative && !slices.Contains(apiRoles, rs.Role.Name) {
+			continue
+		}
+		names = append(names, rs.Role.Name)
+	}
+
+	for _, r := range apiRoles {
+		if !slices.Contains(names, r) {
+			names = append(names, r)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// inheritedRoles return role inherited by role recursively,
+// privilege of inherited role is not used when role is not inherit
+func inheritedRoles(role objects.Role, mapRole map[string]objects.Role) []string {
+	visited := map[string]bool{role.Name: true}
+	result := make([]string, 0)
+
+	var walk func(r objects.Role)
+	walk = func(r objects.Role) {
+		if !r.InheritRole {
+			return
+		}
+
+		for _, ir := range r.InheritRoles {
+			if ir == nil || ir.Name == "" || visited[ir.Name] {
+				continue
+			}
+			visited[ir.Name] = true
+			result = append(result, ir.Name)
+
+			if parent, exist := mapRole[ir.Name]; exist {
+				walk(parent)
+			}
+		}
+	}
+	walk(role)
+
+	sort.Strings(result)
+	return result
+}
+
+// ----- resource -----
+type reportResource struct {
+	Type       string
+	Schema     string
+	Name       string
+	RlsEnabled bool
+	RlsForced  bool
+	IsPublic   bool
+	Policies   objects.Policies
+}
+
+func collectReportResources(st *state.State) []reportResource {
+	resources := make([]reportResource, 0, len(st.Tables)+len(st.Storage))
+	for _, t := range st.Tables {
+		resources = append(resources, reportResource{
+			Type:       ResourceTypeTable,
+			Schema:     t.Table.Schema,
+			Name:       t.Table.Name,
+			RlsEnabled: t.Table.RLSEnabled,
+			RlsForced:  t.Table.RLSForced,
+			Policies:   t.Policies,
+		})
+	}
+
+	for _, s := range st.Storage {
+		resources = append(resources, reportResource{
+			Type:       ResourceTypeBucket,
+			Schema:     constants.DefaultStorageSchema,
+			Name:       s.Storage.Name,
+			RlsEnabled: true,
+			IsPublic:   s.Storage.Public,
+			Policies:   s.Policies,
+		})
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type == ResourceTypeTable
+		}
+		if resources[i].Schema != resources[j].Schema {
+			return resources[i].Schema < resources[j].Schema
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources
+}
+
+func (r reportResource) issues() (issues []ReportIssue) {
+	newIssue := func(message string) ReportIssue {
+		return ReportIssue{Type: r.Type, Schema: r.Schema, Name: r.Name, Message: message}
+	}
+
+	switch {
+	case r.RlsEnabled && len(r.Policies) == 0 && r.Type == ResourceTypeTable:
+		issues = append(issues, newIssue("row level security is enabled without policy, table is only reachable by role that bypass rls"))
+	case r.RlsEnabled && len(r.Policies) == 0 && !r.IsPublic:
+		issues = append(issues, newIssue("bucket doesn't have policy, object is only reachable by role that bypass rls"))
+	case !r.RlsEnabled && len(r.Policies) > 0:
+		issues = append(issues, newIssue("policy is defined but row level security is disabled, policy is not enforced"))
+	}
+
+	if r.RlsForced && !r.RlsEnabled {
+		issues = append(issues, newIssue("force row level security is set but row level security is disabled"))
+	}
+	return
+}
+
+func (r reportResource) access(effectiveRoles []string, bypassRls bool) ResourceAccess {
+	access := ResourceAccess{Type: r.Type, Schema: r.Schema, Name: r.Name, RlsEnabled: r.RlsEnabled}
+	for _, c := range reportCommands {
+		access.Commands = append(access.Commands, r.commandAccess(c, effectiveRoles, bypassRls))
+	}
+	return access
+}
+
+func (r reportResource) commandAccess(command raiden.Command, effectiveRoles []string, bypassRls bool) CommandAccess {
+	ca := CommandAccess{Command: command}
+
+	if !r.RlsEnabled {
+		ca.Allowed, ca.Reason = true, "row level security is disabled"
+		return ca
+	}
+
+	if bypassRls {
+		ca.Allowed, ca.Reason = true, "role bypass row level security"
+		return ca
+	}
+
+	var permissive, restrictive objects.Policies
+	for _, p := range r.Policies {
+		if p.Command != objects.PolicyCommandAll && p.Command != command.ToSupabaseCommand() {
+			continue
+		}
+
+		if !policyAppliesTo(p, effectiveRoles) {
+			continue
+		}
+
+		if p.Action == raiden.AclModeRestrictive.ActionString() {
+			restrictive = append(restrictive, p)
+			continue
+		}
+		permissive = append(permissive, p)
+	}
+
+	if len(permissive) == 0 {
+		if r.IsPublic && command == raiden.CommandSelect {
+			ca.Allowed, ca.Reason = true, "public bucket"
+			return ca
+		}
+		ca.Reason = "no permissive policy"
+		return ca
+	}
+
+	ca.Allowed = true
+	for _, p := range permissive {
+		ca.Policies = append(ca.Policies, p.Name)
+	}
+	for _, p := range restrictive {
+		ca.Policies = append(ca.Policies, p.Name)
+	}
+
+	if command != raiden.CommandInsert {
+		ca.Using = combineExpression(policyUsing(permissive), policyUsing(restrictive))
+	}
+
+	if command == raiden.CommandInsert || command == raiden.CommandUpdate {
+		ca.Check = combineExpression(policyCheck(permissive), policyCheck(restrictive))
+	}
+	return ca
+}
+
+func (a ResourceAccess) isEmpty() bool {
+	for _, c := range a.Commands {
+		if c.Allowed {
+			return false
+		}
+	}
+	return true
+}
+
+func policyAppliesTo(p objects.Policy, effectiveRoles []string) bool {
+	if len(p.Roles) == 0 || slices.Contains(p.Roles, "public") {
+		return true
+	}
+
+	for _, r := range effectiveRoles {
+		if slices.Contains(p.Roles, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyUsing(policies objects.Policies) []string {
+	expressions := make([]string, 0, len(policies))
+	for _, p := range policies {
+		expressions = append(expressions, p.Definition)
+	}
+	return expressions
+}
+
+// policyCheck return check expression of policy, using expression is used when check is not defined
+func policyCheck(policies objects.Policies) []string {
+	expressions := make([]string, 0, len(policies))
+	for _, p := range policies {
+		if p.Check != nil {
+			expressions = append(expressions, *p.Check)
+			continue
+		}
+		expressions = append(expressions, p.Definition)
+	}
+	return expressions
+}
+
+func combineExpression(permissive, restrictive []string) string {
+	expression := joinExpression(permissive, " OR ")
+	if len(restrictive) == 0 {
+		return expression
+	}
+
+	parts := []string{wrapExpression(expression)}
+	for _, r := range restrictive {
+		parts = append(parts, wrapExpression(normalizeExpression(r)))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+func joinExpression(expressions []string, operator string) string {
+	normalized := make([]string, 0, len(expressions))
+	for _, e := range expressions {
+		e = normalizeExpression(e)
+
+		// permissive policy without condition allow all row
+		if e == "true" && operator == " OR " {
+			return e
+		}
+		if !slices.Contains(normalized, e) {
+			normalized = append(normalized, e)
+		}
+	}
+
+	if len(normalized) == 1 {
+		return normalized[0]
+	}
+
+	for i := range normalized {
+		normalized[i] = wrapExpression(normalized[i])
+	}
+	return strings.Join(normalized, operator)
+}
+
+func normalizeExpression(expression string) string {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return "true"
+	}
+	return expression
+}
+
+func wrapExpression(expression string) string {
+	if expression == "true" {
+		return expression
+	}
+	return "(" + expression + ")"
+}
+
+// ----- render -----
+
+// RenderReport render report in text, json or markdown format
+func RenderReport(report Report, format ReportFormat) (string, error) {
+	switch format {
+	case ReportFormatText, "":
+		return renderReportText(report), nil
+	case ReportFormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case ReportFormatMarkdown:
+		return renderReportMarkdown(report), nil
+	default:
+		return "", fmt.Errorf("unsupported report format %s, available format is text, json and markdown", format)
+	}
+}
+
+func renderReportText(report Report) string {
+	var sb strings.Builder
+	for _, r := range report.Roles {
+		sb.WriteString(fmt.Sprintf("Role %s\n", r.Role))
+		if len(r.InheritRoles) > 0 {
+			sb.WriteString(fmt.Sprintf("  inherit : %s\n", strings.Join(r.InheritRoles, ", ")))
+		}
+		if r.BypassRls {
+			sb.WriteString("  bypass row level security\n")
+		}
+
+		if len(r.Resources) == 0 {
+			sb.WriteString("  no access\n")
+		}
+
+		for _, res := range r.Resources {
+			sb.WriteString(fmt.Sprintf("  %s %s.%s\n", res.Type, res.Schema, res.Name))
+			for _, c := range res.Commands {
+				status := "denied"
+				if c.Allowed {
+					status = "allowed"
+				}
+
+				line := fmt.Sprintf("    - %s : %s", c.Command, status)
+				if c.Reason != "" {
+					line += fmt.Sprintf(" (%s)", c.Reason)
+				}
+				sb.WriteString(line + "\n")
+
+				if c.Using != "" {
+					sb.WriteString(fmt.Sprintf("        using : %s\n", c.Using))
+				}
+				if c.Check != "" {
+					sb.WriteString(fmt.Sprintf("        check : %s\n", c.Check))
+				}
+				if len(c.Policies) > 0 {
+					sb.WriteString(fmt.Sprintf("        policies : %s\n", strings.Join(c.Policies, ", ")))
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.Issues) > 0 {
+		sb.WriteString("Issues\n")
+		for _, i := range report.Issues {
+			sb.WriteString(fmt.Sprintf("  - %s %s.%s : %s\n", i.Type, i.Schema, i.Name, i.Message))
+		}
+	}
+
+	return sb.String()
+}
+
+func renderReportMarkdown(report Report) string {
+	var sb strings.Builder
+	sb.WriteString("# Effective Access Report\n")
+
+	for _, r := range report.Roles {
+		sb.WriteString(fmt.Sprintf("\n## Role `%s`\n\n", r.Role))
+		if len(r.InheritRoles) > 0 {
+			sb.WriteString(fmt.Sprintf("Inherit roles : %s\n\n", strings.Join(r.InheritRoles, ", ")))
+		}
+		if r.BypassRls {
+			sb.WriteString("Role bypass row level security\n\n")
+		}
+
+		if len(r.Resources) == 0 {
+			sb.WriteString("No access\n")
+			continue
+		}
+
+		sb.WriteString("| Resource | Command | Allowed | Using | Check | Policies |\n")
+		sb.WriteString("|---|---|---|---|---|---|\n")
+		for _, res := range r.Resources {
+			for _, c := range res.Commands {
+				allowed := "no"
+				if c.Allowed {
+					allowed = "yes"
+				}
+				if c.Reason != "" {
+					allowed += " (" + c.Reason + ")"
+				}
+
+				sb.WriteString(fmt.Sprintf("| %s `%s.%s` | %s | %s | %s | %s | %s |\n",
+					res.Type, res.Schema, res.Name, c.Command, allowed,
+					markdownCode(c.Using), markdownCode(c.Check), escapeMarkdown(strings.Join(c.Policies, ", ")),
+				))
+			}
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		sb.WriteString("\n## Issues\n\n")
+		sb.WriteString("| Resource | Issue |\n")
+		sb.WriteString("|---|---|\n")
+		for _, i := range report.Issues {
+			sb.WriteString(fmt.Sprintf("| %s `%s.%s` | %s |\n", i.Type, i.Schema, i.Name, escapeMarkdown(i.Message)))
+		}
+	}
+
+	return sb.String()
+}
+
+func markdownCode(expression string) string {
+	if expression == "" {
+		return ""
+	}
+	return "`" + escapeMarkdown(expression) + "`"
+}
+
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	return strings.ReplaceAll(value, "|", "\\|")
+}
@@ -0,0 +1,168 @@
+package acl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/require"
+)
+
+func reportState() *state.State {
+	ownerCheck := "author_id = auth.uid()"
+	return &state.State{
+		Roles: []state.RoleState{
+			{Role: objects.Role{Name: "editor", InheritRole: true, InheritRoles: []*objects.Role{{Name: "reviewer"}}}},
+			{Role: objects.Role{Name: "reviewer", InheritRole: true}},
+			{Role: objects.Role{Name: "auditor", InheritRole: false, InheritRoles: []*objects.Role{{Name: "reviewer"}}}},
+			{Role: objects.Role{Name: "service_role", CanBypassRLS: true}, IsNative: true},
+			{Role: objects.Role{Name: "postgres"}, IsNative: true},
+		},
+		Tables: []state.TableState{
+			{
+				Table: objects.Table{Schema: "public", Name: "notes", RLSEnabled: true},
+				Policies: []objects.Policy{
+					{Name: "notes_read", Action: "PERMISSIVE", Roles: []string{"reviewer"}, Command: objects.PolicyCommandSelect, Definition: "published = true"},
+					{Name: "notes_owner", Action: "PERMISSIVE", Roles: []string{"editor"}, Command: objects.PolicyCommandAll, Definition: "author_id = auth.uid()", Check: &ownerCheck},
+					{Name: "notes_tenant", Action: "RESTRICTIVE", Command: objects.PolicyCommandAll, Definition: "org_id = 1"},
+				},
+			},
+			{Table: objects.Table{Schema: "public", Name: "secrets", RLSEnabled: true}},
+			{
+				Table:    objects.Table{Schema: "public", Name: "logs", RLSForced: true},
+				Policies: []objects.Policy{{Name: "logs_read", Action: "PERMISSIVE", Command: objects.PolicyCommandSelect, Definition: "true"}},
+			},
+		},
+		Storage: []state.StorageState{
+			{Storage: objects.Bucket{Name: "avatars", Public: true}},
+		},
+	}
+}
+
+func findRoleReport(t *testing.T, report Report, role string) RoleReport {
+	for _, r := range report.Roles {
+		if r.Role == role {
+			return r
+		}
+	}
+	require.Failf(t, "role not found", "role %s", role)
+	return RoleReport{}
+}
+
+func findCommandAccess(t *testing.T, rr RoleReport, name string, command raiden.Command) CommandAccess {
+	for _, res := range rr.Resources {
+		if res.Name != name {
+			continue
+		}
+		for _, c := range res.Commands {
+			if c.Command == command {
+				return c
+			}
+		}
+	}
+	return CommandAccess{Command: command}
+}
+
+func TestBuildReport(t *testing.T) {
+	report := BuildReport(reportState(), ReportOptions{})
+
+	names := make([]string, 0)
+	for _, r := range report.Roles {
+		names = append(names, r.Role)
+	}
+	require.Equal(t, []string{"anon", "auditor", "authenticated", "editor", "reviewer", "service_role"}, names)
+
+	editor := findRoleReport(t, report, "editor")
+	require.Equal(t, []string{"reviewer"}, editor.InheritRoles)
+
+	selectAccess := findCommandAccess(t, editor, "notes", raiden.CommandSelect)
+	require.True(t, selectAccess.Allowed)
+	require.Equal(t, "((published = true) OR (author_id = auth.uid())) AND (org_id = 1)", selectAccess.Using)
+	require.Equal(t, []string{"notes_read", "notes_owner", "notes_tenant"}, selectAccess.Policies)
+
+	insertAccess := findCommandAccess(t, editor, "notes", raiden.CommandInsert)
+	require.True(t, insertAccess.Allowed)
+	require.Empty(t, insertAccess.Using)
+	require.Equal(t, "(author_id = auth.uid()) AND (org_id = 1)", insertAccess.Check)
+
+	updateAccess := findCommandAccess(t, editor, "notes", raiden.CommandUpdate)
+	require.Equal(t, "(author_id = auth.uid()) AND (org_id = 1)", updateAccess.Using)
+	require.Equal(t, "(author_id = auth.uid()) AND (org_id = 1)", updateAccess.Check)
+
+	// auditor doesn't inherit privilege of reviewer
+	auditor := findRoleReport(t, report, "auditor")
+	require.Equal(t, []string{}, auditor.InheritRoles)
+	require.False(t, findCommandAccess(t, auditor, "notes", raiden.CommandSelect).Allowed)
+
+	reviewer := findRoleReport(t, report, "reviewer")
+	require.True(t, findCommandAccess(t, reviewer, "notes", raiden.CommandSelect).Allowed)
+	require.False(t, findCommandAccess(t, reviewer, "notes", raiden.CommandDelete).Allowed)
+
+	logAccess := findCommandAccess(t, reviewer, "logs", raiden.CommandDelete)
+	require.True(t, logAccess.Allowed)
+	require.Equal(t, "row level security is disabled", logAccess.Reason)
+
+	anon := findRoleReport(t, report, "anon")
+	avatarAccess := findCommandAccess(t, anon, "avatars", raiden.CommandSelect)
+	require.True(t, avatarAccess.Allowed)
+	require.Equal(t, "public bucket", avatarAccess.Reason)
+	require.False(t, findCommandAccess(t, anon, "avatars", raiden.CommandInsert).Allowed)
+
+	serviceRole := findRoleReport(t, report, "service_role")
+	require.True(t, serviceRole.BypassRls)
+	require.True(t, findCommandAccess(t, serviceRole, "secrets", raiden.CommandDelete).Allowed)
+
+	require.Equal(t, []ReportIssue{
+		{Type: ResourceTypeTable, Schema: "public", Name: "logs", Message: "policy is defined but row level security is disabled, policy is not enforced"},
+		{Type: ResourceTypeTable, Schema: "public", Name: "logs", Message: "force row level security is set but row level security is disabled"},
+		{Type: ResourceTypeTable, Schema: "public", Name: "secrets", Message: "row level security is enabled without policy, table is only reachable by role that bypass rls"},
+	}, report.Issues)
+}
+
+func TestBuildReport_FilterRole(t *testing.T) {
+	report := BuildReport(reportState(), ReportOptions{Roles: []string{"reviewer"}})
+	require.Len(t, report.Roles, 1)
+	require.Equal(t, "reviewer", report.Roles[0].Role)
+
+	report = BuildReport(nil, ReportOptions{})
+	require.Empty(t, report.Roles)
+	require.Empty(t, report.Issues)
+}
+
+func TestRenderReport(t *testing.T) {
+	report := BuildReport(reportState(), ReportOptions{Roles: []string{"editor"}})
+
+	text, err := RenderReport(report, ReportFormatText)
+	require.NoError(t, err)
+	require.Contains(t, text, "Role editor\n")
+	require.Contains(t, text, "  inherit : reviewer\n")
+	require.Contains(t, text, "  table public.notes\n")
+	require.Contains(t, text, "    - INSERT : allowed\n")
+	require.Contains(t, text, "        check : (author_id = auth.uid()) AND (org_id = 1)\n")
+	require.Contains(t, text, "Issues\n")
+
+	md, err := RenderReport(report, ReportFormatMarkdown)
+	require.NoError(t, err)
+	require.Contains(t, md, "## Role `editor`")
+	require.Contains(t, md, "| table `public.notes` | DELETE | yes | `(author_id = auth.uid()) AND (org_id = 1)` |  | notes_owner, notes_tenant |")
+	require.Contains(t, md, "## Issues")
+
+	data, err := RenderReport(report, ReportFormatJSON)
+	require.NoError(t, err)
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal([]byte(data), &decoded))
+	require.Equal(t, report, decoded)
+
+	_, err = RenderReport(report, "yaml")
+	require.Error(t, err)
+}
+
+func TestCombineExpression(t *testing.T) {
+	require.Equal(t, "true", combineExpression([]string{"a = 1", ""}, nil))
+	require.Equal(t, "a = 1", combineExpression([]string{"a = 1", " a = 1 "}, nil))
+	require.Equal(t, "true AND (b = 2)", combineExpression([]string{"true"}, []string{"b = 2"}))
+	require.Equal(t, `a \| b`, escapeMarkdown("a | b"))
+}
@@ -15,7 +15,9 @@ type ClauseQualifier struct {
 
 var (
 	identifierParenPattern = regexp.MustCompile(`\(("?[a-zA-Z_][a-zA-Z0-9_\.]*"?)\)`)
+	callParenPattern       = regexp.MustCompile(`\(([a-zA-Z_][a-zA-Z0-9_\.]*\(\))\)`)
 	typeCastPattern        = regexp.MustCompile(`::("?[a-zA-Z_][a-zA-Z0-9_\.]*"?)`)
+	subquerySpacePattern   = regexp.MustCompile(`(?i)\(\s*SELECT\s`)
 )
 
 func NormalizeClauseSQL(sql string, qualifiers ...ClauseQualifier) string {
@@ -31,10 +33,38 @@ func UnmarshalClause(sql string, qualifiers ...ClauseQualifier) (Clause, string,
 	if strings.TrimSpace(normalized) == "" {
 		return Clause(""), "st.Clause(\"\")", true
 	}
-	clause, code, ok := parseClause(normalized)
+	clause, code, ok := parseScopedClause(normalized, clauseScope{outer: outerTable(qualifiers...), storage: isStorageObjectScope(qualifiers...)})
 	return clause, code, ok
 }
 
+// clauseScope is table context of parsed clause, unqualified column inside sub query
+// refer to the outer (policy) table because postgres qualify every column of sub query
+// storage mark clause of storage.objects policy, so storage helper is used when possible
+type clauseScope struct {
+	outer   string
+	inner   string
+	storage bool
+}
+
+func (s clauseScope) qualify(ref string) (string, bool) {
+	if s.inner == "" || strings.Contains(ref, ".") {
+		return ref, true
+	}
+	if s.outer == "" {
+		return "", false
+	}
+	return s.outer + "." + ref, true
+}
+
+func outerTable(qualifiers ...ClauseQualifier) string {
+	for _, q := range qualifiers {
+		if table := strings.TrimSpace(q.Table); table != "" {
+			return table
+		}
+	}
+	return ""
+}
+
 func normalizeClause(expr string, qualifiers ...ClauseQualifier) string {
 	trimmed := strings.TrimSpace(expr)
 	for len(trimmed) >= 2 && trimmed[0] == '(' && trimmed[len(trimmed)-1] == ')' && enclosedByOuterParentheses(trimmed) {
@@ -43,8 +73,10 @@ func normalizeClause(expr string, qualifiers ...ClauseQualifier) string {
 	trimmed = strings.ReplaceAll(trimmed, "\"", "")
 	trimmed = typeCastPattern.ReplaceAllString(trimmed, "")
 	trimmed = collapseWhitespace(trimmed)
+	trimmed = subquerySpacePattern.ReplaceAllString(trimmed, "(SELECT ")
 	for {
-		next := identifierParenPattern.ReplaceAllString(trimmed, "$1")
+		next := stripRedundantParens(trimmed, identifierParenPattern)
+		next = stripRedundantParens(next, callParenPattern)
 		if next == trimmed {
 			break
 		}
@@ -55,19 +87,49 @@ func normalizeClause(expr string, qualifiers ...ClauseQualifier) string {
 	return canonicalizeSimpleEquality(trimmed)
 }
 
+// stripRedundantParens remove parentheses around identifier or function call without argument,
+// parentheses of function call like `storage.foldername(name)` is kept
+func stripRedundantParens(expr string, pattern *regexp.Regexp) string {
+	matches := pattern.FindAllStringSubmatchIndex(expr, -1)
+	if len(matches) == 0 {
+		return expr
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m[0] > 0 && isIdentifierChar(rune(expr[m[0]-1])) {
+			continue
+		}
+		sb.WriteString(expr[last:m[0]])
+		sb.WriteString(expr[m[2]:m[3]])
+		last = m[1]
+	}
+	sb.WriteString(expr[last:])
+	return sb.String()
+}
+
+func isIdentifierChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
 func parseClause(expr string) (Clause, string, bool) {
+	return parseScopedClause(expr, clauseScope{})
+}
+
+func parseScopedClause(expr string, scope clauseScope) (Clause, string, bool) {
 	expr = strings.TrimSpace(expr)
 	if expr == "" {
 		return Clause(""), "st.Clause(\"\")", true
 	}
 	if enclosedByOuterParentheses(expr) {
-		return parseClause(strings.TrimSpace(expr[1 : len(expr)-1]))
+		return parseScopedClause(strings.TrimSpace(expr[1:len(expr)-1]), scope)
 	}
 	if parts := splitByLogical(expr, "OR"); parts != nil {
 		clauses := make([]Clause, 0, len(parts))
 		codes := make([]string, 0, len(parts))
 		for _, part := range parts {
-			cl, code, ok := parseClause(part)
+			cl, code, ok := parseScopedClause(part, scope)
 			if !ok {
 				return "", "", false
 			}
@@ -83,7 +145,7 @@ func parseClause(expr string) (Clause, string, bool) {
 		clauses := make([]Clause, 0, len(parts))
 		codes := make([]string, 0, len(parts))
 		for _, part := range parts {
-			cl, code, ok := parseClause(part)
+			cl, code, ok := parseScopedClause(part, scope)
 			if !ok {
 				return "", "", false
 			}
@@ -98,19 +160,50 @@ func parseClause(expr string) (Clause, string, bool) {
 	upper := strings.ToUpper(expr)
 	if strings.HasPrefix(upper, "NOT ") {
 		sub := strings.TrimSpace(expr[3:])
-		cl, code, ok := parseClause(sub)
+		cl, code, ok := parseScopedClause(sub, scope)
 		if !ok {
 			return "", "", false
 		}
 		return Not(cl), "st.Not(" + code + ")", true
 	}
+	if strings.HasPrefix(upper, "EXISTS") {
+		if sub, isSubquery := subquerySQL(expr[len("EXISTS"):]); isSubquery {
+			q, code, ok := parseSelectQuery(sub, scope)
+			if !ok {
+				return Clause(expr), fmt.Sprintf("st.Clause(%q)", expr), false
+			}
+			return ExistsQuery(q), "st.ExistsQuery(" + code + ")", true
+		}
+	}
+	if parts := splitByLogical(expr, "IN"); len(parts) == 2 {
+		left, negate := parts[0], false
+		if strings.HasSuffix(strings.ToUpper(left), " NOT") {
+			left, negate = strings.TrimSpace(left[:len(left)-len(" NOT")]), true
+		}
+		if sub, isSubquery := subquerySQL(parts[1]); isSubquery && isColumnReference(left) {
+			col, colOk := scope.qualify(left)
+			q, code, ok := parseSelectQuery(sub, scope)
+			if !colOk || !ok {
+				return Clause(expr), fmt.Sprintf("st.Clause(%q)", expr), false
+			}
+			if negate {
+				return NotInQuery(col, q), fmt.Sprintf("st.NotInQuery(%q, %s)", col, code), true
+			}
+			return InQuery(col, q), fmt.Sprintf("st.InQuery(%q, %s)", col, code), true
+		}
+	}
+	if scope.storage {
+		if cl, code, ok := parseStorageClause(expr); ok {
+			return cl, code, true
+		}
+	}
 	if left, right, ok := splitComparison(expr, "="); ok {
-		return buildEqualityClause(left, right)
+		return buildEqualityClause(left, right, scope)
 	}
 	return Clause(expr), fmt.Sprintf("st.Clause(%q)", expr), false
 }
 
-func buildEqualityClause(left, right string) (Clause, string, bool) {
+func buildEqualityClause(left, right string, scope clauseScope) (Clause, string, bool) {
 	left = strings.TrimSpace(left)
 	right = strings.TrimSpace(right)
 	leftIsColumn := isColumnReference(left)
@@ -118,26 +211,177 @@ func buildEqualityClause(left, right string) (Clause, string, bool) {
 
 	switch {
 	case leftIsColumn && !rightIsColumn:
-		rhsExp, rhsCode, ok := operandToBuilderExp(right, true)
-		if !ok {
-			return Clause(""), "", false
-		}
-		return Eq(left, rhsExp), fmt.Sprintf("st.Eq(%q, %s)", left, rhsCode), true
 	case !leftIsColumn && rightIsColumn:
-		lhsExp, lhsCode, ok := operandToBuilderExp(left, true)
-		if !ok {
-			return Clause(""), "", false
-		}
-		return Eq(right, lhsExp), fmt.Sprintf("st.Eq(%q, %s)", right, lhsCode), true
+		left, right = right, left
 	case leftIsColumn && rightIsColumn:
-		rhsExp, rhsCode, ok := operandToBuilderExp(right, true)
-		if !ok {
-			return Clause(""), "", false
-		}
-		return Eq(left, rhsExp), fmt.Sprintf("st.Eq(%q, %s)", left, rhsCode), true
 	default:
 		return Clause(""), "", false
 	}
+
+	col, ok := scope.qualify(left)
+	if !ok {
+		return Clause(""), "", false
+	}
+
+	rhsExp, rhsCode, ok := scopedOperand(right, scope)
+	if !ok {
+		return Clause(""), "", false
+	}
+	return Eq(col, rhsExp), fmt.Sprintf("st.Eq(%q, %s)", col, rhsCode), true
+}
+
+// scopedOperand convert operand into builder expression, sub query is converted into Subquery
+// and column inside sub query is qualified with outer table
+func scopedOperand(operand string, scope clauseScope) (Exp, string, bool) {
+	if sub, isSubquery := subquerySQL(operand); isSubquery {
+		q, code, ok := parseSelectQuery(sub, scope)
+		if !ok {
+			return Exp(""), "", false
+		}
+		return Subquery(q), "st.Subquery(" + code + ")", true
+	}
+
+	exp, code, ok := operandToBuilderExp(operand, true)
+	if !ok || !strings.HasPrefix(code, "st.Ident(") {
+		return exp, code, ok
+	}
+
+	ident, ok := scope.qualify(strings.TrimSpace(operand))
+	if !ok {
+		return Exp(""), "", false
+	}
+	return Ident(ident), fmt.Sprintf("st.Ident(%q)", ident), true
+}
+
+// subquerySQL return select statement of parenthesized sub query like `( SELECT 1 FROM t )`
+func subquerySQL(expr string) (string, bool) {
+	expr = strings.TrimSpace(expr)
+	if !enclosedByOuterParentheses(expr) {
+		return "", false
+	}
+	sub := strings.TrimSpace(expr[1 : len(expr)-1])
+	if !strings.HasPrefix(strings.ToUpper(sub), "SELECT ") {
+		return "", false
+	}
+	return sub, true
+}
+
+// parseSelectQuery parse single table select statement produced by SelectQuery,
+// select with join, group or other clause is not supported
+func parseSelectQuery(sql string, scope clauseScope) (*SelectQuery, string, bool) {
+	body := strings.TrimSpace(sql)[len("SELECT "):]
+
+	fromIndex := indexTopLevelKeyword(body, "FROM")
+	if fromIndex < 0 {
+		return nil, "", false
+	}
+	columnsSQL := strings.TrimSpace(body[:fromIndex])
+	rest := strings.TrimSpace(body[fromIndex+len(" FROM "):])
+
+	limit := 0
+	if i := indexTopLevelKeyword(rest, "LIMIT"); i >= 0 {
+		n, err := strconv.Atoi(strings.TrimSpace(rest[i+len(" LIMIT "):]))
+		if err != nil {
+			return nil, "", false
+		}
+		limit, rest = n, strings.TrimSpace(rest[:i])
+	}
+
+	whereSQL := ""
+	if i := indexTopLevelKeyword(rest, "WHERE"); i >= 0 {
+		whereSQL, rest = strings.TrimSpace(rest[i+len(" WHERE "):]), strings.TrimSpace(rest[:i])
+	}
+
+	source := strings.Fields(rest)
+	if len(source) == 3 && strings.EqualFold(source[1], "AS") {
+		source = []string{source[0], source[2]}
+	}
+	if len(source) == 0 || len(source) > 2 || !isColumnReference(source[0]) || strings.ContainsAny(rest, ",()") {
+		return nil, "", false
+	}
+
+	q := SelectFrom(source[0])
+	code := fmt.Sprintf("st.SelectFrom(%q", source[0])
+	if columnsSQL != "1" {
+		for _, c := range splitFunctionArgs(columnsSQL) {
+			if !isColumnReference(c) {
+				return nil, "", false
+			}
+			q.columns = append(q.columns, c)
+			code += fmt.Sprintf(", %q", c)
+		}
+	}
+	code += ")"
+
+	if len(source) == 2 {
+		if !isColumnReference(source[1]) || strings.Contains(source[1], ".") {
+			return nil, "", false
+		}
+		q.As(source[1])
+		code += fmt.Sprintf(".As(%q)", source[1])
+	}
+
+	if whereSQL != "" {
+		for enclosedByOuterParentheses(whereSQL) {
+			whereSQL = strings.TrimSpace(whereSQL[1 : len(whereSQL)-1])
+		}
+		parts := splitByLogical(whereSQL, "AND")
+		if parts == nil {
+			parts = []string{whereSQL}
+		}
+
+		inner := clauseScope{outer: scope.outer, inner: q.ref()}
+		clauses := make([]Clause, 0, len(parts))
+		codes := make([]string, 0, len(parts))
+		for _, part := range parts {
+			cl, partCode, ok := parseScopedClause(part, inner)
+			if !ok {
+				return nil, "", false
+			}
+			clauses = append(clauses, cl)
+			codes = append(codes, partCode)
+		}
+		q.where = clauses
+		code += ".Where(" + strings.Join(codes, ", ") + ")"
+	}
+
+	if limit > 0 {
+		q.Limit(limit)
+		code += fmt.Sprintf(".Limit(%d)", limit)
+	}
+	return q, code, true
+}
+
+// indexTopLevelKeyword return index of ` keyword ` outside parentheses and string literal
+func indexTopLevelKeyword(expr, keyword string) int {
+	upper := strings.ToUpper(expr)
+	op := " " + keyword + " "
+	depth := 0
+	inString := false
+	for i := 0; i < len(expr); i++ {
+		ch := expr[i]
+		if ch == '\'' {
+			if inString && i+1 < len(expr) && expr[i+1] == '\'' {
+				i++
+				continue
+			}
+			inString = !inString
+			continue
+		}
+		if inString {
+			continue
+		}
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && i+len(op) <= len(expr) && upper[i:i+len(op)] == op {
+			return i
+		}
+	}
+	return -1
 }
 
 func operandToBuilderExp(operand string, allowIdentifier bool) (Exp, string, bool) {
@@ -195,20 +439,22 @@ func removeQualifiers(expr string, qualifiers ...ClauseQualifier) string {
 		}
 		patterns := []string{table + "."}
 		if schema != "" {
-			patterns = append(patterns, schema+"."+table+".")
-			patterns = append(patterns, schema+".")
+			patterns = []string{schema + "." + table + ".", table + ".", schema + "."}
 		}
+
+		// qualifier is only removed from start of identifier, so table of other schema like `crm.projects.id` is kept
 		for _, p := range patterns {
-			re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(p))
-			result = re.ReplaceAllString(result, "")
+			re := regexp.MustCompile(`(?i)(^|[^\w.])` + regexp.QuoteMeta(p))
+			result = re.ReplaceAllString(result, "${1}")
 		}
 	}
 	return result
 }
 
 func canonicalizeSimpleEquality(expr string) string {
-	eqIndex := strings.Index(expr, "=")
-	if eqIndex <= 0 {
+	// only equality outside parentheses is canonicalized, so sub query is kept as is
+	lhs, rhs, found := splitComparison(expr, "=")
+	if !found {
 		return expr
 	}
 	if strings.Contains(expr, " AND ") || strings.Contains(expr, " OR ") || strings.Contains(expr, " NOT ") {
@@ -217,8 +463,6 @@ func canonicalizeSimpleEquality(expr string) string {
 	if strings.ContainsAny(expr, "<>!") {
 		return expr
 	}
-	lhs := strings.TrimSpace(expr[:eqIndex])
-	rhs := strings.TrimSpace(expr[eqIndex+1:])
 	if lhs == "" || rhs == "" {
 		return expr
 	}
@@ -0,0 +1,1773 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Offline evaluator
+// ----------------------------------------------------------------------------
+//
+// Evaluate interprets clause produced by this package against a row and simulated
+// session, so policy can be unit tested without database. It covers the SQL subset
+// emitted by the DSL (comparison, logic, LIKE, IN, BETWEEN, arrays, JSONB operators,
+// casts, auth.* and current_setting); EXISTS is delegated to EvalEnv.Exists.
+
+// EvalEnv is simulated session and row of clause evaluation
+type EvalEnv struct {
+	// Row is column value of evaluated row, struct value is converted through its json tag
+	Row map[string]any
+
+	// Claims is jwt claims of request, used by auth.uid(), auth.jwt() and request.jwt.claims setting
+	Claims map[string]any
+
+	// Role is database role returned by current_user, default is role claim
+	Role string
+
+	// Settings override value returned by current_setting
+	Settings map[string]string
+
+	// Now is value of now(), default is current time
+	Now time.Time
+
+	// Exists evaluate EXISTS sub query, error is returned when it is not set
+	Exists func(query string, env EvalEnv) (bool, error)
+}
+
+// Evaluate return true when clause is satisfied, NULL result is treated as false
+// like row level security does.
+func Evaluate(c Clause, env EvalEnv) (bool, error) {
+	if c.IsEmpty() {
+		return true, nil
+	}
+
+	node, err := parseEvalExpression(c.String())
+	if err != nil {
+		return false, err
+	}
+
+	value, err := node.eval(&env)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	}
+	return false, fmt.Errorf("clause %q return %T instead of boolean", c.String(), value)
+}
+
+// RowFromModel convert model value into evaluated row keyed by column name,
+// relation (join tag), Acl and Metadata field is skipped
+func RowFromModel(model any) map[string]any {
+	row := make(map[string]any)
+	if m, ok := model.(map[string]any); ok {
+		for k, v := range m {
+			row[k] = normalizeEvalJSON(v)
+		}
+		return row
+	}
+
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return row
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return row
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || sf.Tag.Get("json") == "-" || sf.Tag.Get("join") != "" || sf.Name == "Acl" || sf.Name == "Metadata" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if sf.Anonymous && fv.Kind() == reflect.Struct && sf.Tag.Get("column") == "" {
+			for k, item := range RowFromModel(fv.Interface()) {
+				row[k] = item
+			}
+			continue
+		}
+
+		if (fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface) && fv.IsNil() {
+			row[columnNameFromStructField(sf)] = nil
+			continue
+		}
+		row[columnNameFromStructField(sf)] = normalizeEvalJSON(fv.Interface())
+	}
+	return row
+}
+
+// ----------------------------------------------------------------------------
+// Tokenizer
+// ----------------------------------------------------------------------------
+
+type evalTokenKind int
+
+const (
+	evalTokenEOF evalTokenKind = iota
+	evalTokenIdent
+	evalTokenQuotedIdent
+	evalTokenString
+	evalTokenNumber
+	evalTokenOperator
+)
+
+type evalToken struct {
+	kind  evalTokenKind
+	value string
+}
+
+var evalOperators = []string{
+	"->>", "#>>", "!~*", "::", "->", "#>", "?|", "?&", "@>", "<@", "&&", "<>", "!=", "<=", ">=", "~*", "!~", "||", "@@",
+	"=", "<", ">", "~", "?", "(", ")", "[", "]", ",", ".", "*", "+", "-", "/", "%",
+}
+
+func tokenizeEval(input string) ([]evalToken, error) {
+	tokens := make([]evalToken, 0)
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string literal in %q", input)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenString, value: sb.String()})
+		case r == '"':
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '"' {
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						sb.WriteRune('"')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quoted identifier in %q", input)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenQuotedIdent, value: sb.String()})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E') {
+				i++
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenNumber, value: string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenIdent, value: string(runes[start:i])})
+		default:
+			matched := ""
+			for _, op := range evalOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				return nil, fmt.Errorf("unexpected character %q in %q", r, input)
+			}
+			tokens = append(tokens, evalToken{kind: evalTokenOperator, value: matched})
+			i += len([]rune(matched))
+		}
+	}
+	return append(tokens, evalToken{kind: evalTokenEOF}), nil
+}
+
+// ----------------------------------------------------------------------------
+// Parser
+// ----------------------------------------------------------------------------
+
+type evalNode interface {
+	eval(env *EvalEnv) (any, error)
+}
+
+type evalParser struct {
+	input  string
+	tokens []evalToken
+	pos    int
+}
+
+func parseEvalExpression(input string) (evalNode, error) {
+	tokens, err := tokenizeEval(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &evalParser{input: input, tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != evalTokenEOF {
+		return nil, p.errorf("unexpected %q", p.peek().value)
+	}
+	return node, nil
+}
+
+func (p *evalParser) peek() evalToken { return p.tokens[p.pos] }
+
+func (p *evalParser) peekAt(offset int) evalToken {
+	if p.pos+offset >= len(p.tokens) {
+		return evalToken{kind: evalTokenEOF}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *evalParser) next() evalToken {
+	t := p.tokens[p.pos]
+	if t.kind != evalTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *evalParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == evalTokenIdent && strings.EqualFold(t.value, keyword)
+}
+
+func (p *evalParser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *evalParser) isOperator(op string) bool {
+	t := p.peek()
+	return t.kind == evalTokenOperator && t.value == op
+}
+
+func (p *evalParser) acceptOperator(op string) bool {
+	if p.isOperator(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *evalParser) expectOperator(op string) error {
+	if !p.acceptOperator(op) {
+		return p.errorf("expected %q but found %q", op, p.peek().value)
+	}
+	return nil
+}
+
+func (p *evalParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("failed to parse clause %q : %s", p.input, fmt.Sprintf(format, args...))
+}
+
+func (p *evalParser) parseOr() (evalNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = evalLogic{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *evalParser) parseAnd() (evalNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = evalLogic{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *evalParser) parseNot() (evalNode, error) {
+	if p.acceptKeyword("NOT") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return evalNot{operand: operand}, nil
+	}
+	return p.parseIs()
+}
+
+func (p *evalParser) parseIs() (evalNode, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("IS") {
+		negate := p.acceptKeyword("NOT")
+		switch {
+		case p.acceptKeyword("NULL"):
+			left = evalIs{operand: left, target: "NULL", negate: negate}
+		case p.acceptKeyword("TRUE"):
+			left = evalIs{operand: left, target: "TRUE", negate: negate}
+		case p.acceptKeyword("FALSE"):
+			left = evalIs{operand: left, target: "FALSE", negate: negate}
+		case p.acceptKeyword("DISTINCT"):
+			if !p.acceptKeyword("FROM") {
+				return nil, p.errorf("expected FROM after IS DISTINCT")
+			}
+			right, err := p.parseComparison()
+			if err != nil {
+				return nil, err
+			}
+			left = evalDistinct{left: left, right: right, negate: negate}
+		default:
+			return nil, p.errorf("unsupported IS %q", p.peek().value)
+		}
+	}
+	return left, nil
+}
+
+var evalComparisonOperators = map[string]bool{"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func (p *evalParser) parseComparison() (evalNode, error) {
+	left, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.kind != evalTokenOperator || !evalComparisonOperators[t.value] {
+			return left, nil
+		}
+		p.next()
+
+		if p.isKeyword("ANY") || p.isKeyword("SOME") || p.isKeyword("ALL") {
+			quantifier := strings.ToUpper(p.next().value)
+			if err := p.expectOperator("("); err != nil {
+				return nil, err
+			}
+			array, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			left = evalQuantified{op: t.value, left: left, array: array, all: quantifier == "ALL"}
+			continue
+		}
+
+		right, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+		left = evalBinary{op: t.value, left: left, right: right}
+	}
+}
+
+func (p *evalParser) parsePattern() (evalNode, error) {
+	left, err := p.parseOther()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		negate := false
+		if p.isKeyword("NOT") && (p.peekAt(1).kind == evalTokenIdent) {
+			switch strings.ToUpper(p.peekAt(1).value) {
+			case "LIKE", "ILIKE", "IN", "BETWEEN":
+				p.next()
+				negate = true
+			default:
+				return left, nil
+			}
+		}
+
+		switch {
+		case p.acceptKeyword("LIKE"), p.isKeyword("ILIKE"):
+			insensitive := p.acceptKeyword("ILIKE")
+			pattern, err := p.parseOther()
+			if err != nil {
+				return nil, err
+			}
+			escape := evalNode(evalLiteral{value: `\`})
+			if p.acceptKeyword("ESCAPE") {
+				if escape, err = p.parseOther(); err != nil {
+					return nil, err
+				}
+			}
+			left = evalLike{operand: left, pattern: pattern, escape: escape, insensitive: insensitive, negate: negate}
+		case p.acceptKeyword("IN"):
+			if err := p.expectOperator("("); err != nil {
+				return nil, err
+			}
+			items := make([]evalNode, 0)
+			for {
+				item, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+				if !p.acceptOperator(",") {
+					break
+				}
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			left = evalIn{operand: left, items: items, negate: negate}
+		case p.acceptKeyword("BETWEEN"):
+			low, err := p.parseOther()
+			if err != nil {
+				return nil, err
+			}
+			if !p.acceptKeyword("AND") {
+				return nil, p.errorf("expected AND in BETWEEN")
+			}
+			high, err := p.parseOther()
+			if err != nil {
+				return nil, err
+			}
+			left = evalBetween{operand: left, low: low, high: high, negate: negate}
+		default:
+			return left, nil
+		}
+	}
+}
+
+var evalOtherOperators = map[string]bool{
+	"->": true, "->>": true, "#>": true, "#>>": true, "?": true, "?|": true, "?&": true,
+	"@>": true, "<@": true, "&&": true, "~": true, "~*": true, "!~": true, "!~*": true, "||": true, "@@": true,
+}
+
+func (p *evalParser) parseOther() (evalNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		if t.kind != evalTokenOperator || !evalOtherOperators[t.value] {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		left = evalBinary{op: t.value, left: left, right: right}
+	}
+}
+
+func (p *evalParser) parseAdditive() (evalNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("+") || p.isOperator("-") {
+		op := p.next().value
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = evalBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *evalParser) parseMultiplicative() (evalNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("*") || p.isOperator("/") || p.isOperator("%") {
+		op := p.next().value
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = evalBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *evalParser) parseUnary() (evalNode, error) {
+	if p.acceptOperator("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return evalBinary{op: "-", left: evalLiteral{value: float64(0)}, right: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *evalParser) parsePostfix() (evalNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.acceptOperator("::"):
+			typ, err := p.parseTypeName()
+			if err != nil {
+				return nil, err
+			}
+			node = evalCast{operand: node, typ: typ}
+		case p.acceptOperator("["):
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			node = evalSubscript{operand: node, index: index}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *evalParser) parseTypeName() (string, error) {
+	t := p.next()
+	if t.kind != evalTokenIdent && t.kind != evalTokenQuotedIdent {
+		return "", p.errorf("expected type name but found %q", t.value)
+	}
+
+	name := strings.ToLower(t.value)
+	for p.acceptOperator(".") {
+		name = strings.ToLower(p.next().value)
+	}
+
+	// multi word type like `timestamp with time zone` or `character varying`
+	for p.isKeyword("with") || p.isKeyword("without") || p.isKeyword("time") || p.isKeyword("zone") || p.isKeyword("varying") || p.isKeyword("precision") {
+		name += " " + strings.ToLower(p.next().value)
+	}
+
+	if p.acceptOperator("[") {
+		if err := p.expectOperator("]"); err != nil {
+			return "", err
+		}
+		name += "[]"
+	}
+	return name, nil
+}
+
+func (p *evalParser) parsePrimary() (evalNode, error) {
+	t := p.peek()
+	switch t.kind {
+	case evalTokenString:
+		p.next()
+		return evalLiteral{value: t.value}, nil
+	case evalTokenNumber:
+		p.next()
+		n, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", t.value)
+		}
+		return evalLiteral{value: n}, nil
+	case evalTokenOperator:
+		if p.acceptOperator("(") {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+		return nil, p.errorf("unexpected %q", t.value)
+	case evalTokenQuotedIdent:
+		return p.parseName()
+	case evalTokenIdent:
+		switch strings.ToUpper(t.value) {
+		case "TRUE":
+			p.next()
+			return evalLiteral{value: true}, nil
+		case "FALSE":
+			p.next()
+			return evalLiteral{value: false}, nil
+		case "NULL":
+			p.next()
+			return evalLiteral{value: nil}, nil
+		case "CURRENT_USER", "SESSION_USER", "CURRENT_ROLE":
+			p.next()
+			return evalFunction{name: "current_user"}, nil
+		case "ARRAY":
+			if p.peekAt(1).kind == evalTokenOperator && p.peekAt(1).value == "[" {
+				p.pos += 2
+				items := make([]evalNode, 0)
+				for !p.isOperator("]") {
+					item, err := p.parseOr()
+					if err != nil {
+						return nil, err
+					}
+					items = append(items, item)
+					if !p.acceptOperator(",") {
+						break
+					}
+				}
+				if err := p.expectOperator("]"); err != nil {
+					return nil, err
+				}
+				return evalArray{items: items}, nil
+			}
+		case "EXISTS":
+			if p.peekAt(1).kind == evalTokenOperator && p.peekAt(1).value == "(" {
+				p.pos += 2
+				start := p.pos
+				depth := 1
+				for depth > 0 {
+					tok := p.next()
+					if tok.kind == evalTokenEOF {
+						return nil, p.errorf("unterminated EXISTS")
+					}
+					if tok.kind == evalTokenOperator && tok.value == "(" {
+						depth++
+					}
+					if tok.kind == evalTokenOperator && tok.value == ")" {
+						depth--
+					}
+				}
+				return evalExists{query: joinEvalTokens(p.tokens[start : p.pos-1])}, nil
+			}
+		case "CASE":
+			return nil, p.errorf("CASE expression is not supported")
+		}
+		return p.parseName()
+	}
+	return nil, p.errorf("unexpected end of clause")
+}
+
+// parseName parse column reference like "schema"."table"."column" or function call like auth.uid()
+func (p *evalParser) parseName() (evalNode, error) {
+	parts := []string{p.next().value}
+	for p.isOperator(".") && (p.peekAt(1).kind == evalTokenIdent || p.peekAt(1).kind == evalTokenQuotedIdent) {
+		p.next()
+		parts = append(parts, p.next().value)
+	}
+
+	if !p.acceptOperator("(") {
+		return evalColumn{name: parts[len(parts)-1]}, nil
+	}
+
+	args := make([]evalNode, 0)
+	for !p.isOperator(")") {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if !p.acceptOperator(",") {
+			break
+		}
+	}
+	if err := p.expectOperator(")"); err != nil {
+		return nil, err
+	}
+	return evalFunction{name: strings.ToLower(strings.Join(parts, ".")), args: args}, nil
+}
+
+func joinEvalTokens(tokens []evalToken) string {
+	parts := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		switch t.kind {
+		case evalTokenString:
+			parts = append(parts, String(t.value).String())
+		case evalTokenQuotedIdent:
+			parts = append(parts, qi(t.value))
+		default:
+			parts = append(parts, t.value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ----------------------------------------------------------------------------
+// Nodes
+// ----------------------------------------------------------------------------
+
+type evalLiteral struct{ value any }
+
+func (n evalLiteral) eval(*EvalEnv) (any, error) { return n.value, nil }
+
+type evalColumn struct{ name string }
+
+func (n evalColumn) eval(env *EvalEnv) (any, error) {
+	value, ok := env.Row[n.name]
+	if !ok {
+		return nil, fmt.Errorf("column %q does not exist in evaluated row", n.name)
+	}
+	return value, nil
+}
+
+type evalArray struct{ items []evalNode }
+
+func (n evalArray) eval(env *EvalEnv) (any, error) {
+	values := make([]any, 0, len(n.items))
+	for _, item := range n.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+type evalLogic struct {
+	op          string
+	left, right evalNode
+}
+
+func (n evalLogic) eval(env *EvalEnv) (any, error) {
+	left, err := evalBool(n.left, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// short circuit like postgres, so right side is not required when result is known
+	if left != nil && *left == (n.op == "OR") {
+		return *left, nil
+	}
+
+	right, err := evalBool(n.right, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "AND" {
+		if right != nil && !*right {
+			return false, nil
+		}
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		return true, nil
+	}
+
+	if right != nil && *right {
+		return true, nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	return false, nil
+}
+
+type evalNot struct{ operand evalNode }
+
+func (n evalNot) eval(env *EvalEnv) (any, error) {
+	v, err := evalBool(n.operand, env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return !*v, nil
+}
+
+type evalIs struct {
+	operand evalNode
+	target  string
+	negate  bool
+}
+
+func (n evalIs) eval(env *EvalEnv) (any, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var result bool
+	switch n.target {
+	case "NULL":
+		result = v == nil
+	case "TRUE":
+		b, ok := v.(bool)
+		result = ok && b
+	case "FALSE":
+		b, ok := v.(bool)
+		result = ok && !b
+	}
+	return result != n.negate, nil
+}
+
+type evalDistinct struct {
+	left, right evalNode
+	negate      bool
+}
+
+func (n evalDistinct) eval(env *EvalEnv) (any, error) {
+	left, right, err := evalPair(n.left, n.right, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var distinct bool
+	switch {
+	case left == nil && right == nil:
+		distinct = false
+	case left == nil || right == nil:
+		distinct = true
+	default:
+		cmp, err := compareEvalValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		distinct = cmp != 0
+	}
+	return distinct != n.negate, nil
+}
+
+type evalBinary struct {
+	op          string
+	left, right evalNode
+}
+
+func (n evalBinary) eval(env *EvalEnv) (any, error) {
+	left, right, err := evalPair(n.left, n.right, env)
+	if err != nil {
+		return nil, err
+	}
+	return applyEvalOperator(n.op, left, right)
+}
+
+type evalQuantified struct {
+	op          string
+	left, array evalNode
+	all         bool
+}
+
+func (n evalQuantified) eval(env *EvalEnv) (any, error) {
+	left, array, err := evalPair(n.left, n.array, env)
+	if err != nil || left == nil || array == nil {
+		return nil, err
+	}
+
+	items, ok := toEvalArray(array)
+	if !ok {
+		return nil, fmt.Errorf("ANY/ALL require array but found %T", array)
+	}
+
+	hasNull := false
+	for _, item := range items {
+		v, err := applyEvalOperator(n.op, left, item)
+		if err != nil {
+			return nil, err
+		}
+
+		b, _ := v.(bool)
+		switch {
+		case v == nil:
+			hasNull = true
+		case n.all && !b:
+			return false, nil
+		case !n.all && b:
+			return true, nil
+		}
+	}
+
+	if hasNull {
+		return nil, nil
+	}
+	return n.all, nil
+}
+
+type evalIn struct {
+	operand evalNode
+	items   []evalNode
+	negate  bool
+}
+
+func (n evalIn) eval(env *EvalEnv) (any, error) {
+	v, err := n.operand.eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	hasNull := false
+	for _, item := range n.items {
+		iv, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if iv == nil {
+			hasNull = true
+			continue
+		}
+
+		cmp, err := compareEvalValues(v, iv)
+		if err != nil {
+			return nil, err
+		}
+		if cmp == 0 {
+			return !n.negate, nil
+		}
+	}
+
+	if hasNull {
+		return nil, nil
+	}
+	return n.negate, nil
+}
+
+type evalBetween struct {
+	operand, low, high evalNode
+	negate             bool
+}
+
+func (n evalBetween) eval(env *EvalEnv) (any, error) {
+	ge, err := (evalBinary{op: ">=", left: n.operand, right: n.low}).eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	le, err := (evalBinary{op: "<=", left: n.operand, right: n.high}).eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if ge == nil || le == nil {
+		return nil, nil
+	}
+	return (ge.(bool) && le.(bool)) != n.negate, nil
+}
+
+type evalLike struct {
+	operand, pattern, escape evalNode
+	insensitive, negate      bool
+}
+
+func (n evalLike) eval(env *EvalEnv) (any, error) {
+	v, pattern, err := evalPair(n.operand, n.pattern, env)
+	if err != nil || v == nil || pattern == nil {
+		return nil, err
+	}
+
+	escape, err := n.escape.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := likeToEvalRegexp(toEvalText(pattern), toEvalText(escape), n.insensitive)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(toEvalText(v)) != n.negate, nil
+}
+
+type evalCast struct {
+	operand evalNode
+	typ     string
+}
+
+func (n evalCast) eval(env *EvalEnv) (any, error) {
+	v, err := n.operand.eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return castEvalValue(v, n.typ)
+}
+
+type evalSubscript struct {
+	operand, index evalNode
+}
+
+func (n evalSubscript) eval(env *EvalEnv) (any, error) {
+	v, index, err := evalPair(n.operand, n.index, env)
+	if err != nil || v == nil || index == nil {
+		return nil, err
+	}
+
+	items, ok := toEvalArray(v)
+	i, isNumber := index.(float64)
+	if !ok || !isNumber {
+		return nil, fmt.Errorf("invalid array subscript")
+	}
+
+	// postgres array is 1-based
+	if int(i) < 1 || int(i) > len(items) {
+		return nil, nil
+	}
+	return items[int(i)-1], nil
+}
+
+type evalExists struct{ query string }
+
+func (n evalExists) eval(env *EvalEnv) (any, error) {
+	if env.Exists == nil {
+		return nil, fmt.Errorf("EXISTS (%s) require EvalEnv.Exists", n.query)
+	}
+	return env.Exists(n.query, *env)
+}
+
+type evalFunction struct {
+	name string
+	args []evalNode
+}
+
+func (n evalFunction) eval(env *EvalEnv) (any, error) {
+	args := make([]any, 0, len(n.args))
+	for _, a := range n.args {
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	switch n.name {
+	case "auth.uid":
+		return castEvalClaim(env.Claims["sub"], "uuid")
+	case "auth.role":
+		return castEvalClaim(env.Claims["role"], "text")
+	case "auth.email":
+		return castEvalClaim(env.Claims["email"], "text")
+	case "auth.jwt":
+		return normalizeEvalJSON(env.Claims), nil
+	case "current_user":
+		if env.Role != "" {
+			return env.Role, nil
+		}
+		return castEvalClaim(env.Claims["role"], "text")
+	case "current_setting":
+		if len(args) == 0 || args[0] == nil {
+			return nil, fmt.Errorf("current_setting require setting name")
+		}
+		missingOk := len(args) > 1 && args[1] == true
+		return evalCurrentSetting(env, toEvalText(args[0]), missingOk)
+	case "now", "current_timestamp":
+		if env.Now.IsZero() {
+			return time.Now(), nil
+		}
+		return env.Now, nil
+	case "coalesce":
+		for _, a := range args {
+			if a != nil {
+				return a, nil
+			}
+		}
+		return nil, nil
+	case "nullif":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("nullif require 2 arguments")
+		}
+		if args[0] != nil && args[1] != nil {
+			if cmp, err := compareEvalValues(args[0], args[1]); err == nil && cmp == 0 {
+				return nil, nil
+			}
+		}
+		return args[0], nil
+	case "lower", "upper", "trim", "length":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s require 1 argument", n.name)
+		}
+		if args[0] == nil {
+			return nil, nil
+		}
+		s := toEvalText(args[0])
+		switch n.name {
+		case "lower":
+			return strings.ToLower(s), nil
+		case "upper":
+			return strings.ToUpper(s), nil
+		case "trim":
+			return strings.TrimSpace(s), nil
+		}
+		return float64(len([]rune(s))), nil
+	case "array_length", "cardinality":
+		if len(args) == 0 || args[0] == nil {
+			return nil, nil
+		}
+		items, ok := toEvalArray(args[0])
+		if !ok || len(items) == 0 {
+			return nil, nil
+		}
+		return float64(len(items)), nil
+	case "storage.foldername", "storage.filename", "storage.extension":
+		if len(args) != 1 || args[0] == nil {
+			return nil, nil
+		}
+		parts := strings.Split(toEvalText(args[0]), "/")
+		switch n.name {
+		case "storage.foldername":
+			folders := make([]any, 0, len(parts))
+			for _, part := range parts[:len(parts)-1] {
+				folders = append(folders, part)
+			}
+			return folders, nil
+		case "storage.filename":
+			return parts[len(parts)-1], nil
+		}
+		name := parts[len(parts)-1]
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			return name[i+1:], nil
+		}
+		return "", nil
+	}
+	return nil, fmt.Errorf("function %s() is not supported by evaluator", n.name)
+}
+
+// ----------------------------------------------------------------------------
+// Value helpers
+// ----------------------------------------------------------------------------
+
+func evalBool(node evalNode, env *EvalEnv) (*bool, error) {
+	v, err := node.eval(env)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("argument of logical operator must be boolean, not %T", v)
+	}
+	return &b, nil
+}
+
+func evalPair(left, right evalNode, env *EvalEnv) (any, any, error) {
+	l, err := left.eval(env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := right.eval(env)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, r, nil
+}
+
+func evalCurrentSetting(env *EvalEnv, key string, missingOk bool) (any, error) {
+	if v, ok := env.Settings[key]; ok {
+		return v, nil
+	}
+
+	switch {
+	case key == "request.jwt.claims":
+		if env.Claims == nil {
+			return missingEvalSetting(key, missingOk)
+		}
+		data, err := json.Marshal(env.Claims)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	case strings.HasPrefix(key, "request.jwt.claim."):
+		claim, ok := env.Claims[strings.TrimPrefix(key, "request.jwt.claim.")]
+		if !ok || claim == nil {
+			return "", nil
+		}
+		return toEvalText(normalizeEvalJSON(claim)), nil
+	case key == "role":
+		if env.Role != "" {
+			return env.Role, nil
+		}
+		return castEvalClaim(env.Claims["role"], "text")
+	}
+	return missingEvalSetting(key, missingOk)
+}
+
+func missingEvalSetting(key string, missingOk bool) (any, error) {
+	if missingOk {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unrecognized configuration parameter %q", key)
+}
+
+func castEvalClaim(claim any, typ string) (any, error) {
+	if claim == nil {
+		return nil, nil
+	}
+	return castEvalValue(normalizeEvalJSON(claim), typ)
+}
+
+// normalizeEvalJSON convert value into json decoded form, so number is float64 and struct is map
+func normalizeEvalJSON(value any) any {
+	switch value.(type) {
+	case nil, string, float64, bool, map[string]any, []any:
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func toEvalText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func toEvalArray(value any) ([]any, bool) {
+	items, ok := normalizeEvalJSON(value).([]any)
+	return items, ok
+}
+
+func castEvalValue(value any, typ string) (any, error) {
+	value = normalizeEvalJSON(value)
+	switch strings.TrimSuffix(typ, "[]") {
+	case "text", "varchar", "character varying", "char", "character", "bpchar", "name", "citext":
+		if strings.HasSuffix(typ, "[]") {
+			return value, nil
+		}
+		return toEvalText(value), nil
+	case "uuid":
+		s := strings.ToLower(toEvalText(value))
+		if !isEvalUUID(s) {
+			return nil, fmt.Errorf("invalid input syntax for type uuid: %q", toEvalText(value))
+		}
+		return s, nil
+	case "int", "int2", "int4", "int8", "integer", "smallint", "bigint":
+		n, err := toEvalNumber(value)
+		if err != nil {
+			return nil, err
+		}
+		return math.Round(n), nil
+	case "numeric", "decimal", "real", "float4", "float8", "double precision":
+		return toEvalNumber(value)
+	case "bool", "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "t", "true", "yes", "on", "1":
+				return true, nil
+			case "f", "false", "no", "off", "0":
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid input syntax for type boolean: %q", toEvalText(value))
+	case "json", "jsonb":
+		if s, ok := value.(string); ok {
+			var decoded any
+			if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+				return nil, fmt.Errorf("invalid input syntax for type json: %q", s)
+			}
+			return decoded, nil
+		}
+		return value, nil
+	case "date", "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone":
+		t, ok := toEvalTime(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid input syntax for type %s: %q", typ, toEvalText(value))
+		}
+		if typ == "date" {
+			y, m, d := t.Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+		}
+		return t, nil
+	case "interval":
+		d, err := parseEvalInterval(toEvalText(value))
+		if err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
+	// unknown type like enum is compared as text
+	return value, nil
+}
+
+func isEvalUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func toEvalNumber(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid input syntax for type numeric: %q", v)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("cannot cast %T to number", value)
+}
+
+var evalTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05Z07:00", "2006-01-02 15:04:05.999999999Z07", "2006-01-02 15:04:05", "2006-01-02"}
+
+func toEvalTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range evalTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+var evalIntervalPattern = regexp.MustCompile(`(?i)(-?\d+(?:\.\d+)?)\s*(second|minute|hour|day|week|month|year)s?`)
+
+// parseEvalInterval parse simple interval like `1 day` or `2 hours 30 minutes`,
+// month and year is approximated as 30 and 365 days
+func parseEvalInterval(s string) (time.Duration, error) {
+	matches := evalIntervalPattern.FindAllStringSubmatch(s, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("invalid input syntax for type interval: %q", s)
+	}
+
+	units := map[string]time.Duration{
+		"second": time.Second, "minute": time.Minute, "hour": time.Hour, "day": 24 * time.Hour,
+		"week": 7 * 24 * time.Hour, "month": 30 * 24 * time.Hour, "year": 365 * 24 * time.Hour,
+	}
+
+	var total time.Duration
+	for _, m := range matches {
+		n, _ := strconv.ParseFloat(m[1], 64)
+		total += time.Duration(n * float64(units[strings.ToLower(m[2])]))
+	}
+	return total, nil
+}
+
+// compareEvalValues compare two non null value, string is coerced to the other operand type
+// like untyped literal in postgres
+func compareEvalValues(a, b any) (int, error) {
+	a, b = normalizeEvalJSON(a), normalizeEvalJSON(b)
+
+	switch av := a.(type) {
+	case float64:
+		bv, err := toEvalNumber(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareEvalOrdered(av, bv), nil
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			casted, err := castEvalValue(b, "bool")
+			if err != nil {
+				return 0, err
+			}
+			bv = casted.(bool)
+		}
+		return compareEvalOrdered(boolToEvalNumber(av), boolToEvalNumber(bv)), nil
+	case time.Time:
+		bt, ok := toEvalTime(b)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare timestamp with %T", b)
+		}
+		return av.Compare(bt), nil
+	case time.Duration:
+		bd, ok := b.(time.Duration)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare interval with %T", b)
+		}
+		return compareEvalOrdered(float64(av), float64(bd)), nil
+	case string:
+		switch b.(type) {
+		case float64, bool, time.Time:
+			cmp, err := compareEvalValues(b, a)
+			return -cmp, err
+		case string:
+			return strings.Compare(av, b.(string)), nil
+		}
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return 0, nil
+	}
+
+	as, bs := toEvalText(a), toEvalText(b)
+	if as == bs {
+		return 0, nil
+	}
+	return 0, fmt.Errorf("cannot compare %T with %T", a, b)
+}
+
+func compareEvalOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToEvalNumber(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func applyEvalOperator(op string, left, right any) (any, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	switch op {
+	case "=", "<>", "!=", "<", "<=", ">", ">=":
+		cmp, err := compareEvalValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "=":
+			return cmp == 0, nil
+		case "<>", "!=":
+			return cmp != 0, nil
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		}
+		return cmp >= 0, nil
+	case "+", "-", "*", "/", "%":
+		return applyEvalArithmetic(op, left, right)
+	case "||":
+		if items, ok := toEvalArray(left); ok {
+			if rightItems, ok := toEvalArray(right); ok {
+				return append(append([]any{}, items...), rightItems...), nil
+			}
+			return append(append([]any{}, items...), right), nil
+		}
+		return toEvalText(left) + toEvalText(right), nil
+	case "->", "->>":
+		value, ok := evalJSONGet(left, right)
+		if !ok || op == "->" {
+			if !ok {
+				return nil, nil
+			}
+			return value, nil
+		}
+		if value == nil {
+			return nil, nil
+		}
+		return toEvalText(value), nil
+	case "#>", "#>>":
+		path, ok := toEvalArray(right)
+		if !ok {
+			return nil, fmt.Errorf("json path must be array")
+		}
+		value := normalizeEvalJSON(left)
+		for _, key := range path {
+			if value, ok = evalJSONGet(value, key); !ok {
+				return nil, nil
+			}
+		}
+		if op == "#>>" && value != nil {
+			return toEvalText(value), nil
+		}
+		return value, nil
+	case "?", "?|", "?&":
+		keys := []any{right}
+		if op != "?" {
+			items, ok := toEvalArray(right)
+			if !ok {
+				return nil, fmt.Errorf("operator %s require text array", op)
+			}
+			keys = items
+		}
+
+		found := 0
+		for _, key := range keys {
+			if evalJSONHasKey(left, toEvalText(key)) {
+				found++
+			}
+		}
+		if op == "?&" {
+			return found == len(keys), nil
+		}
+		return found > 0, nil
+	case "@>":
+		return evalContains(left, right), nil
+	case "<@":
+		return evalContains(right, left), nil
+	case "&&":
+		leftItems, lok := toEvalArray(left)
+		rightItems, rok := toEvalArray(right)
+		if !lok || !rok {
+			return nil, fmt.Errorf("operator && require array")
+		}
+		for _, l := range leftItems {
+			for _, r := range rightItems {
+				if isEvalEqual(l, r) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	case "~", "~*", "!~", "!~*":
+		pattern := toEvalText(right)
+		if strings.HasSuffix(op, "*") {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(toEvalText(left)) != strings.HasPrefix(op, "!"), nil
+	}
+	return nil, fmt.Errorf("operator %s is not supported by evaluator", op)
+}
+
+func applyEvalArithmetic(op string, left, right any) (any, error) {
+	if t, ok := left.(time.Time); ok {
+		d, ok := right.(time.Duration)
+		if !ok {
+			return nil, fmt.Errorf("operator %s require interval", op)
+		}
+		switch op {
+		case "+":
+			return t.Add(d), nil
+		case "-":
+			return t.Add(-d), nil
+		}
+		return nil, fmt.Errorf("operator %s is not supported for timestamp", op)
+	}
+
+	l, err := toEvalNumber(normalizeEvalJSON(left))
+	if err != nil {
+		return nil, err
+	}
+	r, err := toEvalNumber(normalizeEvalJSON(right))
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	}
+	if r == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	return math.Mod(l, r), nil
+}
+
+func evalJSONGet(value any, key any) (any, bool) {
+	switch v := normalizeEvalJSON(value).(type) {
+	case map[string]any:
+		item, ok := v[toEvalText(key)]
+		return item, ok
+	case []any:
+		index, err := toEvalNumber(normalizeEvalJSON(key))
+		if err != nil {
+			return nil, false
+		}
+		i := int(index)
+		if i < 0 {
+			i += len(v)
+		}
+		if i < 0 || i >= len(v) {
+			return nil, false
+		}
+		return v[i], true
+	}
+	return nil, false
+}
+
+func evalJSONHasKey(value any, key string) bool {
+	switch v := normalizeEvalJSON(value).(type) {
+	case map[string]any:
+		_, ok := v[key]
+		return ok
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evalContains implement @> for array and jsonb
+func evalContains(container, contained any) bool {
+	container, contained = normalizeEvalJSON(container), normalizeEvalJSON(contained)
+	switch c := container.(type) {
+	case map[string]any:
+		sub, ok := contained.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range sub {
+			cv, exist := c[k]
+			if !exist || !evalContains(cv, v) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		items, ok := contained.([]any)
+		if !ok {
+			items = []any{contained}
+		}
+		for _, item := range items {
+			found := false
+			for _, cv := range c {
+				if evalContains(cv, item) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return isEvalEqual(container, contained)
+}
+
+func isEvalEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	cmp, err := compareEvalValues(a, b)
+	return err == nil && cmp == 0
+}
+
+func likeToEvalRegexp(pattern, escape string, insensitive bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	if insensitive {
+		sb.WriteString("(?i)")
+	}
+	sb.WriteString("^")
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case escape != "" && string(r) == escape && i+1 < len(runes):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case r == '%':
+			sb.WriteString("(?s:.*)")
+		case r == '_':
+			sb.WriteString("(?s:.)")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
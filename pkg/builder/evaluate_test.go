@@ -0,0 +1,196 @@
+package builder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	evalUserA = "8f14e45f-ceea-467a-9575-6ab5f1a0e2b1"
+	evalUserB = "c9f0f895-fb98-4b91-8a3e-5b2b4d3c7a10"
+)
+
+type evalArticle struct {
+	Id       int64          `json:"id" column:"name:id;type:bigint"`
+	AuthorId string         `json:"author_id" column:"name:author_id;type:uuid"`
+	OrgId    string         `json:"org_id" column:"name:org_id;type:uuid"`
+	Status   string         `json:"status,omitempty" column:"name:status;type:text"`
+	Tags     []string       `json:"tags" column:"name:tags;type:text[]"`
+	Meta     map[string]any `json:"meta" column:"name:meta;type:jsonb"`
+	Deleted  *time.Time     `json:"deleted_at" column:"name:deleted_at;type:timestamptz"`
+	Comments []any          `json:"comments" join:"joinType:hasMany"`
+}
+
+func evalEnv(row any, claims map[string]any) builder.EvalEnv {
+	return builder.EvalEnv{Row: builder.RowFromModel(row), Claims: claims}
+}
+
+func TestEvaluate_OwnerIsAuth(t *testing.T) {
+	article := evalArticle{Id: 1, AuthorId: evalUserA}
+
+	ok, err := builder.Evaluate(builder.OwnerIsAuth("author_id"), evalEnv(article, map[string]any{"sub": evalUserA}))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = builder.Evaluate(builder.OwnerIsAuth("author_id"), evalEnv(article, map[string]any{"sub": evalUserB}))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// anonymous request doesn't have sub claim, so auth.uid() is null
+	ok, err = builder.Evaluate(builder.OwnerIsAuth("author_id"), evalEnv(article, nil))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestEvaluate_TenantClaim(t *testing.T) {
+	article := evalArticle{OrgId: evalUserA}
+
+	claims := map[string]any{"org_id": evalUserA, "app_metadata": map[string]any{"org_id": evalUserB}}
+
+	ok, err := builder.Evaluate(builder.TenantClaimMatch("org_id", "org_id", "uuid"), evalEnv(article, claims))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = builder.Evaluate(builder.TenantClaimMatch("org_id", "app_metadata.org_id", "uuid"), evalEnv(article, claims))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	env := evalEnv(article, nil)
+	env.Settings = map[string]string{"app.org_id": evalUserA}
+	ok, err = builder.Evaluate(builder.TenantMatch("org_id", "app.org_id", "uuid"), env)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = builder.Evaluate(builder.TenantMatch("org_id", "app.unknown", "uuid"), env)
+	assert.ErrorContains(t, err, "unrecognized configuration parameter")
+}
+
+func TestEvaluate_RoleIs(t *testing.T) {
+	clause := builder.RoleIs("editor")
+
+	ok, err := builder.Evaluate(clause, evalEnv(evalArticle{}, map[string]any{"role": "editor"}))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = builder.Evaluate(clause, evalEnv(evalArticle{}, map[string]any{"role": "authenticated", "roles": []string{"viewer", "editor"}}))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = builder.Evaluate(clause, evalEnv(evalArticle{}, map[string]any{"role": "authenticated"}))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = builder.Evaluate(builder.RolesAny("admin", "editor"), evalEnv(evalArticle{}, map[string]any{"role": "admin"}))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestEvaluate_Operators(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	article := evalArticle{
+		Id:      10,
+		Status:  "Published",
+		Tags:    []string{"go", "sql"},
+		Meta:    map[string]any{"featured": true, "review": map[string]any{"score": 4}},
+		Deleted: &deletedAt,
+	}
+
+	cases := []struct {
+		name   string
+		clause builder.Clause
+		want   bool
+	}{
+		{"eq string", builder.EqString("status", "Published"), true},
+		{"in strings", builder.InStrings("status", "draft", "Published"), true},
+		{"not in", builder.NotIn("id", builder.Int64(1), builder.Int64(2)), true},
+		{"like", builder.Clause(`"status" LIKE 'Pub%'`), true},
+		{"ilike", builder.Clause(`"status" ILIKE '%LISH_D'`), true},
+		{"like escaped", builder.StartsWith("status", "Pub"), false},
+		{"ilike literal", builder.ILike("status", "published"), true},
+		{"not like", builder.NotLike("status", "Draft%"), true},
+		{"between", builder.Between("id", builder.Int64(5), builder.Int64(10)), true},
+		{"gt", builder.Gt("id", builder.Int64(10)), false},
+		{"regex", builder.IRegex("status", "^pub"), true},
+		{"eq any", builder.EqAny("status", builder.ArrayStrings("Published", "archived")), true},
+		{"array contains", builder.ArrayContains("tags", builder.ArrayStrings("go")), true},
+		{"array overlaps", builder.ArrayOverlaps("tags", builder.ArrayStrings("rust", "zig")), false},
+		{"json has key", builder.JSONHasKey("meta", "featured"), true},
+		{"json has any keys", builder.JSONHasAnyKeys("meta", "pinned", "review"), true},
+		{"json has all keys", builder.JSONHasAllKeys("meta", "pinned", "review"), false},
+		{"json contains", builder.JSONContains("meta", builder.JSONB(`{"featured": true}`)), true},
+		{"json path", builder.Ge("id", builder.Exp("("+builder.JSONPathText("meta", "review", "score").String()+")::int")), true},
+		{"not null", builder.NotNull("deleted_at"), true},
+		{"timestamp", builder.Lt("deleted_at", builder.Timestamptz("2024-02-01T00:00:00Z")), true},
+		{"interval", builder.Gt("deleted_at", builder.Exp(builder.Timestamptz("2024-01-01T00:00:00Z").String()+" + "+builder.Interval("12 hours").String())), true},
+		{"and or", builder.And(builder.EqString("status", "Published"), builder.Or(builder.IsNull("deleted_at"), builder.JSONHasKey("meta", "featured"))), true},
+		{"not", builder.Not(builder.EqString("status", "Published")), false},
+		{"distinct from null", builder.IsDistinctFrom("status", builder.Raw("NULL")), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok, err := builder.Evaluate(c.clause, evalEnv(article, nil))
+			require.NoError(t, err)
+			assert.Equal(t, c.want, ok)
+		})
+	}
+}
+
+func TestEvaluate_NullLogic(t *testing.T) {
+	article := evalArticle{Id: 1}
+
+	// null OR true is true, null AND true is null and treated as false
+	ok, err := builder.Evaluate(builder.Or(builder.Eq("deleted_at", builder.Now()), builder.Eq("id", builder.Int64(1))), evalEnv(article, nil))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = builder.Evaluate(builder.And(builder.Eq("deleted_at", builder.Now()), builder.Eq("id", builder.Int64(1))), evalEnv(article, nil))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = builder.Evaluate(builder.Not(builder.Eq("deleted_at", builder.Now())), evalEnv(article, nil))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = builder.Evaluate(builder.Clause(""), evalEnv(article, nil))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestEvaluate_Error(t *testing.T) {
+	env := evalEnv(evalArticle{}, nil)
+
+	_, err := builder.Evaluate(builder.EqString("unknown", "x"), env)
+	assert.ErrorContains(t, err, `column "unknown" does not exist`)
+
+	_, err = builder.Evaluate(builder.Clause(`"id" = `), env)
+	assert.ErrorContains(t, err, "failed to parse clause")
+
+	_, err = builder.Evaluate(builder.Clause(`"id" = my_func()`), env)
+	assert.ErrorContains(t, err, "my_func() is not supported")
+
+	_, err = builder.Evaluate(builder.Clause(`EXISTS (SELECT 1 FROM members WHERE user_id = auth.uid())`), env)
+	assert.ErrorContains(t, err, "require EvalEnv.Exists")
+
+	env.Exists = func(query string, env builder.EvalEnv) (bool, error) {
+		assert.Equal(t, "SELECT 1 FROM members WHERE user_id = auth . uid ( )", query)
+		return true, nil
+	}
+	ok, err := builder.Evaluate(builder.Clause(`EXISTS (SELECT 1 FROM members WHERE user_id = auth.uid())`), env)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestRowFromModel(t *testing.T) {
+	row := builder.RowFromModel(&evalArticle{Id: 7, Status: ""})
+	assert.Equal(t, float64(7), row["id"])
+	assert.Equal(t, "", row["status"])
+	assert.Nil(t, row["deleted_at"])
+	assert.NotContains(t, row, "comments")
+
+	assert.Equal(t, map[string]any{"id": float64(1)}, builder.RowFromModel(map[string]any{"id": 1}))
+}
@@ -176,17 +176,23 @@ func And(cs ...Clause) Clause { return joinClauses("AND", cs...) }
 func Or(cs ...Clause) Clause  { return joinClauses("OR", cs...) }
 
 func joinClauses(op string, cs ...Clause) Clause {
+	otherOp := "OR"
+	if op == "OR" {
+		otherOp = "AND"
+	}
+
 	parts := make([]string, 0, len(cs))
 	for _, c := range cs {
 		s := strings.TrimSpace(c.String())
 		if s == "" {
 			continue
 		}
-		if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
-			parts = append(parts, s)
-		} else {
-			parts = append(parts, "("+s+")")
+		// flatten clause joined with the same operator, ((a) AND (b)) AND (c) -> (a) AND (b) AND (c)
+		if nested := joinedClauseParts(s, op); nested != nil && splitByLogical(s, otherOp) == nil {
+			parts = append(parts, nested...)
+			continue
 		}
+		parts = append(parts, wrapClause(s))
 	}
 	if len(parts) == 0 {
 		return ""
@@ -194,6 +200,25 @@ func joinClauses(op string, cs ...Clause) Clause {
 	return Clause(strings.Join(parts, " "+op+" "))
 }
 
+// joinedClauseParts return parenthesized part of clause produced by joinClauses,
+// clause like `"col" BETWEEN 1 AND 5` is not split
+func joinedClauseParts(s, op string) []string {
+	parts := splitByLogical(s, op)
+	for _, p := range parts {
+		if !enclosedByOuterParentheses(p) {
+			return nil
+		}
+	}
+	return parts
+}
+
+func wrapClause(s string) string {
+	if enclosedByOuterParentheses(s) {
+		return s
+	}
+	return "(" + s + ")"
+}
+
 // ----------------------------------------------------------------------------
 // Convenience macros
 // ----------------------------------------------------------------------------
@@ -509,3 +534,19 @@ func InOrg(col string) Clause {
 
 // simple string claim (text)
 func Claim(key string) Exp { return CurrentSetting(key) }
+
+// JwtClaim returns claim of request jwt as text, nested claim is separated by dot
+// like `app_metadata.org_id`.
+func JwtClaim(claim string) Exp {
+	keys := strings.Split(claim, ".")
+	expr := jwt().String()
+	for _, k := range keys[:len(keys)-1] {
+		expr += " -> " + String(k).String()
+	}
+	return Exp("(" + expr + " ->> " + String(keys[len(keys)-1]).String() + ")")
+}
+
+// TenantClaimMatch matches tenant column with jwt claim, cast is optional column type like `uuid`.
+func TenantClaimMatch(col, claim, cast string) Clause {
+	return Eq(col, Cast(JwtClaim(claim), cast))
+}
@@ -250,6 +250,17 @@ func TestJwtRoleHelpers(t *testing.T) {
 	assert.Equal(t, builder.CurrentSetting("claim"), builder.Claim("claim"))
 }
 
+func TestTenantClaimMatch(t *testing.T) {
+	assert.Equal(t, "(current_setting('request.jwt.claims')::jsonb ->> 'org_id')", builder.JwtClaim("org_id").String())
+	assert.Equal(t, "(current_setting('request.jwt.claims')::jsonb -> 'app_metadata' ->> 'org_id')", builder.JwtClaim("app_metadata.org_id").String())
+
+	clause := builder.TenantClaimMatch("org_id", "org_id", "uuid")
+	assert.Equal(t, `"org_id" = (current_setting('request.jwt.claims')::jsonb ->> 'org_id')::uuid`, clause.String())
+
+	clause = builder.TenantClaimMatch("org_id", "org_id", "")
+	assert.Equal(t, `"org_id" = (current_setting('request.jwt.claims')::jsonb ->> 'org_id')`, clause.String())
+}
+
 // Test Bool function
 func TestBool(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
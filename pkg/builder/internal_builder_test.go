@@ -158,3 +158,14 @@ func TestTrimOuterParens(t *testing.T) {
 	require.Equal(t, "inner", trimOuterParens("(( inner ))"))
 	require.Equal(t, "", trimOuterParens("()"))
 }
+
+func TestJoinClausesPrecedence(t *testing.T) {
+	or := Or(EqString("a", "1"), EqString("b", "2"))
+	require.Equal(t, Clause(`("bucket_id" = 'x') AND (("a" = '1') OR ("b" = '2'))`), And(StorageBucketClause("x"), or))
+
+	and := And(EqString("a", "1"), EqString("b", "2"))
+	require.Equal(t, Clause(`("a" = '1') AND ("b" = '2') AND ("c" = '3')`), And(and, EqString("c", "3")))
+
+	between := Between("n", Int64(1), Int64(5))
+	require.Equal(t, Clause(`("n" BETWEEN 1 AND 5) AND ("c" = '3')`), And(between, EqString("c", "3")))
+}
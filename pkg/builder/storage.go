@@ -1,6 +1,25 @@
 package builder
 
-import "strings"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	storageObjectSchema = "storage"
+	storageObjectTable  = "objects"
+)
+
+// pattern of normalized storage expression, storage schema and objects table
+// can be removed by clause qualifier
+var (
+	storageFolderPattern    = regexp.MustCompile(`^\((?:storage\.)?foldername\((?:objects\.)?name\)\)\[(\d+)\]$`)
+	storageExtensionPattern = regexp.MustCompile(`^(?:storage\.)?extension\((?:objects\.)?name\)$`)
+	storageMetadataPattern  = regexp.MustCompile(`^(?:objects\.)?metadata ?->> ?'((?:[^']|'')*)'$`)
+	storageOwnerPattern     = regexp.MustCompile(`^(?:objects\.)?owner_id$`)
+)
 
 // StorageBucketClause returns the canonical bucket scope clause for Supabase storage policies.
 func StorageBucketClause(bucketName string) Clause {
@@ -23,6 +42,67 @@ func StorageCheckClause(bucketName string, clause Clause) Clause {
 	return combineStorageClause(bucketName, clause)
 }
 
+// StorageFolder returns folder segment of object path, index start from 1 like postgres array.
+//
+// example :
+//
+//	StorageFolder(1) // (storage.foldername("name"))[1]
+func StorageFolder(index int) Exp {
+	if index < 1 {
+		index = 1
+	}
+	return Exp("(storage.foldername(" + qi("name") + "))[" + strconv.Itoa(index) + "]")
+}
+
+// StorageFolderEq matches folder segment of object path with the given value.
+func StorageFolderEq(index int, value string) Clause {
+	return Clause(StorageFolder(index).String() + " = " + String(value).String())
+}
+
+// StorageFolderIsAuth restricts object to folder named by id of authenticated user.
+//
+// example :
+//
+//	StorageFolderIsAuth(1) // (storage.foldername("name"))[1] = auth.uid()::text
+func StorageFolderIsAuth(index int) Clause {
+	return Clause(StorageFolder(index).String() + " = " + Cast(AuthUID(), "text").String())
+}
+
+// StorageExtensionIs matches file extension of object, multiple extension are OR-ed.
+//
+// example :
+//
+//	StorageExtensionIs("png", "jpg") // (storage.extension("name") = 'png') OR (storage.extension("name") = 'jpg')
+func StorageExtensionIs(extensions ...string) Clause {
+	clauses := make([]Clause, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.TrimPrefix(strings.TrimSpace(ext), ".")
+		if ext == "" {
+			continue
+		}
+		clauses = append(clauses, Clause("storage.extension("+qi("name")+") = "+String(ext).String()))
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return Or(clauses...)
+}
+
+// StorageOwnerIsAuth restricts object to the uploader, owner_id is text column so auth.uid() is cast to text.
+func StorageOwnerIsAuth() Clause {
+	return Eq("owner_id", Cast(AuthUID(), "text"))
+}
+
+// StorageMetadataEq matches text value of object metadata key.
+//
+// example :
+//
+//	StorageMetadataEq("mimetype", "image/png") // ("metadata" ->> 'mimetype') = 'image/png'
+func StorageMetadataEq(key, value string) Clause {
+	return Clause("(" + qi("metadata") + " ->> " + String(key).String() + ") = " + String(value).String())
+}
+
 // StripStorageBucketFilter removes the default bucket filter from a raw clause so that
 // generator routines can rebuild it using StorageUsingClause or StorageCheckClause.
 func StripStorageBucketFilter(sql string, bucketName string) string {
@@ -113,3 +193,78 @@ func trimOuterParens(s string) string {
 	}
 	return result
 }
+
+func isStorageObjectScope(qualifiers ...ClauseQualifier) bool {
+	for _, q := range qualifiers {
+		if strings.EqualFold(strings.TrimSpace(q.Schema), storageObjectSchema) && strings.EqualFold(strings.TrimSpace(q.Table), storageObjectTable) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStorageClause convert normalized storage expression into storage helper,
+// operand can be in any side because normalization sort both side of equality
+func parseStorageClause(expr string) (Clause, string, bool) {
+	left, right, ok := splitComparison(expr, "=")
+	if !ok {
+		return "", "", false
+	}
+
+	if cl, code, ok := buildStorageClause(left, right); ok {
+		return cl, code, true
+	}
+	return buildStorageClause(right, left)
+}
+
+func buildStorageClause(target, value string) (Clause, string, bool) {
+	target, value = strings.TrimSpace(target), strings.TrimSpace(value)
+	literal, isLiteral := storageStringLiteral(value)
+	isAuth := strings.EqualFold(value, "auth.uid()")
+
+	if m := storageFolderPattern.FindStringSubmatch(target); m != nil {
+		index, err := strconv.Atoi(m[1])
+		if err != nil || index < 1 {
+			return "", "", false
+		}
+
+		switch {
+		case isAuth:
+			return StorageFolderIsAuth(index), fmt.Sprintf("st.StorageFolderIsAuth(%d)", index), true
+		case isLiteral:
+			return StorageFolderEq(index, literal), fmt.Sprintf("st.StorageFolderEq(%d, %q)", index, literal), true
+		}
+		return "", "", false
+	}
+
+	if storageExtensionPattern.MatchString(target) && isLiteral {
+		return StorageExtensionIs(literal), fmt.Sprintf("st.StorageExtensionIs(%q)", literal), true
+	}
+
+	metadata := target
+	if enclosedByOuterParentheses(metadata) {
+		metadata = strings.TrimSpace(metadata[1 : len(metadata)-1])
+	}
+	if m := storageMetadataPattern.FindStringSubmatch(metadata); m != nil && isLiteral {
+		key := strings.ReplaceAll(m[1], "''", "'")
+		return StorageMetadataEq(key, literal), fmt.Sprintf("st.StorageMetadataEq(%q, %q)", key, literal), true
+	}
+
+	if storageOwnerPattern.MatchString(target) && isAuth {
+		return StorageOwnerIsAuth(), "st.StorageOwnerIsAuth()", true
+	}
+
+	return "", "", false
+}
+
+func storageStringLiteral(value string) (string, bool) {
+	if len(value) < 2 || !strings.HasPrefix(value, "'") || !strings.HasSuffix(value, "'") {
+		return "", false
+	}
+
+	inner := value[1 : len(value)-1]
+	if strings.Contains(strings.ReplaceAll(inner, "''", ""), "'") {
+		return "", false
+	}
+	return strings.ReplaceAll(inner, "''", "'"), true
+}
@@ -27,9 +27,80 @@ func TestStorageUsingClauseSkipsDuplicateBucket(t *testing.T) {
 func TestStripStorageBucketFilter(t *testing.T) {
 	raw := `("bucket_id" = 'avatars') AND auth.role() = 'anon' AND storage.extension(name) = 'jpg'`
 	clean := builder.StripStorageBucketFilter(raw, "avatars")
-	// NormalizeClauseSQL removes identifier parentheses, so extension(name) becomes extensionname.
-	expected := `auth.role() = 'anon' AND storage.extensionname = 'jpg'`
+	expected := `auth.role() = 'anon' AND storage.extension(name) = 'jpg'`
 	assert.Equal(t, expected, clean)
 
 	assert.Equal(t, "", builder.StripStorageBucketFilter(`"bucket_id" = 'avatars'`, "avatars"))
 }
+
+func TestStorageHelpers(t *testing.T) {
+	assert.Equal(t, builder.Exp(`(storage.foldername("name"))[2]`), builder.StorageFolder(2))
+	assert.Equal(t, builder.Exp(`(storage.foldername("name"))[1]`), builder.StorageFolder(0))
+	assert.Equal(t, builder.Clause(`(storage.foldername("name"))[1] = auth.uid()::text`), builder.StorageFolderIsAuth(1))
+	assert.Equal(t, builder.Clause(`(storage.foldername("name"))[1] = 'public'`), builder.StorageFolderEq(1, "public"))
+	assert.Equal(t, builder.Clause(`storage.extension("name") = 'png'`), builder.StorageExtensionIs(".png"))
+	assert.Equal(t, builder.Clause(`(storage.extension("name") = 'png') OR (storage.extension("name") = 'jpg')`), builder.StorageExtensionIs("png", "jpg", ""))
+	assert.Equal(t, builder.Clause(`"owner_id" = auth.uid()::text`), builder.StorageOwnerIsAuth())
+	assert.Equal(t, builder.Clause(`("metadata" ->> 'mimetype') = 'image/png'`), builder.StorageMetadataEq("mimetype", "image/png"))
+}
+
+func TestStorageHelpersRoundTrip(t *testing.T) {
+	qualifier := builder.ClauseQualifier{Schema: "storage", Table: "objects"}
+
+	cases := []struct {
+		name   string
+		clause builder.Clause
+		remote string
+		code   string
+	}{
+		{
+			name:   "folder is auth",
+			clause: builder.StorageFolderIsAuth(1),
+			remote: `((bucket_id = 'avatars'::text) AND ((storage.foldername(name))[1] = (auth.uid())::text))`,
+			code:   `st.StorageFolderIsAuth(1)`,
+		},
+		{
+			name:   "folder value",
+			clause: builder.StorageFolderEq(2, "shared"),
+			remote: `((bucket_id = 'avatars'::text) AND ((storage.foldername(name))[2] = 'shared'::text))`,
+			code:   `st.StorageFolderEq(2, "shared")`,
+		},
+		{
+			name:   "extension",
+			clause: builder.StorageExtensionIs("png", "jpg"),
+			remote: `((bucket_id = 'avatars'::text) AND ((storage.extension(name) = 'png'::text) OR (storage.extension(name) = 'jpg'::text)))`,
+			code:   `st.Or(st.StorageExtensionIs("png"), st.StorageExtensionIs("jpg"))`,
+		},
+		{
+			name:   "owner",
+			clause: builder.StorageOwnerIsAuth(),
+			remote: `((bucket_id = 'avatars'::text) AND (owner_id = (auth.uid())::text))`,
+			code:   `st.StorageOwnerIsAuth()`,
+		},
+		{
+			name:   "metadata",
+			clause: builder.And(builder.StorageFolderIsAuth(1), builder.StorageMetadataEq("mimetype", "image/png")),
+			remote: `((bucket_id = 'avatars'::text) AND ((storage.foldername(name))[1] = (auth.uid())::text) AND ((metadata ->> 'mimetype'::text) = 'image/png'::text))`,
+			code:   `st.And(st.StorageFolderIsAuth(1), st.StorageMetadataEq("mimetype", "image/png"))`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			local := builder.StorageUsingClause("avatars", c.clause)
+			for _, sql := range []string{local.String(), c.remote} {
+				stripped := builder.StripStorageBucketFilter(sql, "avatars")
+				clause, code, ok := builder.UnmarshalClause(stripped, qualifier)
+				assert.True(t, ok)
+				assert.Equal(t, c.code, code)
+				assert.Equal(t, builder.NormalizeClauseSQL(c.clause.String()), builder.NormalizeClauseSQL(clause.String()))
+			}
+			assert.Equal(t, builder.NormalizeClauseSQL(local.String()), builder.NormalizeClauseSQL(c.remote))
+		})
+	}
+
+	// helper is only used for storage object policy
+	_, code, ok := builder.UnmarshalClause(`owner_id = auth.uid()`, builder.ClauseQualifier{Schema: "public", Table: "notes"})
+	assert.True(t, ok)
+	assert.Equal(t, `st.Eq("owner_id", st.AuthUID())`, code)
+}
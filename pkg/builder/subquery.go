@@ -0,0 +1,240 @@
+package builder
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Typed sub query
+// ----------------------------------------------------------------------------
+
+// SelectQuery is small SELECT builder over model, used to compose membership style policy
+// with ExistsQuery, InQuery and Subquery instead of raw sql string.
+//
+// Unqualified column of where clause refer to the selected table,
+// use TableColOf to refer column of the outer (policy) table.
+//
+// example :
+//
+//	m := &models.Membership{}
+//	p := &models.Project{}
+//	member := builder.ExistsQuery(builder.Select(m).Where(
+//		builder.Eq(builder.ColOf(m, &m.ProjectId), builder.Ident(builder.TableColOf(p, &p.Id))),
+//		builder.OwnerIsAuth(builder.ColOf(m, &m.UserId)),
+//	))
+type SelectQuery struct {
+	schema  string
+	table   string
+	alias   string
+	columns []string
+	where   []Clause
+	limit   int
+}
+
+// Select create sub query from table of model, select 1 when column is not set
+func Select(model any, columns ...string) *SelectQuery {
+	schema, table := TableFromModel(model)
+	return &SelectQuery{schema: schema, table: table, columns: columns}
+}
+
+// SelectFrom create sub query from table name, table can be qualified with schema like `public.memberships`
+func SelectFrom(table string, columns ...string) *SelectQuery {
+	q := &SelectQuery{table: strings.TrimSpace(table), columns: columns}
+	if i := strings.LastIndex(q.table, "."); i > 0 {
+		q.schema, q.table = q.table[:i], q.table[i+1:]
+	}
+	return q
+}
+
+// As set alias of selected table
+func (q *SelectQuery) As(alias string) *SelectQuery {
+	q.alias = strings.TrimSpace(alias)
+	return q
+}
+
+// Where add condition of sub query, multiple condition is combined with AND
+func (q *SelectQuery) Where(cs ...Clause) *SelectQuery {
+	for _, c := range cs {
+		if c.IsEmpty() {
+			continue
+		}
+		q.where = append(q.where, qualifyClause(c, q.ref()))
+	}
+	return q
+}
+
+// Limit set limit of sub query, commonly 1 for scalar sub query
+func (q *SelectQuery) Limit(n int) *SelectQuery {
+	q.limit = n
+	return q
+}
+
+// Col return column of selected table qualified with table name or alias
+func (q *SelectQuery) Col(column string) string {
+	if strings.Contains(column, ".") {
+		return column
+	}
+	return q.ref() + "." + column
+}
+
+func (q *SelectQuery) ref() string {
+	if q.alias != "" {
+		return q.alias
+	}
+	return q.table
+}
+
+func (q *SelectQuery) String() string {
+	columns := "1"
+	if len(q.columns) > 0 {
+		quoted := make([]string, len(q.columns))
+		for i, c := range q.columns {
+			quoted[i] = qi(q.Col(c))
+		}
+		columns = strings.Join(quoted, ", ")
+	}
+
+	from := qi(q.table)
+	if q.schema != "" {
+		from = qi(q.schema + "." + q.table)
+	}
+
+	// alias and where condition is written like postgres deparse the policy,
+	// so definition of applied policy is equal to the local one
+	sql := "SELECT " + columns + " FROM " + from
+	if q.alias != "" {
+		sql += " " + qi(q.alias)
+	}
+
+	switch len(q.where) {
+	case 0:
+	case 1:
+		sql += " WHERE " + q.where[0].Paren().String()
+	default:
+		sql += " WHERE " + And(q.where...).Paren().String()
+	}
+
+	if q.limit > 0 {
+		sql += " LIMIT " + strconv.Itoa(q.limit)
+	}
+	return sql
+}
+
+// ExistsQuery check sub query return any row
+func ExistsQuery(q *SelectQuery) Clause { return Clause("EXISTS (" + q.String() + ")") }
+
+// NotExistsQuery check sub query doesn't return any row
+func NotExistsQuery(q *SelectQuery) Clause { return Not(ExistsQuery(q)) }
+
+// InQuery check column is one of value returned by single column sub query
+func InQuery(col string, q *SelectQuery) Clause {
+	return Clause(qi(col) + " IN (" + q.String() + ")")
+}
+
+// NotInQuery check column is not one of value returned by single column sub query
+func NotInQuery(col string, q *SelectQuery) Clause {
+	return Clause(qi(col) + " NOT IN (" + q.String() + ")")
+}
+
+// Subquery use single row, single column sub query as value of comparison
+func Subquery(q *SelectQuery) Exp { return Exp("(" + q.String() + ")") }
+
+// TableColOf return column of model qualified with table name, like `projects.id`,
+// table outside public schema is also qualified with the schema, like `crm.projects.id`
+func TableColOf(model any, fieldPtr any) string {
+	schema, table := TableFromModel(model)
+	col := table + "." + colNameFromPtr(model, fieldPtr)
+	if schema != "" && schema != "public" {
+		return schema + "." + col
+	}
+	return col
+}
+
+// qualifyClause prefix unqualified quoted identifier of clause with ref,
+// string literal, qualified identifier, quoted function name and nested sub query is kept as is
+func qualifyClause(c Clause, ref string) Clause {
+	s := c.String()
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '(' && strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s[i+1:])), "SELECT ") {
+			end := closingParenthesis(s, i)
+			sb.WriteString(s[i : end+1])
+			i = end
+			continue
+		}
+
+		switch s[i] {
+		case '\'':
+			end := i + 1
+			for end < len(s) {
+				if s[end] == '\'' {
+					if end+1 < len(s) && s[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(s) {
+				end = len(s) - 1
+			}
+			sb.WriteString(s[i : end+1])
+			i = end
+		case '"':
+			end := i + 1
+			for end < len(s) {
+				if s[end] == '"' {
+					if end+1 < len(s) && s[end+1] == '"' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			if end >= len(s) {
+				end = len(s) - 1
+			}
+
+			ident := s[i : end+1]
+			qualified := i > 0 && s[i-1] == '.'
+			qualifier := end+1 < len(s) && (s[end+1] == '.' || s[end+1] == '(')
+			if !qualified && !qualifier {
+				sb.WriteString(qi(ref) + ".")
+			}
+			sb.WriteString(ident)
+			i = end
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return Clause(sb.String())
+}
+
+// closingParenthesis return index of parenthesis that close the one at start,
+// parenthesis inside string literal and quoted identifier is ignored
+func closingParenthesis(s string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s) - 1
+}
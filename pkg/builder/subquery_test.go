@@ -0,0 +1,153 @@
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subqueryMembership struct {
+	Id        int64  `json:"id" column:"name:id;type:bigint"`
+	ProjectId int64  `json:"project_id" column:"name:project_id;type:bigint"`
+	UserId    string `json:"user_id" column:"name:user_id;type:uuid"`
+
+	Metadata string `json:"-" schema:"public" tableName:"memberships"`
+}
+
+type subqueryProject struct {
+	Id    int64  `json:"id" column:"name:id;type:bigint"`
+	OrgId string `json:"org_id" column:"name:org_id;type:uuid"`
+
+	Metadata string `json:"-" schema:"public" tableName:"projects"`
+}
+
+var projectQualifier = builder.ClauseQualifier{Schema: "public", Table: "projects"}
+
+func TestSelectQuery(t *testing.T) {
+	m := &subqueryMembership{}
+	p := &subqueryProject{}
+
+	q := builder.Select(m).Where(
+		builder.Eq(builder.ColOf(m, &m.ProjectId), builder.Ident(builder.TableColOf(p, &p.Id))),
+		builder.OwnerIsAuth(builder.ColOf(m, &m.UserId)),
+	)
+	assert.Equal(t, `SELECT 1 FROM "public"."memberships" WHERE (("memberships"."project_id" = "projects"."id") AND ("memberships"."user_id" = auth.uid()))`, q.String())
+
+	exists := builder.ExistsQuery(q)
+	assert.Equal(t, "EXISTS ("+q.String()+")", exists.String())
+	assert.Equal(t, "NOT (EXISTS ("+q.String()+"))", builder.NotExistsQuery(q).String())
+
+	in := builder.InQuery(builder.ColOf(p, &p.Id), builder.Select(m, builder.ColOf(m, &m.ProjectId)).Where(builder.OwnerIsAuth("user_id")))
+	assert.Equal(t, `"id" IN (SELECT "memberships"."project_id" FROM "public"."memberships" WHERE ("memberships"."user_id" = auth.uid()))`, in.String())
+
+	notIn := builder.NotInQuery("id", builder.SelectFrom("archived_projects", "project_id"))
+	assert.Equal(t, `"id" NOT IN (SELECT "archived_projects"."project_id" FROM "archived_projects")`, notIn.String())
+
+	scalar := builder.Eq("org_id", builder.Subquery(builder.SelectFrom("auth.profiles", "org_id").As("pr").Where(builder.Eq("id", builder.AuthUID())).Limit(1)))
+	assert.Equal(t, `"org_id" = (SELECT "pr"."org_id" FROM "auth"."profiles" "pr" WHERE ("pr"."id" = auth.uid()) LIMIT 1)`, scalar.String())
+}
+
+type subqueryCrmProject struct {
+	Id int64 `json:"id" column:"name:id;type:bigint"`
+
+	Metadata string `json:"-" schema:"crm" tableName:"projects"`
+}
+
+func TestSelectQuery_CrossSchema(t *testing.T) {
+	m := &subqueryMembership{}
+	p := &subqueryCrmProject{}
+
+	assert.Equal(t, "crm.projects.id", builder.TableColOf(p, &p.Id))
+
+	member := builder.ExistsQuery(builder.Select(m).Where(
+		builder.Eq(builder.ColOf(m, &m.ProjectId), builder.Ident(builder.TableColOf(p, &p.Id))),
+	))
+	assert.Equal(t, `EXISTS (SELECT 1 FROM "public"."memberships" WHERE ("memberships"."project_id" = "crm"."projects"."id"))`, member.String())
+
+	// policy of other table keep schema of outer reference
+	clause, code, ok := builder.UnmarshalClause(member.String(), projectQualifier)
+	require.True(t, ok)
+	assert.Equal(t, `st.ExistsQuery(st.SelectFrom("memberships").Where(st.Eq("memberships.project_id", st.Ident("crm.projects.id"))))`, code)
+	assert.Equal(t, `EXISTS (SELECT 1 FROM "memberships" WHERE ("memberships"."project_id" = "crm"."projects"."id"))`, clause.String())
+}
+
+func TestSelectQuery_WhereKeepNestedQuery(t *testing.T) {
+	nested := builder.ExistsQuery(builder.SelectFrom("teams").Where(builder.Eq("owner_id", builder.AuthUID()), builder.Eq("id", builder.Ident("memberships.team_id"))))
+	q := builder.SelectFrom("memberships").Where(nested, builder.EqString("role", "it's"))
+
+	assert.Equal(t, `SELECT 1 FROM "memberships" WHERE ((EXISTS (SELECT 1 FROM "teams" WHERE (("teams"."owner_id" = auth.uid()) AND ("teams"."id" = "memberships"."team_id")))) AND ("memberships"."role" = 'it''s'))`, q.String())
+}
+
+func TestUnmarshalClause_Subquery(t *testing.T) {
+	m := &subqueryMembership{}
+	p := &subqueryProject{}
+
+	built := builder.ExistsQuery(builder.Select(m).Where(
+		builder.Eq(builder.ColOf(m, &m.ProjectId), builder.Ident(builder.TableColOf(p, &p.Id))),
+		builder.OwnerIsAuth(builder.ColOf(m, &m.UserId)),
+	))
+	expectedCode := `st.ExistsQuery(st.SelectFrom("memberships").Where(st.Eq("memberships.project_id", st.Ident("projects.id")), st.Eq("memberships.user_id", st.AuthUID())))`
+
+	clause, code, ok := builder.UnmarshalClause(built.String(), projectQualifier)
+	require.True(t, ok)
+	assert.Equal(t, expectedCode, code)
+	assert.Equal(t, builder.NormalizeClauseSQL(built.String(), projectQualifier), builder.NormalizeClauseSQL(clause.String(), projectQualifier))
+
+	// definition as returned by postgres
+	deparsed := "(EXISTS ( SELECT 1\n   FROM memberships\n  WHERE ((memberships.project_id = projects.id) AND (memberships.user_id = auth.uid()))))"
+	clause, code, ok = builder.UnmarshalClause(deparsed, projectQualifier)
+	require.True(t, ok)
+	assert.Equal(t, expectedCode, code)
+	assert.Equal(t, builder.NormalizeClauseSQL(deparsed, projectQualifier), builder.NormalizeClauseSQL(built.String(), projectQualifier))
+	assert.Equal(t, `EXISTS (SELECT 1 FROM "memberships" WHERE (("memberships"."project_id" = "projects"."id") AND ("memberships"."user_id" = auth.uid())))`, clause.String())
+}
+
+func TestUnmarshalClause_SubqueryVariants(t *testing.T) {
+	cases := []struct {
+		name string
+		sql  string
+		code string
+	}{
+		{
+			name: "in",
+			sql:  "(org_id IN ( SELECT m.org_id\n   FROM memberships m\n  WHERE (m.user_id = auth.uid())))",
+			code: `st.InQuery("org_id", st.SelectFrom("memberships", "m.org_id").As("m").Where(st.Eq("m.user_id", st.AuthUID())))`,
+		},
+		{
+			name: "not in",
+			sql:  "(id NOT IN ( SELECT archived_projects.project_id\n   FROM archived_projects))",
+			code: `st.NotInQuery("id", st.SelectFrom("archived_projects", "archived_projects.project_id"))`,
+		},
+		{
+			name: "not exists",
+			sql:  "(NOT (EXISTS ( SELECT 1\n   FROM bans\n  WHERE (bans.user_id = auth.uid()))))",
+			code: `st.Not(st.ExistsQuery(st.SelectFrom("bans").Where(st.Eq("bans.user_id", st.AuthUID()))))`,
+		},
+		{
+			name: "scalar",
+			sql:  "(org_id = ( SELECT profiles.org_id\n   FROM profiles\n  WHERE (profiles.id = auth.uid())\n LIMIT 1))",
+			code: `st.Eq("org_id", st.Subquery(st.SelectFrom("profiles", "profiles.org_id").Where(st.Eq("profiles.id", st.AuthUID())).Limit(1)))`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clause, code, ok := builder.UnmarshalClause(c.sql, projectQualifier)
+			require.True(t, ok)
+			assert.Equal(t, c.code, code)
+			assert.Equal(t, builder.NormalizeClauseSQL(c.sql, projectQualifier), builder.NormalizeClauseSQL(clause.String(), projectQualifier))
+		})
+	}
+}
+
+func TestUnmarshalClause_UnsupportedSubquery(t *testing.T) {
+	// outer column can't be resolved without qualifier
+	_, code, ok := builder.UnmarshalClause("EXISTS (SELECT 1 FROM memberships WHERE memberships.project_id = id)")
+	assert.False(t, ok)
+	assert.Equal(t, `st.Clause("EXISTS (SELECT 1 FROM memberships WHERE memberships.project_id = id)")`, code)
+
+	_, _, ok = builder.UnmarshalClause("EXISTS (SELECT 1 FROM memberships JOIN teams ON teams.id = memberships.team_id)", projectQualifier)
+	assert.False(t, ok)
+}
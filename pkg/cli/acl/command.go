@@ -0,0 +1,73 @@
+package acl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	raiden_acl "github.com/sev-2/raiden/pkg/acl"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var AclLogger hclog.Logger = logger.HcLog().Named("acl")
+
+// The `Flags` struct is used to store the value of report command flags,
+// format of report, role to report and output file of report.
+type Flags struct {
+	Format string
+	Roles  []string
+	Output string
+}
+
+// The `Bind` method is used to bind the `Flags` struct to a `cobra.Command` object.
+func (f *Flags) Bind(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Format, "format", "f", string(raiden_acl.ReportFormatText), "report format, available format is text, json and markdown")
+	cmd.Flags().StringSliceVarP(&f.Roles, "role", "r", []string{}, "role to report, all role is reported when not specified")
+	cmd.Flags().StringVarP(&f.Output, "output", "o", "", "write report to file instead of stdout")
+}
+
+// The function `PreRun` checks if the state file exist in the specified project path,
+// report is built from state that is created when run import or apply.
+func PreRun(projectPath string) error {
+	stateFile := filepath.Join(projectPath, state.StateFileDir, state.StateFileName)
+	if !utils.IsFileExists(stateFile) {
+		return errors.New("missing state file (./build/state), run `raiden imports` first for sync state")
+	}
+	return nil
+}
+
+// The Run function build effective access report from local state,
+// and write the report to stdout or output file.
+func Run(f *Flags, projectPath string) error {
+	AclLogger.Debug("build effective access report", "roles", f.Roles)
+	report, err := raiden_acl.GetReport(raiden_acl.ReportOptions{Roles: f.Roles})
+	if err != nil {
+		return err
+	}
+
+	content, err := raiden_acl.RenderReport(report, raiden_acl.ReportFormat(f.Format))
+	if err != nil {
+		return err
+	}
+
+	if f.Output == "" {
+		fmt.Println(content)
+		return nil
+	}
+
+	outputPath := f.Output
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(projectPath, outputPath)
+	}
+
+	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	AclLogger.Info("report is written", "path", outputPath)
+	return nil
+}
@@ -0,0 +1,30 @@
+package generate
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+// ClientFlags is flags for generate client sdk command.
+// @property {string} Lang - Lang is language of generated client, currently only support `ts`.
+// @property {string} Output - Output is generated file path, relative to project path.
+type ClientFlags struct {
+	Lang   string
+	Output string
+}
+
+func (f *ClientFlags) Bind(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Lang, "lang", "l", string(generator.ClientLanguageTypescript), "language of generated client, available language : ts")
+	cmd.Flags().StringVarP(&f.Output, "output", "o", "", "output file path, default to client/raiden_client.ts")
+}
+
+// The `RunClient` function generates typed client sdk from controllers, models and rpc in project.
+func RunClient(flags *ClientFlags, config *raiden.Config, projectPath string) error {
+	GenerateLogger.Debug("start generate client", "lang", flags.Lang)
+	if err := generator.GenerateClient(projectPath, config.Mode, generator.ClientLanguage(flags.Lang), flags.Output, generator.Generate); err != nil {
+		return err
+	}
+	GenerateLogger.Info("finish generate client")
+	return nil
+}
@@ -98,6 +98,13 @@ func Run(flags *Flags, config *raiden.Config, projectPath string, initialize boo
 		return err
 	}
 
+	if flags.IsGenerateAll() || flags.RpcOnly {
+		GenerateLogger.Debug("validate rpc definition")
+		if err := generator.ValidateRpc(projectPath); err != nil {
+			return err
+		}
+	}
+
 	wg, errChan := sync.WaitGroup{}, make(chan error)
 	go func() {
 		wg.Wait()
@@ -30,6 +30,10 @@ func (q *Query) Count(opts ...CountOptions) (int, error) {
 		}
 	}
 
+	if _, _, err := q.tenantScope(); err != nil {
+		return 0, err
+	}
+
 	url := q.GetUrl()
 
 	headers := make(map[string]string)
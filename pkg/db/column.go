@@ -0,0 +1,231 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Column is typed reference to column of model T, V is the go type of the column value.
+// Comparison value is checked by compiler, so filter like `id=eq.abc` on bigint column
+// can not be created.
+//
+// example :
+//
+//	var m models.Article
+//	title := db.ColOf(&m, &m.Title)
+//
+//	articles, err := db.From[models.Article](ctx).
+//		Where(title.Ilike("%raiden%")).
+//		All()
+type Column[T any, V any] struct {
+	name string
+}
+
+// ColumnOf is implemented by every column of model T regardless the value type,
+// used by operation that only need column name like select and order.
+type ColumnOf[T any] interface {
+	ColumnName() string
+	columnOf(*T)
+}
+
+// ColOf resolve column from pointer of model field, column name is taken from `column` tag
+// and fallback to `json` tag. ColOf panic when field is not part of model.
+func ColOf[T any, V any](model *T, field *V) Column[T, V] {
+	if model == nil || field == nil {
+		panic("db: model and field are required")
+	}
+
+	name, ok := findColumnName(reflect.ValueOf(model).Elem(), reflect.ValueOf(field).Pointer(), reflect.TypeOf(field).Elem())
+	if !ok {
+		panic(fmt.Sprintf("db: field is not column of %T", *model))
+	}
+	return Column[T, V]{name: name}
+}
+
+// NewColumn create typed column by name,
+// use it when column is not declared as model field, for example computed column.
+func NewColumn[T any, V any](name string) Column[T, V] {
+	return Column[T, V]{name: name}
+}
+
+func (c Column[T, V]) ColumnName() string {
+	return c.name
+}
+
+func (c Column[T, V]) columnOf(*T) {}
+
+func (c Column[T, V]) Eq(value V) Condition[T] {
+	return newCondition[T](c.name, "eq", formatColumnValue(value))
+}
+
+func (c Column[T, V]) Neq(value V) Condition[T] {
+	return newCondition[T](c.name, "neq", formatColumnValue(value))
+}
+
+func (c Column[T, V]) Gt(value V) Condition[T] {
+	return newCondition[T](c.name, "gt", formatColumnValue(value))
+}
+
+func (c Column[T, V]) Gte(value V) Condition[T] {
+	return newCondition[T](c.name, "gte", formatColumnValue(value))
+}
+
+func (c Column[T, V]) Lt(value V) Condition[T] {
+	return newCondition[T](c.name, "lt", formatColumnValue(value))
+}
+
+func (c Column[T, V]) Lte(value V) Condition[T] {
+	return newCondition[T](c.name, "lte", formatColumnValue(value))
+}
+
+func (c Column[T, V]) In(values ...V) Condition[T] {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, formatColumnListValue(v))
+	}
+	return newCondition[T](c.name, "in", fmt.Sprintf("(%s)", strings.Join(items, ",")))
+}
+
+// Like match value with pattern, `%` is converted to postgrest wildcard `*`
+func (c Column[T, V]) Like(pattern string) Condition[T] {
+	return newCondition[T](c.name, "like", escapeColumnValue(strings.ReplaceAll(pattern, "%", "*")))
+}
+
+// Ilike is case insensitive version of Like
+func (c Column[T, V]) Ilike(pattern string) Condition[T] {
+	return newCondition[T](c.name, "ilike", escapeColumnValue(strings.ReplaceAll(pattern, "%", "*")))
+}
+
+func (c Column[T, V]) IsNull() Condition[T] {
+	return newCondition[T](c.name, "is", "null")
+}
+
+func (c Column[T, V]) IsTrue() Condition[T] {
+	return newCondition[T](c.name, "is", "true")
+}
+
+func (c Column[T, V]) IsFalse() Condition[T] {
+	return newCondition[T](c.name, "is", "false")
+}
+
+// Condition is single filter of model T
+type Condition[T any] struct {
+	column   string
+	operator string
+	value    string
+	literal  valueLiteral
+	negate   bool
+}
+
+// valueLiteral is kind of literal that condition value is formatted to
+type valueLiteral int
+
+const (
+	literalNone valueLiteral = iota
+	literalArray
+	literalJson
+)
+
+func newCondition[T any](column, operator, value string) Condition[T] {
+	return Condition[T]{column: column, operator: operator, value: value}
+}
+
+// Not negate condition, for example `id=eq.1` become `id=not.eq.1`
+func (c Condition[T]) Not() Condition[T] {
+	c.negate = !c.negate
+	return c
+}
+
+// String return condition in query param format, for example `id=eq.1`
+func (c Condition[T]) String() string {
+	return fmt.Sprintf("%s=%s", c.column, c.expression())
+}
+
+// orString return condition in logical operator format, for example `id.eq.1`
+func (c Condition[T]) orString() string {
+	return fmt.Sprintf("%s.%s", c.column, c.expression())
+}
+
+func (c Condition[T]) expression() string {
+	if c.negate {
+		return fmt.Sprintf("not.%s.%s", c.operator, c.value)
+	}
+	return fmt.Sprintf("%s.%s", c.operator, c.value)
+}
+
+func findColumnName(structValue reflect.Value, target uintptr, targetType reflect.Type) (string, bool) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if name, ok := findColumnName(fieldValue, target, targetType); ok {
+				return name, true
+			}
+			continue
+		}
+
+		if field.Type != targetType || fieldValue.Addr().Pointer() != target {
+			continue
+		}
+
+		for _, t := range strings.Split(field.Tag.Get("column"), ";") {
+			if strings.HasPrefix(t, "name:") {
+				return strings.TrimPrefix(t, "name:"), true
+			}
+		}
+
+		if jsonName := strings.Split(field.Tag.Get("json"), ",")[0]; jsonName != "" && jsonName != "-" {
+			return jsonName, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+func formatColumnValue(value any) string {
+	return escapeColumnValue(rawColumnValue(value))
+}
+
+// formatColumnListValue format value as item of list,
+// value that contain reserved character is double quoted
+func formatColumnListValue(value any) string {
+	raw := rawColumnValue(value)
+	if strings.ContainsAny(raw, ",()\" ") {
+		raw = fmt.Sprintf("\"%s\"", strings.ReplaceAll(raw, "\"", "\\\""))
+	}
+	return escapeColumnValue(raw)
+}
+
+func rawColumnValue(value any) string {
+	rv := reflect.ValueOf(value)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "null"
+		}
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() {
+		return "null"
+	}
+
+	switch v := rv.Interface().(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+var columnValueEscaper = strings.NewReplacer("%", "%25", "&", "%26", "#", "%23", "+", "%2B", " ", "%20")
+
+// escapeColumnValue escape character that break query string
+func escapeColumnValue(value string) string {
+	return columnValueEscaper.Replace(value)
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type ColumnMockModel struct {
+	ModelBase
+
+	Id        int64     `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	Code      uuid.UUID `json:"code" column:"name:code;type:uuid"`
+	Title     string    `json:"title,omitempty" column:"name:title;type:text"`
+	Score     *float64  `json:"score" column:"name:score;type:numeric;nullable"`
+	Rank      int64     `json:"rank"`
+	PublishAt time.Time `json:"publish_at" column:"name:publish_at;type:timestamptz"`
+
+	Metadata string `json:"-" schema:"public" tableName:"columns"`
+}
+
+func TestColOf(t *testing.T) {
+	var m ColumnMockModel
+
+	assert.Equal(t, "id", ColOf(&m, &m.Id).ColumnName())
+	assert.Equal(t, "title", ColOf(&m, &m.Title).ColumnName())
+	assert.Equal(t, "score", ColOf(&m, &m.Score).ColumnName())
+	assert.Equal(t, "rank", ColOf(&m, &m.Rank).ColumnName())
+
+	var other ColumnMockModel
+	assert.Panics(t, func() { ColOf(&m, &other.Id) })
+	assert.Panics(t, func() { ColOf(&m, &m.Metadata) })
+}
+
+func TestColumnCondition(t *testing.T) {
+	var m ColumnMockModel
+	id := ColOf(&m, &m.Id)
+	title := ColOf(&m, &m.Title)
+	score := ColOf(&m, &m.Score)
+	code := ColOf(&m, &m.Code)
+	publishAt := ColOf(&m, &m.PublishAt)
+
+	value := 4.5
+	codeValue := uuid.MustParse("2c5ea4c0-4067-11e9-8bad-9b1deb4d3b7d")
+
+	assert.Equal(t, "id=eq.1", id.Eq(1).String())
+	assert.Equal(t, "id=neq.1", id.Neq(1).String())
+	assert.Equal(t, "id=gt.1", id.Gt(1).String())
+	assert.Equal(t, "id=gte.1", id.Gte(1).String())
+	assert.Equal(t, "id=lt.1", id.Lt(1).String())
+	assert.Equal(t, "id=lte.1", id.Lte(1).String())
+	assert.Equal(t, "id=not.eq.1", id.Eq(1).Not().String())
+	assert.Equal(t, "id=in.(1,2,3)", id.In(1, 2, 3).String())
+	assert.Equal(t, `title=in.(a,"b,c","d%20e")`, title.In("a", "b,c", "d e").String())
+	assert.Equal(t, "title=eq.rock%20%26%20roll", title.Eq("rock & roll").String())
+	assert.Equal(t, "title=ilike.*raiden*", title.Ilike("%raiden%").String())
+	assert.Equal(t, "title=like.raiden*", title.Like("raiden%").String())
+	assert.Equal(t, "score=eq.4.5", score.Eq(&value).String())
+	assert.Equal(t, "score=is.null", score.IsNull().String())
+	assert.Equal(t, "score=not.is.null", score.IsNull().Not().String())
+	assert.Equal(t, "code=eq.2c5ea4c0-4067-11e9-8bad-9b1deb4d3b7d", code.Eq(codeValue).String())
+	assert.Equal(t, "publish_at=gte.2024-01-02T03:04:05%2B07:00", publishAt.Gte(time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("WIB", 7*3600))).String())
+	assert.Equal(t, "id.eq.1", id.Eq(1).orString())
+}
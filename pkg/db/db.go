@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"reflect"
@@ -20,6 +21,7 @@ type Query struct {
 	model        interface{}
 	Columns      []string
 	Relations    []string
+	preloads     *[]*preloadNode
 	WhereAndList *[]string
 	WhereOrList  *[]string
 	IsList       *[]string
@@ -29,6 +31,11 @@ type Query struct {
 	Errors       []error
 	ByPass       bool
 	credential   Credential
+	withTrashed  bool
+	forceDelete  bool
+
+	tenant        string
+	withoutTenant bool
 }
 
 type ModelBase struct {
@@ -89,6 +96,13 @@ func (m *ModelBase) Execute() (model *ModelBase) {
 }
 
 func (q Query) Get(collection interface{}) error {
+	if q.HasError() {
+		return errors.Join(q.Errors...)
+	}
+
+	if _, _, err := q.tenantScope(); err != nil {
+		return err
+	}
 
 	url := q.GetUrl()
 
@@ -101,10 +115,18 @@ func (q Query) Get(collection interface{}) error {
 		return err
 	}
 
-	return nil
+	return q.afterFind(collection)
 }
 
 func (q Query) Single(model interface{}) error {
+	if q.HasError() {
+		return errors.Join(q.Errors...)
+	}
+
+	if _, _, err := q.tenantScope(); err != nil {
+		return err
+	}
+
 	url := q.Limit(1).GetUrl()
 
 	headers := make(map[string]string)
@@ -116,7 +138,7 @@ func (q Query) Single(model interface{}) error {
 		return err
 	}
 
-	return nil
+	return q.afterFind(model)
 }
 
 func (q Query) GetQueryURI() string {
@@ -162,6 +184,10 @@ func buildQueryURI(q Query) string {
 		output += "," + strings.Join(q.Relations, ",")
 	}
 
+	if q.preloads != nil && len(*q.preloads) > 0 {
+		output += "," + strings.Join(buildPreloadSelect(*q.preloads), ",")
+	}
+
 	if q.WhereAndList != nil && len(*q.WhereAndList) > 0 {
 		eqList := strings.Join(*q.WhereAndList, "&")
 		output += fmt.Sprintf("&%s", eqList)
@@ -177,6 +203,14 @@ func buildQueryURI(q Query) string {
 		output += fmt.Sprintf("&%s", list)
 	}
 
+	if column, ok := q.softDeleteColumn(); ok {
+		output += fmt.Sprintf("&%s=is.null", column)
+	}
+
+	if filter := q.tenantFilter(); filter != "" {
+		output += "&" + filter
+	}
+
 	if q.OrderList != nil && len(*q.OrderList) > 0 {
 		orders := strings.Join(*q.OrderList, ",")
 		output += fmt.Sprintf("&order=%s", orders)
@@ -5,17 +5,20 @@ import (
 )
 
 func (q *Query) Delete() error {
-	url := q.GetUrl()
+	_, err := q.DeleteWith(nil, MutationOptions{})
+	return err
+}
 
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/json"
-	headers["Prefer"] = "return=representation"
+// DeleteWith delete all row that match the filter and bind deleted row into result,
+// row of soft delete model is marked as deleted instead, use ForceDelete to remove it
+func (q *Query) DeleteWith(result interface{}, opts MutationOptions) (MutationResult, error) {
+	if err := q.beforeDelete(); err != nil {
+		return MutationResult{}, err
+	}
 
-	var a interface{}
-	_, err := PostgrestRequest(q.Context, q.credential, fasthttp.MethodDelete, url, nil, headers, q.ByPass, &a)
-	if err != nil {
-		return err
+	if column, found := getColumnByOption(q.model, SoftDeleteOption); found && !q.forceDelete {
+		return q.softDelete(column, result, opts)
 	}
 
-	return nil
+	return q.mutate(fasthttp.MethodDelete, q.mutationUrl(opts), nil, result, opts)
 }
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupEmulator(t *testing.T) *mock.MockPostgrest {
+	pg := mock.NewMockPostgrest(&ArticleMockModel{})
+	err := pg.Seed(
+		[]TeamsMockModel{
+			{Id: 1, Name: "core", OrganizationId: 1},
+			{Id: 2, Name: "growth", OrganizationId: 1},
+		},
+		[]UsersMockModel{
+			{Id: 1, Username: "john", TeamId: 1},
+			{Id: 2, Username: "jane", TeamId: 2},
+		},
+		[]ArticleMockModel{
+			{Id: 1, UserId: 1, Title: "Hello Raiden", Rating: 5, IsFeatured: true, Tags: []string{"go", "raiden"}},
+			{Id: 2, UserId: 1, Title: "Go generics", Rating: 3, Tags: []string{"go"}},
+			{Id: 3, UserId: 2, Title: "Supabase tips", Rating: 4, IsFeatured: true},
+		},
+	)
+	assert.NoError(t, err)
+
+	GetClientFn = func() Client { return pg }
+	t.Cleanup(func() { GetClientFn = DefaultGetClientFn })
+	return pg
+}
+
+func articleTitles(articles []ArticleMockModel) []string {
+	titles := make([]string, 0, len(articles))
+	for _, a := range articles {
+		titles = append(titles, a.Title)
+	}
+	return titles
+}
+
+func TestEmulator_Get(t *testing.T) {
+	setupEmulator(t)
+
+	var articles []ArticleMockModel
+	err := NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		Gte("rating", 4).
+		OrderDesc("rating").
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Hello Raiden", "Supabase tips"}, articleTitles(articles))
+
+	articles = nil
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		Where(Or(Like("title", "%generics"), And(Eq("user_id", 2), Is("is_featured", true)))).
+		OrderAsc("id").
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go generics", "Supabase tips"}, articleTitles(articles))
+
+	articles = nil
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		Where(In("id", 1, 3), ArrayContains("tags", []string{"raiden"})).
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Hello Raiden"}, articleTitles(articles))
+
+	articles = nil
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		OrderAsc("id").
+		Limit(1).
+		Offset(1).
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go generics"}, articleTitles(articles))
+}
+
+func TestEmulator_Preload(t *testing.T) {
+	setupEmulator(t)
+
+	var articles []ArticleMockModel
+	err := NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		PreloadWith("User", PreloadOptions{Filters: []Filter{Eq("team_id", 2)}, Inner: true}).
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, "Supabase tips", articles[0].Title)
+	assert.Equal(t, "jane", articles[0].User.Username)
+
+	articles = nil
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		PreloadWith("User.Team", PreloadOptions{Columns: []string{"id", "name"}}).
+		OrderAsc("id").
+		Get(&articles)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 3)
+	assert.Equal(t, "core", articles[0].User.Team.Name)
+	assert.Equal(t, "growth", articles[2].User.Team.Name)
+}
+
+func TestEmulator_SingleAndCount(t *testing.T) {
+	setupEmulator(t)
+
+	var article ArticleMockModel
+	err := NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).Eq("id", 2).Single(&article)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go generics", article.Title)
+
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).Eq("id", 10).Single(&article)
+	assert.EqualError(t, err, "JSON object requested, multiple (or no) rows returned")
+
+	count, err := NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).Eq("user_id", 1).Count()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).Eq("unknown", 1).Single(&article)
+	assert.EqualError(t, err, "column articles.unknown does not exist")
+}
+
+func TestEmulator_Mutation(t *testing.T) {
+	pg := setupEmulator(t)
+
+	var inserted []ArticleMockModel
+	result, err := NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).InsertWith(
+		[]ArticleMockModel{{UserId: 2, Title: "New article", Rating: 2}},
+		&inserted,
+		MutationOptions{Count: "exact"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+	assert.Equal(t, int64(4), inserted[0].Id)
+	assert.Len(t, pg.Rows("articles"), 4)
+
+	_, err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).InsertWith(ArticleMockModel{Id: 1, Title: "Duplicate"}, nil, MutationOptions{})
+	assert.Error(t, err)
+	assert.Len(t, pg.Rows("articles"), 4)
+
+	var updated []ArticleMockModel
+	result, err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		Eq("user_id", 1).
+		UpdateWith(map[string]any{"rating": 1}, &updated, MutationOptions{Count: "exact"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	for _, a := range updated {
+		assert.Equal(t, int64(1), a.Rating)
+	}
+
+	var upserted []ArticleMockModel
+	_, err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).UpsertWith(
+		[]map[string]any{{"id": 3, "title": "Supabase tricks"}, {"id": 5, "user_id": 1, "title": "Upserted"}},
+		&upserted,
+		UpsertOptions{OnConflict: "id"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Supabase tricks", "Upserted"}, articleTitles(upserted))
+	assert.Equal(t, int64(4), upserted[0].Rating)
+
+	result, err = NewQuery(&mockRaidenContext).Model(ArticleMockModel{}).
+		Lt("rating", 2).
+		DeleteWith(nil, MutationOptions{Count: "exact"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.Len(t, pg.Rows("articles"), 3)
+}
+
+func TestEmulator_TypedQuery(t *testing.T) {
+	setupEmulator(t)
+
+	var m ArticleMockModel
+	rating := ColOf(&m, &m.Rating)
+
+	articles, err := From[ArticleMockModel](&mockRaidenContext).
+		Where(rating.Gt(3)).
+		OrderAsc(rating).
+		All()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Supabase tips", "Hello Raiden"}, articleTitles(articles))
+}
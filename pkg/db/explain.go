@@ -0,0 +1,118 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ExplainOptions is option of postgres EXPLAIN, postgrest must be configured with `db-plan-enabled`
+type ExplainOptions struct {
+	// Analyze execute the query and return actual time and row count
+	Analyze  bool
+	Verbose  bool
+	Buffers  bool
+	Settings bool
+	Wal      bool
+}
+
+// ExplainPlan is result of EXPLAIN (FORMAT JSON)
+type ExplainPlan struct {
+	Plan          PlanNode `json:"Plan"`
+	PlanningTime  float64  `json:"Planning Time,omitempty"`
+	ExecutionTime float64  `json:"Execution Time,omitempty"`
+}
+
+// PlanNode is single node of query plan, actual field is only available when Analyze is enabled
+type PlanNode struct {
+	NodeType            string     `json:"Node Type"`
+	ParentRelationship  string     `json:"Parent Relationship,omitempty"`
+	RelationName        string     `json:"Relation Name,omitempty"`
+	Schema              string     `json:"Schema,omitempty"`
+	Alias               string     `json:"Alias,omitempty"`
+	IndexName           string     `json:"Index Name,omitempty"`
+	IndexCond           string     `json:"Index Cond,omitempty"`
+	Filter              string     `json:"Filter,omitempty"`
+	RowsRemovedByFilter float64    `json:"Rows Removed by Filter,omitempty"`
+	StartupCost         float64    `json:"Startup Cost"`
+	TotalCost           float64    `json:"Total Cost"`
+	PlanRows            float64    `json:"Plan Rows"`
+	PlanWidth           int        `json:"Plan Width"`
+	ActualStartupTime   float64    `json:"Actual Startup Time,omitempty"`
+	ActualTotalTime     float64    `json:"Actual Total Time,omitempty"`
+	ActualRows          float64    `json:"Actual Rows,omitempty"`
+	ActualLoops         float64    `json:"Actual Loops,omitempty"`
+	SharedHitBlocks     int64      `json:"Shared Hit Blocks,omitempty"`
+	SharedReadBlocks    int64      `json:"Shared Read Blocks,omitempty"`
+	Output              []string   `json:"Output,omitempty"`
+	Plans               []PlanNode `json:"Plans,omitempty"`
+}
+
+// AcceptHeader return postgrest plan media type, for example
+// `application/vnd.pgrst.plan+json; for="application/json"; options=analyze|buffers`
+func (o ExplainOptions) AcceptHeader() string {
+	var options []string
+	if o.Analyze {
+		options = append(options, "analyze")
+	}
+	if o.Verbose {
+		options = append(options, "verbose")
+	}
+	if o.Buffers {
+		options = append(options, "buffers")
+	}
+	if o.Settings {
+		options = append(options, "settings")
+	}
+	if o.Wal {
+		options = append(options, "wal")
+	}
+
+	accept := `application/vnd.pgrst.plan+json; for="application/json"`
+	if len(options) > 0 {
+		accept += "; options=" + strings.Join(options, "|")
+	}
+	return accept
+}
+
+// Explain return query plan of the query instead of the data
+func (q Query) Explain(opts ExplainOptions) (*ExplainPlan, error) {
+	if q.HasError() {
+		return nil, errors.Join(q.Errors...)
+	}
+
+	if _, _, err := q.tenantScope(); err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	headers["Accept"] = opts.AcceptHeader()
+
+	var body json.RawMessage
+	_, err := PostgrestRequest(q.Context, q.credential, fasthttp.MethodGet, q.GetUrl(), nil, headers, q.ByPass, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseExplainPlan(body)
+}
+
+// ParseExplainPlan parse EXPLAIN (FORMAT JSON) output, postgres return the plan wrapped in json array
+func ParseExplainPlan(data []byte) (*ExplainPlan, error) {
+	var plans []ExplainPlan
+	if err := json.Unmarshal(data, &plans); err != nil {
+		var plan ExplainPlan
+		if errSingle := json.Unmarshal(data, &plan); errSingle != nil {
+			return nil, fmt.Errorf("failed to unmarshal explain plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if len(plans) == 0 || plans[0].Plan.NodeType == "" {
+		return nil, fmt.Errorf("failed to unmarshal explain plan: plan is empty, make sure db-plan-enabled is set in postgrest")
+	}
+	return &plans[0], nil
+}
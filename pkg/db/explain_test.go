@@ -0,0 +1,73 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainOptions_AcceptHeader(t *testing.T) {
+	assert.Equal(t, `application/vnd.pgrst.plan+json; for="application/json"`, ExplainOptions{}.AcceptHeader())
+	assert.Equal(t, `application/vnd.pgrst.plan+json; for="application/json"; options=analyze|verbose|buffers|settings|wal`, ExplainOptions{
+		Analyze:  true,
+		Verbose:  true,
+		Buffers:  true,
+		Settings: true,
+		Wal:      true,
+	}.AcceptHeader())
+}
+
+func TestParseExplainPlan(t *testing.T) {
+	data := []byte(`[{
+		"Plan": {
+			"Node Type": "Limit",
+			"Startup Cost": 0.00,
+			"Total Cost": 1.50,
+			"Plan Rows": 10,
+			"Plan Width": 64,
+			"Actual Total Time": 0.12,
+			"Actual Rows": 3,
+			"Actual Loops": 1,
+			"Plans": [{
+				"Node Type": "Seq Scan",
+				"Parent Relationship": "Outer",
+				"Relation Name": "articles",
+				"Filter": "(rating >= 4)",
+				"Rows Removed by Filter": 7,
+				"Shared Hit Blocks": 2,
+				"Plan Rows": 10,
+				"Plan Width": 64
+			}]
+		},
+		"Planning Time": 0.08,
+		"Execution Time": 0.21
+	}]`)
+
+	plan, err := ParseExplainPlan(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Limit", plan.Plan.NodeType)
+	assert.Equal(t, 1.5, plan.Plan.TotalCost)
+	assert.Equal(t, float64(3), plan.Plan.ActualRows)
+	assert.Equal(t, 0.21, plan.ExecutionTime)
+	assert.Len(t, plan.Plan.Plans, 1)
+	assert.Equal(t, "articles", plan.Plan.Plans[0].RelationName)
+	assert.Equal(t, "(rating >= 4)", plan.Plan.Plans[0].Filter)
+	assert.Equal(t, int64(2), plan.Plan.Plans[0].SharedHitBlocks)
+
+	single, err := ParseExplainPlan([]byte(`{"Plan":{"Node Type":"Seq Scan"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Seq Scan", single.Plan.NodeType)
+
+	_, err = ParseExplainPlan([]byte(`[{"id":1}]`))
+	assert.Error(t, err)
+
+	_, err = ParseExplainPlan([]byte(`invalid`))
+	assert.Error(t, err)
+}
+
+func TestExplain_QueryError(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Select([]string{"unknown"})
+	plan, err := q.Explain(ExplainOptions{Analyze: true})
+	assert.Nil(t, plan)
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter is condition or group of condition that can be nested,
+// group is compiled to postgrest logical operator `and=(...)` and `or=(...)`.
+//
+// example :
+//
+//	// is_featured=eq.true&or=(rating.gte.4,and(user_id.eq.1,title.ilike.*raiden*))
+//	q.Where(
+//		db.Eq("is_featured", true),
+//		db.Or(
+//			db.Gte("rating", 4),
+//			db.And(db.Eq("user_id", 1), db.Ilike("title", "%raiden%")),
+//		),
+//	)
+type Filter interface {
+	// filterParam return filter as query param key and value,
+	// for example `id` and `eq.1` or `or` and `(id.eq.1,id.eq.2)`
+	filterParam() (string, string)
+
+	// filterTerm return filter as term of logical operator,
+	// for example `id.eq.1` or `or(id.eq.1,id.eq.2)`
+	filterTerm() string
+
+	negateFilter() Filter
+	isEmptyFilter() bool
+}
+
+type filterGroup struct {
+	operator string
+	filters  []Filter
+	negate   bool
+}
+
+func Eq(column string, value any) Filter {
+	return newCondition[any](column, "eq", formatColumnValue(value))
+}
+
+func Neq(column string, value any) Filter {
+	return newCondition[any](column, "neq", formatColumnValue(value))
+}
+
+func Gt(column string, value any) Filter {
+	return newCondition[any](column, "gt", formatColumnValue(value))
+}
+
+func Gte(column string, value any) Filter {
+	return newCondition[any](column, "gte", formatColumnValue(value))
+}
+
+func Lt(column string, value any) Filter {
+	return newCondition[any](column, "lt", formatColumnValue(value))
+}
+
+func Lte(column string, value any) Filter {
+	return newCondition[any](column, "lte", formatColumnValue(value))
+}
+
+func Like(column string, pattern string) Filter {
+	return newCondition[any](column, "like", escapeColumnValue(strings.ReplaceAll(pattern, "%", "*")))
+}
+
+func Ilike(column string, pattern string) Filter {
+	return newCondition[any](column, "ilike", escapeColumnValue(strings.ReplaceAll(pattern, "%", "*")))
+}
+
+func In(column string, values ...any) Filter {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, formatColumnListValue(v))
+	}
+	return newCondition[any](column, "in", fmt.Sprintf("(%s)", strings.Join(items, ",")))
+}
+
+func Is(column string, value any) Filter {
+	if getWhitelistIsValue(value) == "" {
+		panic("getWhitelistIsValue: only \"true\", \"false\", \"nil\", \"null\", or \"unknown\" are allowed")
+	}
+	return newCondition[any](column, "is", getWhitelistIsValue(value))
+}
+
+// And group filters that must be matched by all row
+func And(filters ...Filter) Filter {
+	return &filterGroup{operator: "and", filters: filters}
+}
+
+// Or group filters that at least one of them must be matched
+func Or(filters ...Filter) Filter {
+	return &filterGroup{operator: "or", filters: filters}
+}
+
+// Not negate filter or group, for example `or=(...)` become `not.or=(...)`
+func Not(filter Filter) Filter {
+	return filter.negateFilter()
+}
+
+func (g *filterGroup) filterParam() (string, string) {
+	key := g.operator
+	if g.negate {
+		key = "not." + key
+	}
+	return key, fmt.Sprintf("(%s)", strings.Join(g.terms(), ","))
+}
+
+func (g *filterGroup) filterTerm() string {
+	key, value := g.filterParam()
+	return key + value
+}
+
+func (g *filterGroup) negateFilter() Filter {
+	return &filterGroup{operator: g.operator, filters: g.filters, negate: !g.negate}
+}
+
+func (g *filterGroup) isEmptyFilter() bool {
+	return len(g.terms()) == 0
+}
+
+func (g *filterGroup) terms() []string {
+	terms := make([]string, 0, len(g.filters))
+	for _, f := range g.filters {
+		if f == nil || f.isEmptyFilter() {
+			continue
+		}
+		terms = append(terms, f.filterTerm())
+	}
+	return terms
+}
+
+func (c Condition[T]) filterParam() (string, string) {
+	return c.column, c.expression()
+}
+
+var filterTermEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+
+// filterTerm quote value that contain reserved character of logical operator,
+// value of `in` operator and array literal `{...}` is already quoted per item,
+// json value is always quoted because nested object is not allowed unquoted
+func (c Condition[T]) filterTerm() string {
+	isPlain := c.literal == literalNone && !strings.ContainsAny(c.value, ",()\"")
+	if c.operator == "in" || c.literal == literalArray || isPlain {
+		return c.orString()
+	}
+
+	value := fmt.Sprintf("\"%s\"", filterTermEscaper.Replace(c.value))
+	if c.negate {
+		return fmt.Sprintf("%s.not.%s.%s", c.column, c.operator, value)
+	}
+	return fmt.Sprintf("%s.%s.%s", c.column, c.operator, value)
+}
+
+func (c Condition[T]) negateFilter() Filter {
+	return c.Not()
+}
+
+func (c Condition[T]) isEmptyFilter() bool {
+	return c.column == ""
+}
+
+// Where add filters that must be matched by all row,
+// filter can be single condition or nested group created with And / Or
+func (q *Query) Where(filters ...Filter) *Query {
+	return q.addFilters("", filters)
+}
+
+// WhereRelation add filters to embedded resource,
+// for example `user.or=(id.eq.1,id.eq.2)`, relation must be loaded with Preload
+func (q *Query) WhereRelation(relation string, filters ...Filter) *Query {
+	return q.addFilters(relation, filters)
+}
+
+func (q *Query) addFilters(relation string, filters []Filter) *Query {
+	for _, f := range filters {
+		if f == nil || f.isEmptyFilter() {
+			continue
+		}
+
+		if q.WhereAndList == nil {
+			q.WhereAndList = &[]string{}
+		}
+
+		key, value := f.filterParam()
+		if relation != "" {
+			key = fmt.Sprintf("%s.%s", relation, key)
+		}
+
+		*q.WhereAndList = append(*q.WhereAndList, fmt.Sprintf("%s=%s", key, value))
+	}
+	return q
+}
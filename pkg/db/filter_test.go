@@ -0,0 +1,67 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhere_Nested(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Eq("user_id", 1),
+		Or(
+			Eq("rating", 5),
+			And(Gte("rating", 3), Is("is_featured", true)),
+		),
+	)
+
+	assert.Equal(t, "articles?select=*&user_id=eq.1&or=(rating.eq.5,and(rating.gte.3,is_featured.is.true))", buildQueryURI(*q))
+}
+
+func TestWhere_Negated(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Not(Or(Eq("id", 1), Not(Eq("id", 2)))),
+		Not(And(Like("title", "%raiden%"), In("id", 1, 2))),
+	)
+
+	assert.Equal(t, "articles?select=*&not.or=(id.eq.1,id.not.eq.2)&not.and=(title.like.*raiden*,id.in.(1,2))", buildQueryURI(*q))
+}
+
+func TestWhere_QuoteReservedValue(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Eq("title", "a,b"),
+		Or(Eq("title", "a,b"), Ilike("title", "(draft)"), In("title", "x,y", "z")),
+	)
+
+	assert.Equal(t, `articles?select=*&title=eq.a,b&or=(title.eq."a,b",title.ilike."(draft)",title.in.("x,y",z))`, buildQueryURI(*q))
+}
+
+func TestWhere_SkipEmptyGroup(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Or(),
+		And(Or(), Eq("id", 1)),
+	)
+
+	assert.Equal(t, "articles?select=*&and=(id.eq.1)", buildQueryURI(*q))
+}
+
+func TestWhereRelation(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		Preload("User").
+		WhereRelation("user", Or(Eq("username", "raiden"), Eq("team_id", 1))).
+		WhereRelation("user", Neq("id", 2))
+
+	assert.Equal(t, "articles?select=*,user:users!user_id(*)&user.or=(username.eq.raiden,team_id.eq.1)&user.id=neq.2", buildQueryURI(*q))
+}
+
+func TestWhere_TypedCondition(t *testing.T) {
+	var m ArticleMockModel
+	id := ColOf(&m, &m.Id)
+	rating := ColOf(&m, &m.Rating)
+
+	q := From[ArticleMockModel](&mockRaidenContext).
+		Where(rating.Gte(3)).
+		WhereGroup(Or(id.Eq(1), And(id.Gt(10), rating.Lt(5).Not())))
+
+	assert.Equal(t, "articles?select=*&rating=gte.3&or=(id.eq.1,and(id.gt.10,rating.not.lt.5))", q.GetQueryURI())
+}
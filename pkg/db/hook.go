@@ -0,0 +1,166 @@
+package db
+
+import (
+	"reflect"
+
+	"github.com/sev-2/raiden"
+)
+
+// BeforeInsertHook is called for every payload before insert and upsert request is sent,
+// model implement it to fill field like timestamp, slug or audit column.
+// Error returned from any hook abort the request.
+//
+// example :
+//
+//	func (m *Article) BeforeInsert(ctx raiden.Context) error {
+//		m.Slug = slug.Make(m.Title)
+//		m.CreatedAt = time.Now()
+//		return nil
+//	}
+type BeforeInsertHook interface {
+	BeforeInsert(ctx raiden.Context) error
+}
+
+// AfterInsertHook is called for every returned row after insert and upsert succeed,
+// payload is used when returned row is not bind
+type AfterInsertHook interface {
+	AfterInsert(ctx raiden.Context) error
+}
+
+// BeforeUpdateHook is called for update payload before request is sent
+type BeforeUpdateHook interface {
+	BeforeUpdate(ctx raiden.Context) error
+}
+
+// AfterUpdateHook is called for every returned row after update succeed,
+// payload is used when returned row is not bind
+type AfterUpdateHook interface {
+	AfterUpdate(ctx raiden.Context) error
+}
+
+// BeforeDeleteHook is called for query model before delete request is sent
+type BeforeDeleteHook interface {
+	BeforeDelete(ctx raiden.Context) error
+}
+
+// AfterFindHook is called for every row returned by Get and Single
+type AfterFindHook interface {
+	AfterFind(ctx raiden.Context) error
+}
+
+// applyHook call hook of every model in target, target can be struct, pointer to struct,
+// slice or pointer to slice. Struct value is copied before the hook is called,
+// so the returned value must be used instead of target.
+func applyHook[H any](target any, call func(hook H) error) (any, error) {
+	rv := reflect.ValueOf(target)
+	if !rv.IsValid() {
+		return target, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return target, nil
+		}
+
+		if rv.Elem().Kind() == reflect.Slice {
+			return target, applySliceHook(rv.Elem(), call)
+		}
+		return target, callHook(rv, call)
+	case reflect.Struct:
+		copied := reflect.New(rv.Type())
+		copied.Elem().Set(rv)
+		if err := callHook(copied, call); err != nil {
+			return target, err
+		}
+		return copied.Interface(), nil
+	case reflect.Slice:
+		return target, applySliceHook(rv, call)
+	}
+
+	return target, nil
+}
+
+func applySliceHook[H any](slice reflect.Value, call func(hook H) error) error {
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+
+		switch item.Kind() {
+		case reflect.Ptr:
+			if item.IsNil() {
+				continue
+			}
+			if err := callHook(item, call); err != nil {
+				return err
+			}
+		case reflect.Interface:
+			if item.IsNil() {
+				continue
+			}
+
+			// value inside interface is not addressable, call hook on copy and put it back
+			inner := item.Elem()
+			if inner.Kind() == reflect.Ptr {
+				if err := callHook(inner, call); err != nil {
+					return err
+				}
+				continue
+			}
+
+			copied := reflect.New(inner.Type())
+			copied.Elem().Set(inner)
+			if err := callHook(copied, call); err != nil {
+				return err
+			}
+			item.Set(copied.Elem())
+		default:
+			if err := callHook(item.Addr(), call); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func callHook[H any](model reflect.Value, call func(hook H) error) error {
+	if hook, ok := model.Interface().(H); ok {
+		return call(hook)
+	}
+	return nil
+}
+
+// hookTarget return bind result as target of after hook, payload is used when result is not bind
+func hookTarget(result any, payload any) any {
+	if result != nil {
+		return result
+	}
+	return payload
+}
+
+func (q *Query) beforeInsert(payload any) (any, error) {
+	return applyHook(payload, func(h BeforeInsertHook) error { return h.BeforeInsert(q.Context) })
+}
+
+func (q *Query) afterInsert(target any) error {
+	_, err := applyHook(target, func(h AfterInsertHook) error { return h.AfterInsert(q.Context) })
+	return err
+}
+
+func (q *Query) beforeUpdate(payload any) (any, error) {
+	return applyHook(payload, func(h BeforeUpdateHook) error { return h.BeforeUpdate(q.Context) })
+}
+
+func (q *Query) afterUpdate(target any) error {
+	_, err := applyHook(target, func(h AfterUpdateHook) error { return h.AfterUpdate(q.Context) })
+	return err
+}
+
+func (q *Query) beforeDelete() error {
+	_, err := applyHook(q.model, func(h BeforeDeleteHook) error { return h.BeforeDelete(q.Context) })
+	return err
+}
+
+func (q *Query) afterFind(target any) error {
+	_, err := applyHook(target, func(h AfterFindHook) error { return h.AfterFind(q.Context) })
+	return err
+}
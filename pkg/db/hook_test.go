@@ -0,0 +1,115 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+type HookMockModel struct {
+	ModelBase
+
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	Title string `json:"title,omitempty" column:"name:title;type:text"`
+	Slug  string `json:"slug,omitempty" column:"name:slug;type:text"`
+
+	Metadata string `json:"-" schema:"public" tableName:"hooks"`
+
+	found bool
+}
+
+func (m *HookMockModel) BeforeInsert(ctx raiden.Context) error {
+	if m.Title == "" {
+		return errors.New("title is required")
+	}
+	m.Slug = "slug-" + m.Title
+	return nil
+}
+
+func (m *HookMockModel) BeforeDelete(ctx raiden.Context) error {
+	return errors.New("delete is not allowed")
+}
+
+func (m *HookMockModel) AfterFind(ctx raiden.Context) error {
+	m.found = true
+	return nil
+}
+
+func TestApplyHook(t *testing.T) {
+	q := NewQuery(&mockRaidenContext)
+	call := func(h BeforeInsertHook) error { return h.BeforeInsert(q.Context) }
+
+	t.Run("struct value is copied", func(t *testing.T) {
+		model := HookMockModel{Title: "a"}
+		result, err := applyHook(model, call)
+		assert.NoError(t, err)
+		assert.Equal(t, "", model.Slug)
+		assert.Equal(t, "slug-a", result.(*HookMockModel).Slug)
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		model := &HookMockModel{Title: "b"}
+		result, err := applyHook(model, call)
+		assert.NoError(t, err)
+		assert.Equal(t, model, result)
+		assert.Equal(t, "slug-b", model.Slug)
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		models := []HookMockModel{{Title: "c"}, {Title: "d"}}
+		_, err := applyHook(models, call)
+		assert.NoError(t, err)
+		assert.Equal(t, "slug-d", models[1].Slug)
+
+		pointers := &[]*HookMockModel{{Title: "e"}, nil}
+		_, err = applyHook(pointers, call)
+		assert.NoError(t, err)
+		assert.Equal(t, "slug-e", (*pointers)[0].Slug)
+
+		values := []interface{}{HookMockModel{Title: "f"}, &HookMockModel{Title: "g"}, nil}
+		_, err = applyHook(values, call)
+		assert.NoError(t, err)
+		assert.Equal(t, "slug-f", values[0].(HookMockModel).Slug)
+		assert.Equal(t, "slug-g", values[1].(*HookMockModel).Slug)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := applyHook([]HookMockModel{{Title: "h"}, {}}, call)
+		assert.EqualError(t, err, "title is required")
+	})
+
+	t.Run("model without hook", func(t *testing.T) {
+		article := ArticleMockModel{Title: "i"}
+		result, err := applyHook(&article, call)
+		assert.NoError(t, err)
+		assert.Equal(t, &article, result)
+	})
+}
+
+func TestHook_AbortRequest(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(HookMockModel{})
+
+	err := q.Insert(HookMockModel{}, nil)
+	assert.EqualError(t, err, "title is required")
+
+	_, err = q.UpsertWith([]HookMockModel{{Title: "a"}, {}}, nil, UpsertOptions{OnConflict: "slug"})
+	assert.EqualError(t, err, "title is required")
+
+	err = q.Delete()
+	assert.EqualError(t, err, "delete is not allowed")
+}
+
+func TestHook_AfterFind(t *testing.T) {
+	q := NewQuery(&mockRaidenContext)
+
+	rows := []HookMockModel{{Id: 1}, {Id: 2}}
+	assert.NoError(t, q.afterFind(&rows))
+	assert.True(t, rows[0].found)
+	assert.True(t, rows[1].found)
+
+	row := HookMockModel{Id: 1}
+	assert.NoError(t, q.afterFind(&row))
+	assert.True(t, row.found)
+}
@@ -7,21 +7,31 @@ import (
 )
 
 func (q *Query) Insert(payload interface{}, model interface{}) error {
-	jsonData, err := json.Marshal(payload)
+	_, err := q.InsertWith(payload, model, MutationOptions{})
+	return err
+}
+
+// InsertWith insert single or bulk payload and bind inserted row into result,
+// result can be nil when returned row is not needed
+func (q *Query) InsertWith(payload interface{}, result interface{}, opts MutationOptions) (MutationResult, error) {
+	payload, err := q.beforeInsert(payload)
 	if err != nil {
-		return err
+		return MutationResult{}, err
 	}
 
-	url := q.GetUrl()
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return MutationResult{}, err
+	}
 
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/json"
-	headers["Prefer"] = "return=representation"
+	if jsonData, err = q.stampTenant(jsonData); err != nil {
+		return MutationResult{}, err
+	}
 
-	_, err0 := PostgrestRequest(q.Context, q.credential, fasthttp.MethodPost, url, jsonData, headers, q.ByPass, model)
-	if err0 != nil {
-		return err0
+	mutationResult, err := q.mutate(fasthttp.MethodPost, q.unscoped().mutationUrl(opts), jsonData, result, opts)
+	if err != nil {
+		return mutationResult, err
 	}
 
-	return nil
+	return mutationResult, q.afterInsert(hookTarget(result, payload))
 }
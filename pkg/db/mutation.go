@@ -0,0 +1,190 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	ReturnRepresentation = "representation"
+	ReturnMinimal        = "minimal"
+	ReturnHeadersOnly    = "headers-only"
+)
+
+// MutationOptions control postgrest `Prefer` header of insert, update, upsert and delete
+type MutationOptions struct {
+	// Return is `representation`, `minimal` or `headers-only`,
+	// default is representation when result is bind and minimal otherwise
+	Return string
+
+	// Count is `exact`, `planned` or `estimated`, affected row count is returned in MutationResult
+	Count string
+
+	// MissingDefault fill missing key of bulk insert payload with column default instead of null
+	MissingDefault bool
+
+	// Columns limit payload key that inserted or updated
+	Columns []string
+}
+
+type MutationResult struct {
+	// Count is affected row count, only available when MutationOptions.Count is set
+	Count int
+}
+
+func (o MutationOptions) validate() error {
+	switch o.Return {
+	case "", ReturnRepresentation, ReturnMinimal, ReturnHeadersOnly:
+	default:
+		return fmt.Errorf("unrecognized return options: %s", o.Return)
+	}
+
+	switch o.Count {
+	case "", "exact", "planned", "estimated":
+	default:
+		return fmt.Errorf("unrecognized count options: %s", o.Count)
+	}
+	return nil
+}
+
+// preferences return value of `Prefer` header, extra preferences like resolution is put first
+func (o MutationOptions) preferences(hasResult bool, extra ...string) string {
+	preferences := append([]string{}, extra...)
+
+	returning := o.Return
+	if returning == "" {
+		returning = ReturnMinimal
+		if hasResult {
+			returning = ReturnRepresentation
+		}
+	}
+	preferences = append(preferences, "return="+returning)
+
+	if o.Count != "" {
+		preferences = append(preferences, "count="+o.Count)
+	}
+
+	if o.MissingDefault {
+		preferences = append(preferences, "missing=default")
+	}
+
+	return strings.Join(preferences, ",")
+}
+
+// mutationUrl return url of mutation request with additional query param
+func (q *Query) mutationUrl(opts MutationOptions, params ...string) string {
+	url := q.GetUrl()
+	if len(opts.Columns) > 0 {
+		params = append(params, "columns="+strings.Join(opts.Columns, ","))
+	}
+
+	if len(params) > 0 {
+		url += "&" + strings.Join(params, "&")
+	}
+	return url
+}
+
+// mutate send mutation request and bind returned row into result,
+// result can be pointer to slice or pointer to struct for single row
+func (q *Query) mutate(method string, url string, payload []byte, result interface{}, opts MutationOptions, extraPreferences ...string) (MutationResult, error) {
+	var mutationResult MutationResult
+
+	if q.HasError() {
+		return mutationResult, errors.Join(q.Errors...)
+	}
+
+	if _, _, err := q.tenantScope(); err != nil {
+		return mutationResult, err
+	}
+
+	if err := opts.validate(); err != nil {
+		return mutationResult, err
+	}
+
+	headers := make(map[string]string)
+	headers["Content-Type"] = "application/json"
+	headers["Prefer"] = opts.preferences(result != nil, extraPreferences...)
+
+	countInterceptor := func(res *fasthttp.Response) error {
+		if opts.Count == "" {
+			return nil
+		}
+
+		count, err := parseContentRangeCount(string(res.Header.Peek("Content-Range")))
+		if err != nil {
+			return err
+		}
+		mutationResult.Count = count
+		return nil
+	}
+
+	var body json.RawMessage
+	_, err := PostgrestRequest(q.Context, q.credential, method, url, payload, headers, q.ByPass, &body, countInterceptor)
+	if err != nil {
+		return mutationResult, err
+	}
+
+	if result != nil {
+		if err := bindMutationResult(body, result); err != nil {
+			return mutationResult, err
+		}
+	}
+
+	return mutationResult, nil
+}
+
+// bindMutationResult bind returned row into result, postgrest always return json array
+// so the first row is used when result is not a slice
+func bindMutationResult(body []byte, result interface{}) error {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+
+	resultType := reflect.TypeOf(result)
+	for resultType.Kind() == reflect.Ptr {
+		resultType = resultType.Elem()
+	}
+
+	if body[0] != '[' || resultType.Kind() == reflect.Slice || resultType.Kind() == reflect.Interface {
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+		return nil
+	}
+
+	var rows []json.RawMessage
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(rows[0], result); err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+	return nil
+}
+
+// parseContentRangeCount return total from content range header, for example `0-9/10` or `*/10`
+func parseContentRangeCount(contentRange string) (int, error) {
+	parts := strings.Split(contentRange, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid Content-Range format")
+	}
+
+	if parts[1] == "*" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(parts[1])
+}
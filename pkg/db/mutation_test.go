@@ -0,0 +1,82 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutationOptions_Preferences(t *testing.T) {
+	assert.Equal(t, "return=representation", MutationOptions{}.preferences(true))
+	assert.Equal(t, "return=minimal", MutationOptions{}.preferences(false))
+	assert.Equal(t, "resolution=merge-duplicates,return=headers-only,count=exact,missing=default", MutationOptions{
+		Return:         ReturnHeadersOnly,
+		Count:          "exact",
+		MissingDefault: true,
+	}.preferences(true, "resolution="+MergeDuplicates))
+}
+
+func TestMutationOptions_Validate(t *testing.T) {
+	assert.NoError(t, MutationOptions{Return: ReturnMinimal, Count: "planned"}.validate())
+	assert.EqualError(t, MutationOptions{Return: "all"}.validate(), "unrecognized return options: all")
+	assert.EqualError(t, MutationOptions{Count: "wrong"}.validate(), "unrecognized count options: wrong")
+
+	_, err := NewQuery(&mockRaidenContext).Model(articleMockModel).DeleteWith(nil, MutationOptions{Count: "wrong"})
+	assert.EqualError(t, err, "unrecognized count options: wrong")
+}
+
+func TestMutationUrl(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Eq("id", 1)
+
+	assert.Equal(t, "/rest/v1/articles?select=*&id=eq.1", q.mutationUrl(MutationOptions{}))
+	assert.Equal(t, "/rest/v1/articles?select=*&id=eq.1&on_conflict=user_id,title&columns=title,body", q.mutationUrl(MutationOptions{Columns: []string{"title", "body"}}, "on_conflict=user_id,title"))
+}
+
+func TestBindMutationResult(t *testing.T) {
+	body := []byte(`[{"id":1,"title":"foo"},{"id":2,"title":"bar"}]`)
+
+	var rows []ArticleMockModel
+	assert.NoError(t, bindMutationResult(body, &rows))
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "bar", rows[1].Title)
+
+	var row ArticleMockModel
+	assert.NoError(t, bindMutationResult(body, &row))
+	assert.Equal(t, int64(1), row.Id)
+
+	var empty ArticleMockModel
+	assert.NoError(t, bindMutationResult([]byte(`[]`), &empty))
+	assert.Equal(t, int64(0), empty.Id)
+
+	var raw interface{}
+	assert.NoError(t, bindMutationResult(body, &raw))
+	assert.Len(t, raw, 2)
+
+	assert.NoError(t, bindMutationResult(nil, &row))
+	assert.Error(t, bindMutationResult([]byte(`[{"id":"x"}]`), &row))
+}
+
+func TestParseContentRangeCount(t *testing.T) {
+	count, err := parseContentRangeCount("0-9/25")
+	assert.NoError(t, err)
+	assert.Equal(t, 25, count)
+
+	count, err = parseContentRangeCount("*/3")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = parseContentRangeCount("0-9/*")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	_, err = parseContentRangeCount("")
+	assert.Error(t, err)
+}
+
+func TestUpsertWith_InvalidResolution(t *testing.T) {
+	_, err := NewQuery(&mockRaidenContext).
+		Model(articleMockModel).
+		UpsertWith([]ArticleMockModel{{Id: 1}}, nil, UpsertOptions{OnConflict: "id", Resolution: "replace"})
+
+	assert.EqualError(t, err, "unrecognized resolution options: replace")
+}
@@ -0,0 +1,317 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Full-text search
+// ----------------------------------------------------------------------------
+
+// Fts match column with to_tsquery, config is optional text search config like `english`
+func Fts(column string, query string, config ...string) Filter {
+	return newCondition[any](column, ftsOperator("fts", config), escapeColumnValue(query))
+}
+
+// Plfts match column with plainto_tsquery
+func Plfts(column string, query string, config ...string) Filter {
+	return newCondition[any](column, ftsOperator("plfts", config), escapeColumnValue(query))
+}
+
+// Phfts match column with phraseto_tsquery
+func Phfts(column string, query string, config ...string) Filter {
+	return newCondition[any](column, ftsOperator("phfts", config), escapeColumnValue(query))
+}
+
+// Wfts match column with websearch_to_tsquery
+func Wfts(column string, query string, config ...string) Filter {
+	return newCondition[any](column, ftsOperator("wfts", config), escapeColumnValue(query))
+}
+
+// Search is the Wfts alias, same as builder.Search for policy
+func Search(column string, query string, config ...string) Filter {
+	return Wfts(column, query, config...)
+}
+
+// ----------------------------------------------------------------------------
+// Array, range and json containment
+// ----------------------------------------------------------------------------
+
+// Contains match column that contain value (`@>`),
+// slice value is encoded as array literal, map or struct value as json
+// and string value is sent as is, for example range literal `[1,10)`
+func Contains(column string, value any) Filter {
+	return newOperatorCondition[any](column, "cs", value)
+}
+
+// ContainedBy match column that contained by value (`<@`)
+func ContainedBy(column string, value any) Filter {
+	return newOperatorCondition[any](column, "cd", value)
+}
+
+// Overlaps match column that have element or range in common with value (`&&`)
+func Overlaps(column string, value any) Filter {
+	return newOperatorCondition[any](column, "ov", value)
+}
+
+// ArrayContains is the Contains alias for array column, same as builder.ArrayContains for policy
+func ArrayContains(column string, values any) Filter {
+	return Contains(column, values)
+}
+
+// ArrayContainedBy is the ContainedBy alias for array column
+func ArrayContainedBy(column string, values any) Filter {
+	return ContainedBy(column, values)
+}
+
+// ArrayOverlaps is the Overlaps alias for array column
+func ArrayOverlaps(column string, values any) Filter {
+	return Overlaps(column, values)
+}
+
+// RangeLeft match range column that strictly left of value (`<<`)
+func RangeLeft(column string, value string) Filter {
+	return newCondition[any](column, "sl", escapeColumnValue(value))
+}
+
+// RangeRight match range column that strictly right of value (`>>`)
+func RangeRight(column string, value string) Filter {
+	return newCondition[any](column, "sr", escapeColumnValue(value))
+}
+
+// RangeNotExtendLeft match range column that does not extend to the left of value (`&>`)
+func RangeNotExtendLeft(column string, value string) Filter {
+	return newCondition[any](column, "nxl", escapeColumnValue(value))
+}
+
+// RangeNotExtendRight match range column that does not extend to the right of value (`&<`)
+func RangeNotExtendRight(column string, value string) Filter {
+	return newCondition[any](column, "nxr", escapeColumnValue(value))
+}
+
+// RangeAdjacent match range column that adjacent to value (`-|-`)
+func RangeAdjacent(column string, value string) Filter {
+	return newCondition[any](column, "adj", escapeColumnValue(value))
+}
+
+// ----------------------------------------------------------------------------
+// Pattern matching
+// ----------------------------------------------------------------------------
+
+// Match match column with posix regular expression (`~`)
+func Match(column string, pattern string) Filter {
+	return newCondition[any](column, "match", escapeColumnValue(pattern))
+}
+
+// Imatch is case insensitive version of Match (`~*`)
+func Imatch(column string, pattern string) Filter {
+	return newCondition[any](column, "imatch", escapeColumnValue(pattern))
+}
+
+// ----------------------------------------------------------------------------
+// JSON path
+// ----------------------------------------------------------------------------
+
+// JSONPath build json arrow path that return json, for example `data->address->city`,
+// the result can be used as column in filter, select and order
+func JSONPath(column string, path ...string) string {
+	if len(path) == 0 {
+		return column
+	}
+	return fmt.Sprintf("%s->%s", column, strings.Join(path, "->"))
+}
+
+// JSONPathText build json arrow path that return text, for example `data->address->>city`
+func JSONPathText(column string, path ...string) string {
+	if len(path) == 0 {
+		return column
+	}
+
+	last := len(path) - 1
+	return fmt.Sprintf("%s->>%s", JSONPath(column, path[:last]...), path[last])
+}
+
+// ----------------------------------------------------------------------------
+// Query methods
+// ----------------------------------------------------------------------------
+
+func (q *Query) Fts(column string, query string, config ...string) *Query {
+	return q.Where(Fts(column, query, config...))
+}
+
+func (q *Query) Plfts(column string, query string, config ...string) *Query {
+	return q.Where(Plfts(column, query, config...))
+}
+
+func (q *Query) Phfts(column string, query string, config ...string) *Query {
+	return q.Where(Phfts(column, query, config...))
+}
+
+func (q *Query) Wfts(column string, query string, config ...string) *Query {
+	return q.Where(Wfts(column, query, config...))
+}
+
+func (q *Query) Search(column string, query string, config ...string) *Query {
+	return q.Where(Search(column, query, config...))
+}
+
+func (q *Query) Contains(column string, value any) *Query {
+	return q.Where(Contains(column, value))
+}
+
+func (q *Query) ContainedBy(column string, value any) *Query {
+	return q.Where(ContainedBy(column, value))
+}
+
+func (q *Query) Overlaps(column string, value any) *Query {
+	return q.Where(Overlaps(column, value))
+}
+
+func (q *Query) ArrayContains(column string, values any) *Query {
+	return q.Where(ArrayContains(column, values))
+}
+
+func (q *Query) ArrayContainedBy(column string, values any) *Query {
+	return q.Where(ArrayContainedBy(column, values))
+}
+
+func (q *Query) ArrayOverlaps(column string, values any) *Query {
+	return q.Where(ArrayOverlaps(column, values))
+}
+
+func (q *Query) RangeLeft(column string, value string) *Query {
+	return q.Where(RangeLeft(column, value))
+}
+
+func (q *Query) RangeRight(column string, value string) *Query {
+	return q.Where(RangeRight(column, value))
+}
+
+func (q *Query) RangeNotExtendLeft(column string, value string) *Query {
+	return q.Where(RangeNotExtendLeft(column, value))
+}
+
+func (q *Query) RangeNotExtendRight(column string, value string) *Query {
+	return q.Where(RangeNotExtendRight(column, value))
+}
+
+func (q *Query) RangeAdjacent(column string, value string) *Query {
+	return q.Where(RangeAdjacent(column, value))
+}
+
+func (q *Query) Match(column string, pattern string) *Query {
+	return q.Where(Match(column, pattern))
+}
+
+func (q *Query) Imatch(column string, pattern string) *Query {
+	return q.Where(Imatch(column, pattern))
+}
+
+// ----------------------------------------------------------------------------
+// Typed column
+// ----------------------------------------------------------------------------
+
+func (c Column[T, V]) Fts(query string, config ...string) Condition[T] {
+	return newCondition[T](c.name, ftsOperator("fts", config), escapeColumnValue(query))
+}
+
+func (c Column[T, V]) Plfts(query string, config ...string) Condition[T] {
+	return newCondition[T](c.name, ftsOperator("plfts", config), escapeColumnValue(query))
+}
+
+func (c Column[T, V]) Phfts(query string, config ...string) Condition[T] {
+	return newCondition[T](c.name, ftsOperator("phfts", config), escapeColumnValue(query))
+}
+
+func (c Column[T, V]) Wfts(query string, config ...string) Condition[T] {
+	return newCondition[T](c.name, ftsOperator("wfts", config), escapeColumnValue(query))
+}
+
+func (c Column[T, V]) Contains(value V) Condition[T] {
+	return newOperatorCondition[T](c.name, "cs", value)
+}
+
+func (c Column[T, V]) ContainedBy(value V) Condition[T] {
+	return newOperatorCondition[T](c.name, "cd", value)
+}
+
+func (c Column[T, V]) Overlaps(value V) Condition[T] {
+	return newOperatorCondition[T](c.name, "ov", value)
+}
+
+func (c Column[T, V]) Match(pattern string) Condition[T] {
+	return newCondition[T](c.name, "match", escapeColumnValue(pattern))
+}
+
+func (c Column[T, V]) Imatch(pattern string) Condition[T] {
+	return newCondition[T](c.name, "imatch", escapeColumnValue(pattern))
+}
+
+func ftsOperator(operator string, config []string) string {
+	if len(config) > 0 && config[0] != "" {
+		return fmt.Sprintf("%s(%s)", operator, config[0])
+	}
+	return operator
+}
+
+// newOperatorCondition create condition with value formatted by formatOperatorValue,
+// kind of the value is kept so filterTerm doesn't have to guess it from the formatted value
+func newOperatorCondition[T any](column, operator string, value any) Condition[T] {
+	c := newCondition[T](column, operator, "")
+	c.value, c.literal = formatOperatorValue(value)
+	return c
+}
+
+func formatOperatorValue(value any) (string, valueLiteral) {
+	rv := reflect.ValueOf(value)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return "null", literalNone
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		items := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, formatArrayItem(rv.Index(i).Interface()))
+		}
+		return escapeColumnValue(fmt.Sprintf("{%s}", strings.Join(items, ","))), literalArray
+	case reflect.Map, reflect.Struct:
+		if _, isTime := rv.Interface().(time.Time); isTime {
+			break
+		}
+		if _, isStringer := rv.Interface().(fmt.Stringer); isStringer {
+			break
+		}
+
+		jsonValue, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return escapeColumnValue(fmt.Sprintf("%v", rv.Interface())), literalNone
+		}
+		return escapeColumnValue(string(jsonValue)), literalJson
+	}
+
+	return formatColumnValue(rv.Interface()), literalNone
+}
+
+// formatArrayItem format item of postgres array literal,
+// item that contain reserved character is double quoted
+func formatArrayItem(value any) string {
+	raw := rawColumnValue(value)
+	if raw == "" || strings.ContainsAny(raw, ",{}\"\\ ") {
+		raw = strings.ReplaceAll(raw, "\\", "\\\\")
+		raw = fmt.Sprintf("\"%s\"", strings.ReplaceAll(raw, "\"", "\\\""))
+	}
+	return raw
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullTextSearch(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		Fts("title", "fat & cat").
+		Plfts("body", "fat cat", "english").
+		Phfts("body", "fat cat").
+		Wfts("title", "\"fat cat\" -rat", "english").
+		Search("body", "raiden")
+
+	assert.Equal(t, "articles?select=*&title=fts.fat%20%26%20cat&body=plfts(english).fat%20cat&body=phfts.fat%20cat&title=wfts(english).\"fat%20cat\"%20-rat&body=wfts.raiden", buildQueryURI(*q))
+}
+
+func TestContainment(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		ArrayContains("tags", []string{"go", "hello world"}).
+		ArrayContainedBy("tags", []string{"a,b", ""}).
+		ArrayOverlaps("ids", []int{1, 2}).
+		Contains("metadata", map[string]any{"draft": true}).
+		ContainedBy("period", "[2024-01-01,2024-12-31]").
+		Overlaps("period", "[1,10)")
+
+	assert.Equal(t, `articles?select=*&tags=cs.{go,"hello%20world"}&tags=cd.{"a,b",""}&ids=ov.{1,2}&metadata=cs.{"draft":true}&period=cd.[2024-01-01,2024-12-31]&period=ov.[1,10)`, buildQueryURI(*q))
+}
+
+func TestRangeOperator(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		RangeLeft("period", "[1,10]").
+		RangeRight("period", "[1,10]").
+		RangeNotExtendLeft("period", "[1,10]").
+		RangeNotExtendRight("period", "[1,10]").
+		RangeAdjacent("period", "[1,10]")
+
+	assert.Equal(t, "articles?select=*&period=sl.[1,10]&period=sr.[1,10]&period=nxl.[1,10]&period=nxr.[1,10]&period=adj.[1,10]", buildQueryURI(*q))
+}
+
+func TestMatch(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		Match("title", "^ra+iden$").
+		Imatch("body", "go lang")
+
+	assert.Equal(t, "articles?select=*&title=match.^ra%2Biden$&body=imatch.go%20lang", buildQueryURI(*q))
+}
+
+func TestOperatorInGroup(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Or(
+			ArrayContains("tags", []string{"a", "b"}),
+			Not(Overlaps("period", "[1,10)")),
+			Fts("title", "cat", "english"),
+		),
+	)
+
+	assert.Equal(t, `articles?select=*&or=(tags.cs.{a,b},period.not.ov."[1,10)",title.fts(english).cat)`, buildQueryURI(*q))
+}
+
+func TestOperatorJSONInGroup(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).Where(
+		Or(
+			Contains("metadata", map[string]any{"address": map[string]string{"city": "a,b"}}),
+			ContainedBy("metadata", map[string]any{}),
+			Contains("title", "{draft}"),
+		),
+	)
+
+	assert.Equal(t, `articles?select=*&or=(metadata.cs."{\"address\":{\"city\":\"a,b\"}}",metadata.cd."{}",title.cs.{draft})`, buildQueryURI(*q))
+}
+
+func TestJSONPath(t *testing.T) {
+	assert.Equal(t, "data", JSONPath("data"))
+	assert.Equal(t, "data->address->city", JSONPath("data", "address", "city"))
+	assert.Equal(t, "data->>city", JSONPathText("data", "city"))
+	assert.Equal(t, "data->address->>city", JSONPathText("data", "address", "city"))
+
+	q := NewQuery(&mockRaidenContext).Model(articleMockModel).
+		Select([]string{"id", "city:" + JSONPathText("body", "address", "city")}).
+		Eq(JSONPathText("body", "address", "city"), "Bandung").
+		OrderAsc(JSONPath("body", "rank"))
+
+	assert.Empty(t, q.Errors)
+	assert.Equal(t, "articles?select=id,city:body->address->>city&body->address->>city=eq.Bandung&order=body->rank.asc", buildQueryURI(*q))
+}
+
+func TestTypedColumnOperator(t *testing.T) {
+	var m ArticleMockModel
+	tags := ColOf(&m, &m.Tags)
+	title := ColOf(&m, &m.Title)
+
+	assert.Equal(t, "tags=cs.{go,raiden}", tags.Contains([]string{"go", "raiden"}).String())
+	assert.Equal(t, "tags=cd.{go}", tags.ContainedBy([]string{"go"}).String())
+	assert.Equal(t, "tags=ov.{go}", tags.Overlaps([]string{"go"}).String())
+	assert.Equal(t, "title=fts(english).cat", title.Fts("cat", "english").String())
+	assert.Equal(t, "title=plfts.fat%20cat", title.Plfts("fat cat").String())
+	assert.Equal(t, "title=phfts.fat%20cat", title.Phfts("fat cat").String())
+	assert.Equal(t, "title=wfts.cat", title.Wfts("cat").String())
+	assert.Equal(t, "title=match.^a", title.Match("^a").String())
+	assert.Equal(t, "title=imatch.^a", title.Imatch("^a").String())
+}
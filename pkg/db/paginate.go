@@ -0,0 +1,188 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sev-2/raiden/pkg/paginate"
+	"github.com/valyala/fasthttp"
+)
+
+// Paginate fetch one page of query and bind the row into []T.
+//
+// Offset pagination use Page and Limit of options. Cursor pagination is keyset pagination
+// over SortKeys (default is CursorRefColumn or id ascending), the order of query is replaced
+// by the sort keys and Cursor is opaque token returned as NextCursor or PrevCursor of previous page.
+//
+// example :
+//
+//	result, err := db.Paginate[models.Article](db.NewQuery(ctx).From(models.Article{}).Eq("status", "published"), paginate.ExecuteOptions{
+//		Type:      paginate.CursorPagination,
+//		Limit:     20,
+//		Cursor:    string(ctx.RequestContext().QueryArgs().Peek("cursor")),
+//		SortKeys:  []paginate.SortKey{paginate.Desc("created_at"), paginate.Desc("id")},
+//		WithCount: true,
+//	})
+func Paginate[T any](q *Query, opts paginate.ExecuteOptions) (paginate.ExecuteResult[T], error) {
+	var result paginate.ExecuteResult[T]
+
+	if q.HasError() {
+		return result, errors.Join(q.Errors...)
+	}
+
+	if _, _, err := q.tenantScope(); err != nil {
+		return result, err
+	}
+
+	if opts.Limit <= 0 {
+		return result, errors.New("paginate limit must be greater than 0")
+	}
+
+	page := *q
+	if opts.IsBypass {
+		page.ByPass = true
+	}
+
+	if opts.Type != paginate.CursorPagination {
+		pageNumber := opts.Page
+		if pageNumber < 1 {
+			pageNumber = 1
+		}
+		page.LimitValue, page.OffsetValue = opts.Limit, (pageNumber-1)*opts.Limit
+
+		rows := make([]T, 0)
+		body, count, err := page.fetchPage(opts)
+		if err != nil {
+			return result, err
+		}
+
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &rows); err != nil {
+				return result, fmt.Errorf("failed to unmarshal response body: %w", err)
+			}
+		}
+
+		if err := q.afterFind(&rows); err != nil {
+			return result, err
+		}
+
+		result.Data, result.Count = rows, count
+		return result, nil
+	}
+
+	keys := opts.SortKeys
+	if len(keys) == 0 {
+		column := opts.CursorRefColumn
+		if column == "" {
+			column = paginate.DefaultOffsetColumn
+		}
+		keys = []paginate.SortKey{paginate.Asc(column)}
+	}
+
+	var cursor []any
+	if token, ok := opts.Cursor.(string); ok && token != "" {
+		values, err := paginate.DecodeCursor(keys, token)
+		if err != nil {
+			return result, err
+		}
+		cursor = values
+	} else if opts.Cursor != nil && !ok {
+		return result, paginate.ErrInvalidCursor
+	}
+
+	backward := opts.CursorDirection == paginate.CursorPaginateDirectionPrev && cursor != nil
+	if cursor != nil {
+		filter, err := paginate.KeysetFilter(keys, cursor, backward)
+		if err != nil {
+			return result, err
+		}
+
+		where := make([]string, 0)
+		if q.WhereAndList != nil {
+			where = append(where, *q.WhereAndList...)
+		}
+		where = append(where, filter)
+		page.WhereAndList = &where
+	}
+	page.OrderList = &[]string{paginate.KeysetOrder(keys, backward)}
+	page.LimitValue, page.OffsetValue = opts.Limit+1, 0
+
+	body, count, err := page.fetchPage(opts)
+	if err != nil {
+		return result, err
+	}
+
+	// number is decoded as json.Number so cursor value doesn't lose precision
+	var items []paginate.Item
+	if len(body) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&items); err != nil {
+			return result, fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+	}
+
+	direction := paginate.CursorPaginateDirectionNext
+	if backward {
+		direction = paginate.CursorPaginateDirectionPrev
+	}
+
+	items, nextCursor, prevCursor, err := paginate.KeysetPage(keys, items, opts.Limit, direction, cursor != nil)
+	if err != nil {
+		return result, err
+	}
+
+	rows := make([]T, 0, len(items))
+	data, err := json.Marshal(items)
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return result, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	if err := q.afterFind(&rows); err != nil {
+		return result, err
+	}
+
+	result.Data, result.Count = rows, count
+	result.NextCursor, result.PrevCursor = nextCursor, prevCursor
+	return result, nil
+}
+
+// fetchPage get row of query and return total row when count is requested
+func (q Query) fetchPage(opts paginate.ExecuteOptions) (json.RawMessage, int, error) {
+	headers := make(map[string]string)
+	headers["Content-Type"] = "application/json"
+
+	if opts.WithCount {
+		countMethod := opts.CountMethod
+		if countMethod == "" {
+			countMethod = paginate.CountEstimated
+		}
+		headers["Prefer"] = fmt.Sprintf("count=%s", countMethod)
+	}
+
+	var count int
+	countInterceptor := func(res *fasthttp.Response) error {
+		if !opts.WithCount {
+			return nil
+		}
+
+		total, err := parseContentRangeCount(string(res.Header.Peek("Content-Range")))
+		if err != nil {
+			return err
+		}
+		count = total
+		return nil
+	}
+
+	var body json.RawMessage
+	if _, err := PostgrestRequest(q.Context, q.credential, fasthttp.MethodGet, q.GetUrl(), nil, headers, q.ByPass, &body, countInterceptor); err != nil {
+		return nil, 0, err
+	}
+	return body, count, nil
+}
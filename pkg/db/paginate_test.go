@@ -0,0 +1,85 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/paginate"
+	"github.com/stretchr/testify/assert"
+)
+
+func articleIds(articles []ArticleMockModel) []int64 {
+	ids := make([]int64, 0, len(articles))
+	for _, a := range articles {
+		ids = append(ids, a.Id)
+	}
+	return ids
+}
+
+func TestPaginate_Offset(t *testing.T) {
+	setupEmulator(t)
+
+	var m ArticleMockModel
+	result, err := From[ArticleMockModel](&mockRaidenContext).
+		OrderAsc(ColOf(&m, &m.Id)).
+		Paginate(paginate.ExecuteOptions{Page: 2, Limit: 2, WithCount: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, articleIds(result.Data))
+	assert.Equal(t, 3, result.Count)
+
+	_, err = Paginate[ArticleMockModel](NewQuery(&mockRaidenContext).Model(ArticleMockModel{}), paginate.ExecuteOptions{})
+	assert.EqualError(t, err, "paginate limit must be greater than 0")
+}
+
+func TestPaginate_Keyset(t *testing.T) {
+	pg := setupEmulator(t)
+	assert.NoError(t, pg.Seed([]ArticleMockModel{
+		{Id: 4, UserId: 2, Title: "Keyset", Rating: 4},
+		{Id: 5, UserId: 2, Title: "Cursor", Rating: 3},
+	}))
+
+	keys := []paginate.SortKey{paginate.Desc("rating"), paginate.Desc("id")}
+	page := func(cursor any, direction paginate.CursorPaginateDirection) paginate.ExecuteResult[ArticleMockModel] {
+		result, err := Paginate[ArticleMockModel](NewQuery(&mockRaidenContext).Model(ArticleMockModel{}), paginate.ExecuteOptions{
+			Type:            paginate.CursorPagination,
+			Limit:           2,
+			Cursor:          cursor,
+			CursorDirection: direction,
+			SortKeys:        keys,
+			WithCount:       true,
+		})
+		assert.NoError(t, err)
+		return result
+	}
+
+	first := page(nil, paginate.CursorPaginateDirectionNext)
+	assert.Equal(t, []int64{1, 4}, articleIds(first.Data))
+	assert.Equal(t, 5, first.Count)
+	assert.Nil(t, first.PrevCursor)
+	assert.NotNil(t, first.NextCursor)
+
+	second := page(first.NextCursor, paginate.CursorPaginateDirectionNext)
+	assert.Equal(t, []int64{3, 5}, articleIds(second.Data))
+	assert.NotNil(t, second.PrevCursor)
+	assert.NotNil(t, second.NextCursor)
+
+	last := page(second.NextCursor, paginate.CursorPaginateDirectionNext)
+	assert.Equal(t, []int64{2}, articleIds(last.Data))
+	assert.Nil(t, last.NextCursor)
+
+	back := page(last.PrevCursor, paginate.CursorPaginateDirectionPrev)
+	assert.Equal(t, []int64{3, 5}, articleIds(back.Data))
+	assert.Equal(t, second.NextCursor, back.NextCursor)
+	assert.Equal(t, second.PrevCursor, back.PrevCursor)
+
+	front := page(back.PrevCursor, paginate.CursorPaginateDirectionPrev)
+	assert.Equal(t, []int64{1, 4}, articleIds(front.Data))
+	assert.Nil(t, front.PrevCursor)
+
+	_, err := Paginate[ArticleMockModel](NewQuery(&mockRaidenContext).Model(ArticleMockModel{}), paginate.ExecuteOptions{
+		Type:     paginate.CursorPagination,
+		Limit:    2,
+		Cursor:   first.NextCursor,
+		SortKeys: []paginate.SortKey{paginate.Asc("id")},
+	})
+	assert.ErrorIs(t, err, paginate.ErrInvalidCursor)
+}
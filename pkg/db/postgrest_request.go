@@ -11,6 +11,20 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// Client send request to postgrest, fasthttp.Client or in-memory emulator like mock.MockPostgrest
+type Client interface {
+	Do(*fasthttp.Request, *fasthttp.Response) error
+}
+
+var DefaultGetClientFn = func() Client {
+	return &fasthttp.Client{}
+}
+
+// GetClientFn return client used by PostgrestRequest, replace it to route request to other client.
+// It is a package level variable, so replacing it is not safe for parallel test,
+// test that replace it must not call t.Parallel and should restore DefaultGetClientFn after finish
+var GetClientFn = DefaultGetClientFn
+
 type ErrorResponse struct {
 	Message string `json:"message"`
 	Code    string `json:"code"`
@@ -18,7 +32,9 @@ type ErrorResponse struct {
 	Details string `json:"details"`
 }
 
-func PostgrestRequest(ctx raiden.Context, credential Credential, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}) (*fasthttp.Response, error) {
+// PostgrestRequest send request to postgrest, response interceptor is called
+// before the response is released, use it for reading response header
+func PostgrestRequest(ctx raiden.Context, credential Credential, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}, resInterceptors ...func(res *fasthttp.Response) error) (*fasthttp.Response, error) {
 	callbackErr := func(errCode int, data []byte) error {
 		var errorResponse ErrorResponse
 		if err := json.Unmarshal(data, &errorResponse); err == nil && errorResponse.Message != "" && errorResponse.Code != "" {
@@ -28,18 +44,18 @@ func PostgrestRequest(ctx raiden.Context, credential Credential, method string,
 	}
 
 	if ctx != nil {
-		return PostgrestRequestBind(ctx, method, url, payload, headers, bypass, result, callbackErr)
+		return PostgrestRequestBind(ctx, method, url, payload, headers, bypass, result, callbackErr, resInterceptors...)
 	}
-	return PostgrestRequestBindCredential(credential, method, url, payload, headers, bypass, result, callbackErr)
+	return PostgrestRequestBindCredential(credential, method, url, payload, headers, bypass, result, callbackErr, resInterceptors...)
 }
 
-func PostgrestRequestBind(ctx raiden.Context, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}, callbackErr func(code int, data []byte) error) (*fasthttp.Response, error) {
+func PostgrestRequestBind(ctx raiden.Context, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}, callbackErr func(code int, data []byte) error, resInterceptors ...func(res *fasthttp.Response) error) (*fasthttp.Response, error) {
 
 	if !isAllowedMethod(method) {
 		return nil, fmt.Errorf("method %s is not allowed", method)
 	}
 
-	client := &fasthttp.Client{}
+	client := GetClientFn()
 
 	req := fasthttp.AcquireRequest()
 	defer fasthttp.ReleaseRequest(req)
@@ -130,6 +146,12 @@ func PostgrestRequestBind(ctx raiden.Context, method string, url string, payload
 		}
 	}
 
+	for _, interceptor := range resInterceptors {
+		if err := interceptor(res); err != nil {
+			return res, err
+		}
+	}
+
 	for _, key := range res.Header.PeekKeys() {
 		headerKey := string(key)
 		ctx.RequestContext().Response.Header.Set(
@@ -141,12 +163,12 @@ func PostgrestRequestBind(ctx raiden.Context, method string, url string, payload
 	return res, nil
 }
 
-func PostgrestRequestBindCredential(credential Credential, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}, callbackErr func(code int, data []byte) error) (*fasthttp.Response, error) {
+func PostgrestRequestBindCredential(credential Credential, method string, url string, payload []byte, headers map[string]string, bypass bool, result interface{}, callbackErr func(code int, data []byte) error, resInterceptors ...func(res *fasthttp.Response) error) (*fasthttp.Response, error) {
 	if !isAllowedMethod(method) {
 		return nil, fmt.Errorf("method %s is not allowed", method)
 	}
 
-	client := &fasthttp.Client{}
+	client := GetClientFn()
 
 	req := fasthttp.AcquireRequest()
 	defer fasthttp.ReleaseRequest(req)
@@ -241,6 +263,12 @@ func PostgrestRequestBindCredential(credential Credential, method string, url st
 		}
 	}
 
+	for _, interceptor := range resInterceptors {
+		if err := interceptor(res); err != nil {
+			return res, err
+		}
+	}
+
 	return res, nil
 }
 
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreloadOptions configure embedded resource loaded with PreloadWith
+type PreloadOptions struct {
+	// Columns selected inside the embed, default is all column
+	Columns []string
+
+	// Filters applied to the embedded resource, without Inner
+	// parent row is still returned with empty embed when no child match
+	Filters []Filter
+
+	Order  []PreloadOrder
+	Limit  int
+	Offset int
+
+	// Inner use `!inner` join, so parent row is filtered by the embedded resource
+	Inner bool
+
+	// Hint is foreign key column or constraint name used to disambiguate relation,
+	// default is foreignKey in join tag
+	Hint string
+}
+
+type PreloadOrder struct {
+	Column string
+	Desc   bool
+}
+
+type preloadNode struct {
+	relation relationInfo
+	columns  []string
+	hint     string
+	inner    bool
+	children []*preloadNode
+}
+
+// PreloadWith load embedded resource by relation path like `User.Articles`,
+// options is applied to the last relation in the path.
+//
+// example :
+//
+//	// select=*,user:users!user_id!inner(id,username)&user.team_id=eq.1
+//	q.PreloadWith("User", db.PreloadOptions{
+//		Columns: []string{"id", "username"},
+//		Filters: []db.Filter{db.Eq("team_id", 1)},
+//		Inner:   true,
+//	})
+func (q *Query) PreloadWith(relation string, opts PreloadOptions) *Query {
+	relations, err := resolveRelations(q.model, relation)
+	if err != nil {
+		q.Errors = append(q.Errors, err)
+		return q
+	}
+
+	last := relations[len(relations)-1]
+	for _, column := range opts.Columns {
+		if !isColumnExist(last.model, getBaseColumn(column)) {
+			q.Errors = append(q.Errors, fmt.Errorf("invalid column: \"%s\" is not available on \"%s\" table", column, last.table))
+			return q
+		}
+	}
+
+	for _, o := range opts.Order {
+		if !isColumnExist(last.model, getBaseColumn(o.Column)) {
+			q.Errors = append(q.Errors, fmt.Errorf("invalid order column: \"%s\" is not available on \"%s\" table", o.Column, last.table))
+			return q
+		}
+	}
+
+	if q.preloads == nil {
+		q.preloads = &[]*preloadNode{}
+	}
+
+	var node *preloadNode
+	nodes := q.preloads
+	embedPath := make([]string, 0, len(relations))
+	for _, r := range relations {
+		node = findOrAddPreloadNode(nodes, r)
+		nodes = &node.children
+		embedPath = append(embedPath, r.alias)
+	}
+
+	if len(opts.Columns) > 0 {
+		node.columns = opts.Columns
+	}
+
+	if opts.Hint != "" {
+		node.hint = opts.Hint
+	}
+
+	if opts.Inner {
+		node.inner = true
+	}
+
+	prefix := strings.Join(embedPath, ".")
+	q.addFilters(prefix, opts.Filters)
+
+	var params []string
+	if len(opts.Order) > 0 {
+		orders := make([]string, 0, len(opts.Order))
+		for _, o := range opts.Order {
+			direction := "asc"
+			if o.Desc {
+				direction = "desc"
+			}
+			orders = append(orders, fmt.Sprintf("%s.%s", o.Column, direction))
+		}
+		params = append(params, fmt.Sprintf("%s.order=%s", prefix, strings.Join(orders, ",")))
+	}
+
+	if opts.Limit > 0 {
+		params = append(params, fmt.Sprintf("%s.limit=%d", prefix, opts.Limit))
+	}
+
+	if opts.Offset > 0 {
+		params = append(params, fmt.Sprintf("%s.offset=%d", prefix, opts.Offset))
+	}
+
+	if len(params) > 0 {
+		if q.WhereAndList == nil {
+			q.WhereAndList = &[]string{}
+		}
+		*q.WhereAndList = append(*q.WhereAndList, params...)
+	}
+
+	return q
+}
+
+func findOrAddPreloadNode(nodes *[]*preloadNode, relation relationInfo) *preloadNode {
+	for _, n := range *nodes {
+		if n.relation.field == relation.field {
+			return n
+		}
+	}
+
+	n := &preloadNode{relation: relation, hint: relation.foreignKey}
+	*nodes = append(*nodes, n)
+	return n
+}
+
+// String render embed as select item, for example `user:users!user_id!inner(id,team:teams!team_id(*))`
+func (n *preloadNode) String() string {
+	related := n.relation.table
+	if n.relation.alias != n.relation.table {
+		related = fmt.Sprintf("%s:%s", n.relation.alias, n.relation.table)
+	}
+
+	if n.hint != "" {
+		related = fmt.Sprintf("%s!%s", related, n.hint)
+	}
+
+	if n.inner {
+		related += "!inner"
+	}
+
+	columns := n.columns
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+
+	items := append([]string{}, columns...)
+	for _, child := range n.children {
+		items = append(items, child.String())
+	}
+
+	return fmt.Sprintf("%s(%s)", related, strings.Join(items, ","))
+}
+
+func buildPreloadSelect(nodes []*preloadNode) []string {
+	selects := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		selects = append(selects, n.String())
+	}
+	return selects
+}
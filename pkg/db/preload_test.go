@@ -0,0 +1,91 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreloadWith(t *testing.T) {
+	articleMockModel := ArticleMockModel{}
+	orderMockModel := OrdersMockModel{}
+	userMockModel := UsersMockModel{}
+
+	t.Run("columns, filters, order and limit", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(userMockModel).
+			PreloadWith("Articles", PreloadOptions{
+				Columns: []string{"id", "title"},
+				Filters: []Filter{Gte("rating", 4), Eq("is_featured", true)},
+				Order:   []PreloadOrder{{Column: "rating", Desc: true}, {Column: "id"}},
+				Limit:   5,
+				Offset:  10,
+			})
+
+		assert.Empty(t, q.Errors)
+		assert.Equal(t, "users?select=*,articles!article_id(id,title)&articles.rating=gte.4&articles.is_featured=eq.true&articles.order=rating.desc,id.asc&articles.limit=5&articles.offset=10", buildQueryURI(*q))
+	})
+
+	t.Run("inner join filter parent by alias", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(articleMockModel).
+			PreloadWith("User", PreloadOptions{
+				Filters: []Filter{Or(Eq("team_id", 1), Eq("team_id", 2))},
+				Inner:   true,
+			})
+
+		assert.Equal(t, "articles?select=*,user:users!user_id!inner(*)&user.or=(team_id.eq.1,team_id.eq.2)", buildQueryURI(*q))
+	})
+
+	t.Run("disambiguation hint", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(orderMockModel).
+			PreloadWith("UserBilling", PreloadOptions{Hint: "orders_billing_id_fkey", Columns: []string{"username"}}).
+			PreloadWith("UserAddress", PreloadOptions{})
+
+		assert.Equal(t, "orders?select=*,user_billing:users!orders_billing_id_fkey(username),user_address:users!address_id(*)", buildQueryURI(*q))
+	})
+
+	t.Run("nested relation share parent embed", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(articleMockModel).
+			Select([]string{"id"}).
+			PreloadWith("User", PreloadOptions{Columns: []string{"id", "username"}}).
+			PreloadWith("User.Team", PreloadOptions{Filters: []Filter{Eq("name", "Engineering")}, Inner: true}).
+			PreloadWith("User.Team.Organization", PreloadOptions{Limit: 1})
+
+		assert.Empty(t, q.Errors)
+		assert.Equal(t, "articles?select=id,user:users!user_id(id,username,team:teams!team_id!inner(*,organization:organizations!organization_id(*)))&user.team.name=eq.Engineering&user.team.organization.limit=1", buildQueryURI(*q))
+	})
+
+	t.Run("invalid relation", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(articleMockModel).
+			PreloadWith("User.Unknown", PreloadOptions{})
+
+		assert.EqualError(t, q.Get(&[]ArticleMockModel{}), "invalid relation \"User.Unknown\": could not find related model: field Unknown not found")
+		assert.Equal(t, "articles?select=*", buildQueryURI(*q))
+	})
+
+	t.Run("invalid column", func(t *testing.T) {
+		q := NewQuery(&mockRaidenContext).
+			Model(articleMockModel).
+			PreloadWith("User", PreloadOptions{Columns: []string{"unknown"}})
+
+		assert.EqualError(t, q.Single(&ArticleMockModel{}), "invalid column: \"unknown\" is not available on \"users\" table")
+
+		q = NewQuery(&mockRaidenContext).
+			Model(articleMockModel).
+			PreloadWith("User", PreloadOptions{Order: []PreloadOrder{{Column: "unknown"}}})
+
+		assert.Len(t, q.Errors, 1)
+		assert.EqualError(t, q.Errors[0], "invalid order column: \"unknown\" is not available on \"users\" table")
+	})
+
+	t.Run("typed query", func(t *testing.T) {
+		q := From[ArticleMockModel](&mockRaidenContext).
+			Preload("User", PreloadOptions{Inner: true})
+
+		assert.Equal(t, "articles?select=*,user:users!user_id!inner(*)", q.GetQueryURI())
+	})
+}
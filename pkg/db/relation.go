@@ -4,14 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-
-	"github.com/sev-2/raiden"
 )
 
 func (q *Query) Preload(table string, args ...string) *Query {
 
 	relatedFieldPrefix := ""
-	relationMap := make(map[string]map[string]string)
 
 	field := ""
 	operator := ""
@@ -29,67 +26,10 @@ func (q *Query) Preload(table string, args ...string) *Query {
 		value = args[2]
 	}
 
-	relations := strings.Split(table, ".")
-
-	if len(relations) > 3 {
-		raiden.Panic("unsupported nested relations more than 3 levels")
-	}
-
-	var relatedModel interface{}
-	for i, relation := range relations {
-		var currentModelStruct reflect.Type
-		var err error
-		if i == 0 {
-			currentModelStruct = reflect.TypeOf(q.model)
-			relatedModel, err = instantiateFieldByPath(q.model, relation)
-		} else {
-			currentModelStruct = reflect.TypeOf(relatedModel)
-			relatedModel, err = instantiateFieldByPath(relatedModel, relation)
-		}
-
-		if err != nil {
-			raiden.Panic("could not find related model.")
-		}
-
-		relatedModelStruct := reflect.TypeOf(relatedModel)
-		if relatedModelStruct.Kind() == reflect.Ptr {
-			relatedModelStruct = relatedModelStruct.Elem()
-		}
-
-		var relatedAlias string
-		var relatedTableName string
-		var relatedForeignKey string
-		for i := 0; i < relatedModelStruct.NumField(); i++ {
-			field := relatedModelStruct.Field(i)
-			if field.Name == "Metadata" {
-				relatedTableName = field.Tag.Get("tableName")
-			}
-		}
-
-		if currentModelStruct.Kind() == reflect.Ptr {
-			currentModelStruct = currentModelStruct.Elem()
-		}
-
-		for i := 0; i < currentModelStruct.NumField(); i++ {
-			field := currentModelStruct.Field(i)
-			if field.Name == relation {
-				jsonField := field.Tag.Get("json")
-				join := field.Tag.Get("join")
-
-				relatedAlias = strings.Split(jsonField, ",")[0]
-				relatedForeignKey, err = getTagValue(join, "foreignKey")
-
-				if err != nil {
-					raiden.Panic("could not find foreign key in join tag.")
-				}
-			}
-		}
-
-		relationData := make(map[string]string)
-		relationData["alias"] = relatedAlias
-		relationData["table"] = relatedTableName
-		relationData["fk"] = relatedForeignKey
-		relationMap[relation] = relationData
+	relations, err := resolveRelations(q.model, table)
+	if err != nil {
+		q.Errors = append(q.Errors, err)
+		return q
 	}
 
 	var selects []string
@@ -98,16 +38,11 @@ func (q *Query) Preload(table string, args ...string) *Query {
 	// If the table is `Users.Team.Organization`,
 	// the select query will be `users(teams(organizations(*)))`
 	for _, r := range relations {
-		d := relationMap[r]
-		alias := d["alias"]
-		table := d["table"]
-		fk := d["fk"]
-
 		var related string
-		if alias == table {
-			related = fmt.Sprintf("%s!%s", table, fk)
+		if r.alias == r.table {
+			related = fmt.Sprintf("%s!%s", r.table, r.foreignKey)
 		} else {
-			related = fmt.Sprintf("%s:%s!%s", alias, table, fk)
+			related = fmt.Sprintf("%s:%s!%s", r.alias, r.table, r.foreignKey)
 		}
 
 		if len(selects) > 0 {
@@ -120,9 +55,9 @@ func (q *Query) Preload(table string, args ...string) *Query {
 		}
 
 		if relatedFieldPrefix == "" {
-			relatedFieldPrefix = table
+			relatedFieldPrefix = r.table
 		} else {
-			relatedFieldPrefix = fmt.Sprintf("%s.%s", relatedFieldPrefix, table)
+			relatedFieldPrefix = fmt.Sprintf("%s.%s", relatedFieldPrefix, r.table)
 		}
 	}
 
@@ -142,6 +77,50 @@ func (q *Query) Preload(table string, args ...string) *Query {
 	return q
 }
 
+type relationInfo struct {
+	field      string
+	alias      string
+	table      string
+	foreignKey string
+	model      interface{}
+}
+
+// resolveRelations resolve relation path like `Team.Organization`
+// into list of relation from the first to the last level
+func resolveRelations(model interface{}, path string) ([]relationInfo, error) {
+	var relations []relationInfo
+
+	currentModel := model
+	for _, relation := range strings.Split(path, ".") {
+		relatedModel, err := instantiateFieldByPath(currentModel, relation)
+		if err != nil {
+			return nil, fmt.Errorf("invalid relation \"%s\": could not find related model: %w", path, err)
+		}
+
+		currentModelStruct := reflect.TypeOf(currentModel)
+		if currentModelStruct.Kind() == reflect.Ptr {
+			currentModelStruct = currentModelStruct.Elem()
+		}
+
+		field, _ := currentModelStruct.FieldByName(relation)
+		foreignKey, err := getTagValue(field.Tag.Get("join"), "foreignKey")
+		if err != nil {
+			return nil, fmt.Errorf("invalid relation \"%s\": could not find foreign key in join tag of %s", path, relation)
+		}
+
+		relations = append(relations, relationInfo{
+			field:      relation,
+			alias:      strings.Split(field.Tag.Get("json"), ",")[0],
+			table:      GetTable(relatedModel),
+			foreignKey: foreignKey,
+			model:      relatedModel,
+		})
+		currentModel = relatedModel
+	}
+
+	return relations, nil
+}
+
 func instantiateFieldByPath(model interface{}, fieldPath string) (interface{}, error) {
 	fields := strings.Split(fieldPath, ".")
 	val := reflect.ValueOf(model)
@@ -151,27 +151,36 @@ func TestWith(t *testing.T) {
 
 	t.Run("invalid relation", func(t *testing.T) {
 		t.Run("invalid relation name", func(t *testing.T) {
-			assert.Panics(t, func() {
-				NewQuery(&mockRaidenContext).
-					Model(articleMockModel).
-					Preload("InvalidRelation")
-			}, "Expected panic for invalid relation name")
+			q := NewQuery(&mockRaidenContext).
+				Model(articleMockModel).
+				Preload("InvalidRelation")
+
+			assert.True(t, q.HasError(), "Expected error for invalid relation name")
+			assert.EqualError(t, q.Get(&[]ArticleMockModel{}), "invalid relation \"InvalidRelation\": could not find related model: field InvalidRelation not found")
 		})
 
 		t.Run("invalid relation when data type is not a struct", func(t *testing.T) {
-			assert.Panics(t, func() {
-				NewQuery(&mockRaidenContext).
-					Model(articleMockModel).
-					Preload("Title")
-			}, "Expected panic for invalid relation with non-struct data type")
+			q := NewQuery(&mockRaidenContext).
+				Model(articleMockModel).
+				Preload("Title")
+
+			assert.True(t, q.HasError(), "Expected error for invalid relation with non-struct data type")
 		})
 
 		t.Run("invalid relation when data type is not a slice of struct", func(t *testing.T) {
-			assert.Panics(t, func() {
-				NewQuery(&mockRaidenContext).
-					Model(articleMockModel).
-					Preload("Tags")
-			}, "Expected panic for invalid relation with non-slice of struct data type")
+			q := NewQuery(&mockRaidenContext).
+				Model(articleMockModel).
+				Preload("Tags")
+
+			assert.True(t, q.HasError(), "Expected error for invalid relation with non-slice of struct data type")
+		})
+
+		t.Run("relation without foreign key", func(t *testing.T) {
+			q := NewQuery(&mockRaidenContext).
+				Model(articleMockModel).
+				Preload("ModelBase")
+
+			assert.True(t, q.HasError(), "Expected error for relation without join tag")
 		})
 	})
 }
@@ -29,6 +29,9 @@ func (q Query) Select(columns []string) (model *Query) {
 			column = c
 		}
 
+		// json arrow path is validated by the base column
+		column = getBaseColumn(column)
+
 		if !isColumnExist(q.model, column) {
 			err := fmt.Errorf("invalid column: \"%s\" is not available on \"%s\" table", column, table)
 			q.Errors = append(q.Errors, err)
@@ -83,6 +86,15 @@ func isColumnExist(model interface{}, column string) bool {
 	return validSet[column]
 }
 
+// getBaseColumn return column name of json arrow path,
+// for example `data->address->>city` return `data`
+func getBaseColumn(column string) string {
+	if idx := strings.Index(column, "->"); idx > 0 {
+		return column[:idx]
+	}
+	return column
+}
+
 func isValidColumnName(column string) bool {
 	isAllowed, _ := regexp.MatchString(`^[a-zA-Z_][a-zA-Z0-9_]{1,59}`, column)
 
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// SoftDeleteOption mark column as soft delete timestamp,
+	// for example `column:"name:deleted_at;type:timestamptz;nullable;softDelete"`
+	SoftDeleteOption = "softDelete"
+
+	// VersionOption mark column as optimistic locking version,
+	// for example `column:"name:version;type:bigint;version"`
+	VersionOption = "version"
+)
+
+// WithTrashed include soft deleted row in query result
+func (q *Query) WithTrashed() *Query {
+	q.withTrashed = true
+	return q
+}
+
+// ForceDelete permanently delete row of soft delete model,
+// the query is not changed so next Delete is still soft delete
+func (q *Query) ForceDelete() error {
+	forced := *q
+	forced.forceDelete = true
+	return forced.Delete()
+}
+
+// softDeleteColumn return soft delete column of model that used as query scope
+func (q Query) softDeleteColumn() (string, bool) {
+	if q.withTrashed || q.model == nil {
+		return "", false
+	}
+	return getColumnByOption(q.model, SoftDeleteOption)
+}
+
+// softDelete mark row that match the filter as deleted instead of removing it
+func (q *Query) softDelete(column string, result interface{}, opts MutationOptions) (MutationResult, error) {
+	jsonData, err := json.Marshal(map[string]any{column: time.Now().UTC()})
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	return q.mutate(fasthttp.MethodPatch, q.mutationUrl(opts), jsonData, result, opts)
+}
+
+// unscoped return copy of query without soft delete and tenant scope, used by insert
+// because filter is not applicable for new row
+func (q *Query) unscoped() *Query {
+	copied := *q
+	copied.withTrashed = true
+	copied.withoutTenant = true
+	return &copied
+}
+
+// getColumnByOption return name of column that have option in column tag
+func getColumnByOption(model interface{}, option string) (string, bool) {
+	field, found := getFieldByOption(model, option)
+	if !found {
+		return "", false
+	}
+	return getColumnName(field), true
+}
+
+func getFieldByOption(model interface{}, option string) (reflect.StructField, bool) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, item := range strings.Split(field.Tag.Get("column"), ";") {
+			if item == option {
+				return field, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// getColumnName return column name of field from `column` tag and fallback to `json` tag
+func getColumnName(field reflect.StructField) string {
+	for _, item := range strings.Split(field.Tag.Get("column"), ";") {
+		if strings.HasPrefix(item, "name:") {
+			return strings.TrimPrefix(item, "name:")
+		}
+	}
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
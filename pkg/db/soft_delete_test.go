@@ -0,0 +1,130 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type SoftDeleteMockModel struct {
+	ModelBase
+
+	Id        int64      `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	Title     string     `json:"title,omitempty" column:"name:title;type:text"`
+	Version   int64      `json:"version" column:"name:version;type:bigint;version"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" column:"name:deleted_at;type:timestamptz;nullable;softDelete"`
+
+	Metadata string `json:"-" schema:"public" tableName:"posts"`
+}
+
+func TestGetColumnByOption(t *testing.T) {
+	column, found := getColumnByOption(&SoftDeleteMockModel{}, SoftDeleteOption)
+	assert.True(t, found)
+	assert.Equal(t, "deleted_at", column)
+
+	column, found = getColumnByOption(SoftDeleteMockModel{}, VersionOption)
+	assert.True(t, found)
+	assert.Equal(t, "version", column)
+
+	_, found = getColumnByOption(articleMockModel, SoftDeleteOption)
+	assert.False(t, found)
+
+	_, found = getColumnByOption(nil, SoftDeleteOption)
+	assert.False(t, found)
+}
+
+func TestSoftDeleteScope(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(SoftDeleteMockModel{}).Eq("id", 1).OrderAsc("id")
+	assert.Equal(t, "posts?select=*&id=eq.1&deleted_at=is.null&order=id.asc", q.GetQueryURI())
+
+	q = NewQuery(&mockRaidenContext).Model(SoftDeleteMockModel{}).Eq("id", 1).WithTrashed()
+	assert.Equal(t, "posts?select=*&id=eq.1", q.GetQueryURI())
+
+	q = NewQuery(&mockRaidenContext).Model(SoftDeleteMockModel{})
+	assert.Equal(t, "/rest/v1/posts?select=*", q.unscoped().mutationUrl(MutationOptions{}))
+	assert.Equal(t, "posts?select=*&deleted_at=is.null", q.GetQueryURI())
+
+	typed := From[SoftDeleteMockModel](&mockRaidenContext)
+	assert.Equal(t, "posts?select=*&deleted_at=is.null", typed.GetQueryURI())
+	assert.Equal(t, "posts?select=*", typed.WithTrashed().GetQueryURI())
+}
+
+func TestForceDelete(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed(SoftDeleteMockModel{Id: 1, Title: "foo"}))
+
+	GetClientFn = func() Client { return pg }
+	defer func() { GetClientFn = DefaultGetClientFn }()
+
+	q := NewQuery(&mockRaidenContext).Model(SoftDeleteMockModel{}).Eq("title", "foo")
+	assert.NoError(t, q.ForceDelete())
+	assert.Empty(t, pg.Rows("posts"))
+
+	// force delete doesn't leak to next delete of the same query
+	assert.NoError(t, pg.Seed(SoftDeleteMockModel{Id: 2, Title: "foo"}))
+	assert.NoError(t, q.Delete())
+
+	rows := pg.Rows("posts")
+	assert.Len(t, rows, 1)
+	assert.NotNil(t, rows[0]["deleted_at"])
+}
+
+func TestVersionLock(t *testing.T) {
+	q := NewQuery(&mockRaidenContext).Model(SoftDeleteMockModel{}).Eq("id", 1)
+
+	payload := &SoftDeleteMockModel{Id: 1, Title: "foo", Version: 3}
+	lock, err := q.getVersionLock(payload)
+	assert.NoError(t, err)
+	assert.NotNil(t, lock)
+
+	locked, jsonData, err := lock.apply(q, []byte(`{"id":1,"title":"foo","version":3}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"title":"foo","version":4}`, string(jsonData))
+	assert.Equal(t, "posts?select=*&id=eq.1&version=eq.3&deleted_at=is.null", locked.GetQueryURI())
+	assert.Equal(t, "posts?select=*&id=eq.1&deleted_at=is.null", q.GetQueryURI())
+
+	lock.commit()
+	assert.Equal(t, int64(4), payload.Version)
+
+	t.Run("payload without version", func(t *testing.T) {
+		lock, err := q.getVersionLock(struct {
+			Title string `json:"title"`
+		}{Title: "bar"})
+		assert.NoError(t, err)
+		assert.Nil(t, lock)
+	})
+
+	t.Run("model without version", func(t *testing.T) {
+		lock, err := NewQuery(&mockRaidenContext).Model(articleMockModel).getVersionLock(payload)
+		assert.NoError(t, err)
+		assert.Nil(t, lock)
+	})
+
+	t.Run("invalid version type", func(t *testing.T) {
+		_, err := q.getVersionLock(struct {
+			Version string `json:"version"`
+		}{Version: "1"})
+		assert.EqualError(t, err, "invalid version: column \"version\" must be integer")
+
+		_, err = q.getVersionLock(struct {
+			Version *int `json:"version"`
+		}{})
+		assert.EqualError(t, err, "invalid version: column \"version\" of payload is nil")
+	})
+
+	t.Run("payload is not struct", func(t *testing.T) {
+		lock, err := q.getVersionLock(map[string]any{"title": "bar"})
+		assert.Nil(t, lock)
+		assert.EqualError(t, err, "invalid version: payload of versioned model must be struct, got map[string]interface {}")
+
+		_, err = q.getVersionLock((*SoftDeleteMockModel)(nil))
+		assert.EqualError(t, err, "invalid version: payload of versioned model must be struct, got *db.SoftDeleteMockModel")
+	})
+}
+
+func TestErrVersionConflict(t *testing.T) {
+	assert.Equal(t, 409, ErrVersionConflict.StatusCode)
+	assert.Contains(t, ErrVersionConflict.Error(), "version conflict")
+}
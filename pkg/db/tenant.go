@@ -0,0 +1,80 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sev-2/raiden"
+)
+
+// ForTenant scope query of tenant scoped model to tenant instead of tenant resolved from request
+func (q *Query) ForTenant(tenant string) *Query {
+	q.tenant = tenant
+	return q
+}
+
+// WithoutTenant disable tenant scope, use it for cross tenant query like admin report
+func (q *Query) WithoutTenant() *Query {
+	q.withoutTenant = true
+	return q
+}
+
+// tenantScope return tenant column and tenant of tenant scoped model,
+// system query without resolvable tenant is not scoped
+func (q Query) tenantScope() (column string, tenant string, err error) {
+	if q.withoutTenant || q.model == nil {
+		return "", "", nil
+	}
+
+	column, found := raiden.GetTenantColumn(q.model)
+	if !found {
+		return "", "", nil
+	}
+
+	if q.tenant != "" {
+		return column, q.tenant, nil
+	}
+
+	tenant, err = raiden.ResolveTenant(q.Context)
+	if err != nil {
+		if q.ByPass && err == raiden.ErrTenantNotResolved {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	return column, tenant, nil
+}
+
+func (q Query) tenantFilter() string {
+	column, tenant, err := q.tenantScope()
+	if err != nil || column == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s=eq.%s", column, formatColumnValue(tenant))
+}
+
+// stampTenant set tenant column of json object or every object in json array
+func (q Query) stampTenant(jsonData []byte) ([]byte, error) {
+	column, tenant, err := q.tenantScope()
+	if err != nil || column == "" {
+		return jsonData, err
+	}
+
+	var payload any
+	if err := json.Unmarshal(jsonData, &payload); err != nil {
+		return nil, err
+	}
+
+	switch p := payload.(type) {
+	case map[string]any:
+		p[column] = tenant
+	case []any:
+		for _, item := range p {
+			if m, ok := item.(map[string]any); ok {
+				m[column] = tenant
+			}
+		}
+	}
+
+	return json.Marshal(payload)
+}
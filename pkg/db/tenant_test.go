@@ -0,0 +1,156 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type TenantMockModel struct {
+	ModelBase
+
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	OrgId string `json:"org_id,omitempty" column:"name:org_id;type:text;nullable:false;tenant"`
+	Name  string `json:"name,omitempty" column:"name:name;type:text;nullable:false"`
+
+	Metadata string `json:"-" schema:"public" tableName:"projects" rlsEnable:"true" rlsForced:"false"`
+}
+
+const tenantJwtSecret = "tenant-secret"
+
+func newTenantContext(t *testing.T, claims map[string]any) *raiden.Ctx {
+	ctx := raiden.NewCtx(&raiden.Config{JwtSecret: tenantJwtSecret}, nil, nil)
+	ctx.RequestCtx = &fasthttp.RequestCtx{}
+	if claims == nil {
+		return &ctx
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims)).SignedString([]byte(tenantJwtSecret))
+	assert.NoError(t, err)
+	ctx.Request.Header.Set("Authorization", "Bearer "+token)
+	return &ctx
+}
+
+func TestTenant_QueryURI(t *testing.T) {
+	ctx := newTenantContext(t, map[string]any{"org_id": "acme"})
+
+	uri := NewQuery(ctx).Model(TenantMockModel{}).Eq("name", "raiden").GetQueryURI()
+	assert.Equal(t, "projects?select=*&name=eq.raiden&org_id=eq.acme", uri)
+
+	uri = NewQuery(ctx).Model(TenantMockModel{}).ForTenant("globex").GetQueryURI()
+	assert.Equal(t, "projects?select=*&org_id=eq.globex", uri)
+
+	uri = NewQuery(ctx).Model(TenantMockModel{}).WithoutTenant().GetQueryURI()
+	assert.Equal(t, "projects?select=*", uri)
+
+	uri = NewQuery(ctx).Model(ArticleMockModel{}).GetQueryURI()
+	assert.Equal(t, "articles?select=*", uri)
+
+	restore := raiden.SetTenantResolver(raiden.TenantFromHeader("X-Org-Id"))
+	defer restore()
+
+	ctx.Request.Header.Set("X-Org-Id", "initech")
+	uri = NewQuery(ctx).Model(TenantMockModel{}).GetQueryURI()
+	assert.Equal(t, "projects?select=*&org_id=eq.initech", uri)
+}
+
+func TestTenant_NotResolved(t *testing.T) {
+	ctx := newTenantContext(t, nil)
+
+	var projects []TenantMockModel
+	err := NewQuery(ctx).Model(TenantMockModel{}).Get(&projects)
+	assert.Equal(t, raiden.ErrTenantNotResolved, err)
+
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).InsertWith(TenantMockModel{Name: "raiden"}, nil, MutationOptions{})
+	assert.Equal(t, raiden.ErrTenantNotResolved, err)
+
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).Count()
+	assert.Equal(t, raiden.ErrTenantNotResolved, err)
+
+	// system query without tenant is not scoped
+	uri := NewQuery(ctx).Model(TenantMockModel{}).AsSystem().GetQueryURI()
+	assert.Equal(t, "projects?select=*", uri)
+}
+
+func TestTenant_Emulator(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed([]TenantMockModel{
+		{OrgId: "acme", Name: "rocket"},
+		{OrgId: "globex", Name: "dome"},
+		{OrgId: "acme", Name: "anvil"},
+	}))
+
+	GetClientFn = func() Client { return pg }
+	defer func() { GetClientFn = DefaultGetClientFn }()
+
+	ctx := newTenantContext(t, map[string]any{"org_id": "acme"})
+
+	var projects []TenantMockModel
+	err := NewQuery(ctx).Model(TenantMockModel{}).OrderAsc("name").Get(&projects)
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+	assert.Equal(t, "anvil", projects[0].Name)
+	assert.Equal(t, "rocket", projects[1].Name)
+
+	var inserted []TenantMockModel
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).InsertWith(
+		[]TenantMockModel{{Name: "magnet"}, {OrgId: "globex", Name: "spring"}},
+		&inserted,
+		MutationOptions{},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", inserted[0].OrgId)
+	assert.Equal(t, "acme", inserted[1].OrgId)
+
+	result, err := NewQuery(ctx).Model(TenantMockModel{}).DeleteWith(nil, MutationOptions{Count: "exact"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Count)
+	assert.Len(t, pg.Rows("projects"), 1)
+}
+
+func TestTenant_MutationScope(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed([]TenantMockModel{
+		{OrgId: "acme", Name: "rocket"},
+		{OrgId: "globex", Name: "dome"},
+	}))
+
+	GetClientFn = func() Client { return pg }
+	defer func() { GetClientFn = DefaultGetClientFn }()
+
+	ctx := newTenantContext(t, map[string]any{"org_id": "acme"})
+
+	// update can't move row to other tenant
+	var updated []TenantMockModel
+	_, err := NewQuery(ctx).Model(TenantMockModel{}).Eq("name", "rocket").
+		UpdateWith(map[string]any{"name": "rocket-2", "org_id": "globex"}, &updated, MutationOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, "acme", updated[0].OrgId)
+
+	// merge upsert without tenant column in conflict target can overwrite row of other tenant
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).UpsertWith(
+		[]map[string]any{{"id": 2, "name": "stolen"}}, nil, UpsertOptions{OnConflict: "id"},
+	)
+	assert.Equal(t, raiden.ErrTenantConflictTarget, err)
+
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).UpsertWith(
+		[]map[string]any{{"id": 2, "name": "stolen"}}, nil, UpsertOptions{Resolution: MergeDuplicates},
+	)
+	assert.Equal(t, raiden.ErrTenantConflictTarget, err)
+
+	_, err = NewQuery(ctx).Model(TenantMockModel{}).UpsertWith(
+		[]map[string]any{{"id": 2, "name": "ignored"}}, nil, UpsertOptions{OnConflict: "id", Resolution: IgnoreDuplicates},
+	)
+	assert.NoError(t, err)
+
+	var rows []TenantMockModel
+	err = NewQuery(ctx).Model(TenantMockModel{}).WithoutTenant().OrderAsc("id").Get(&rows)
+	assert.NoError(t, err)
+	assert.Equal(t, "dome", rows[1].Name)
+	assert.Equal(t, "globex", rows[1].OrgId)
+}
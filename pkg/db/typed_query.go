@@ -0,0 +1,233 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/paginate"
+)
+
+// TypedQuery is generic version of Query, filter is built from typed column
+// and result is returned as model T instead of bind to interface{}
+type TypedQuery[T any] struct {
+	query *Query
+}
+
+// From create typed query for model T
+//
+// example :
+//
+//	var m models.Article
+//	rating := db.ColOf(&m, &m.Rating)
+//
+//	articles, err := db.From[models.Article](ctx).
+//		Where(rating.Gte(4)).
+//		OrderDesc(rating).
+//		Limit(10).
+//		All()
+func From[T any](ctx raiden.Context) *TypedQuery[T] {
+	return &TypedQuery[T]{query: NewQuery(ctx).From(new(T))}
+}
+
+// Query return underlying query, use it for feature that not available in typed query
+func (q *TypedQuery[T]) Query() *Query {
+	return q.query
+}
+
+func (q *TypedQuery[T]) SetCredential(credential Credential) *TypedQuery[T] {
+	q.query.SetCredential(credential)
+	return q
+}
+
+func (q *TypedQuery[T]) AsSystem() *TypedQuery[T] {
+	q.query.AsSystem()
+	return q
+}
+
+func (q *TypedQuery[T]) Select(columns ...ColumnOf[T]) *TypedQuery[T] {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.ColumnName())
+	}
+	q.query = q.query.Select(names)
+	return q
+}
+
+// Where add conditions that must be matched by all row
+func (q *TypedQuery[T]) Where(conditions ...Condition[T]) *TypedQuery[T] {
+	for _, c := range conditions {
+		if c.operator == "is" {
+			if q.query.IsList == nil {
+				q.query.IsList = &[]string{}
+			}
+			*q.query.IsList = append(*q.query.IsList, c.String())
+			continue
+		}
+
+		if q.query.WhereAndList == nil {
+			q.query.WhereAndList = &[]string{}
+		}
+		*q.query.WhereAndList = append(*q.query.WhereAndList, c.String())
+	}
+	return q
+}
+
+// OrWhere add conditions that at least one of them must be matched
+func (q *TypedQuery[T]) OrWhere(conditions ...Condition[T]) *TypedQuery[T] {
+	if q.query.WhereOrList == nil {
+		q.query.WhereOrList = &[]string{}
+	}
+
+	for _, c := range conditions {
+		*q.query.WhereOrList = append(*q.query.WhereOrList, c.filterTerm())
+	}
+	return q
+}
+
+// WhereGroup add nested filter group created with db.And / db.Or,
+// typed condition can be used as member of the group
+func (q *TypedQuery[T]) WhereGroup(groups ...Filter) *TypedQuery[T] {
+	q.query.Where(groups...)
+	return q
+}
+
+// WithTrashed include soft deleted row in query result
+func (q *TypedQuery[T]) WithTrashed() *TypedQuery[T] {
+	q.query.WithTrashed()
+	return q
+}
+
+// ForTenant scope query to tenant instead of tenant resolved from request
+func (q *TypedQuery[T]) ForTenant(tenant string) *TypedQuery[T] {
+	q.query.ForTenant(tenant)
+	return q
+}
+
+// WithoutTenant disable tenant scope of tenant scoped model
+func (q *TypedQuery[T]) WithoutTenant() *TypedQuery[T] {
+	q.query.WithoutTenant()
+	return q
+}
+
+// Preload load embedded resource, see Query.PreloadWith
+func (q *TypedQuery[T]) Preload(relation string, opts PreloadOptions) *TypedQuery[T] {
+	q.query.PreloadWith(relation, opts)
+	return q
+}
+
+func (q *TypedQuery[T]) OrderAsc(column ColumnOf[T]) *TypedQuery[T] {
+	q.query.OrderAsc(column.ColumnName())
+	return q
+}
+
+func (q *TypedQuery[T]) OrderDesc(column ColumnOf[T]) *TypedQuery[T] {
+	q.query.OrderDesc(column.ColumnName())
+	return q
+}
+
+func (q *TypedQuery[T]) Limit(value int) *TypedQuery[T] {
+	q.query.Limit(value)
+	return q
+}
+
+func (q *TypedQuery[T]) Offset(value int) *TypedQuery[T] {
+	q.query.Offset(value)
+	return q
+}
+
+func (q *TypedQuery[T]) GetQueryURI() string {
+	return q.query.GetQueryURI()
+}
+
+// All return all row that match the filter
+func (q *TypedQuery[T]) All() ([]T, error) {
+	if q.query.HasError() {
+		return nil, errors.Join(q.query.Errors...)
+	}
+
+	rows := make([]T, 0)
+	if err := q.query.Get(&rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Single return exactly one row, error is returned when no row or more than one row found
+func (q *TypedQuery[T]) Single() (*T, error) {
+	if q.query.HasError() {
+		return nil, errors.Join(q.query.Errors...)
+	}
+
+	var row T
+	if err := q.query.Single(&row); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// Paginate return one page of row, see Paginate
+func (q *TypedQuery[T]) Paginate(opts paginate.ExecuteOptions) (paginate.ExecuteResult[T], error) {
+	return Paginate[T](q.query, opts)
+}
+
+// Explain return execution plan of the query, see Query.Explain
+func (q *TypedQuery[T]) Explain(opts ExplainOptions) (*ExplainPlan, error) {
+	return q.query.Explain(opts)
+}
+
+func (q *TypedQuery[T]) Count(opts ...CountOptions) (int, error) {
+	return q.query.Count(opts...)
+}
+
+// Insert create new row and return the inserted data
+func (q *TypedQuery[T]) Insert(payload T) (*T, error) {
+	rows, _, err := q.InsertMany([]T{payload}, MutationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("insert %s : no row returned", GetTable(q.query.model))
+	}
+	return &rows[0], nil
+}
+
+// InsertMany create rows in single request and return the inserted data,
+// use MutationOptions.MissingDefault to fill missing value with column default
+func (q *TypedQuery[T]) InsertMany(payloads []T, opts MutationOptions) ([]T, MutationResult, error) {
+	rows := make([]T, 0)
+	result, err := q.query.InsertWith(payloads, &rows, opts)
+	if err != nil {
+		return nil, result, err
+	}
+	return rows, result, nil
+}
+
+// Update change all row that match the filter and return the updated data
+func (q *TypedQuery[T]) Update(payload T) ([]T, error) {
+	rows := make([]T, 0)
+	if _, err := q.query.UpdateWith(payload, &rows, MutationOptions{}); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Upsert insert or update rows and return the affected data
+func (q *TypedQuery[T]) Upsert(payloads []T, opts UpsertOptions) ([]T, MutationResult, error) {
+	rows := make([]T, 0)
+	result, err := q.query.UpsertWith(payloads, &rows, opts)
+	if err != nil {
+		return nil, result, err
+	}
+	return rows, result, nil
+}
+
+func (q *TypedQuery[T]) Delete() error {
+	return q.query.Delete()
+}
+
+// ForceDelete permanently delete row of soft delete model
+func (q *TypedQuery[T]) ForceDelete() error {
+	return q.query.ForceDelete()
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedQuery_GetQueryURI(t *testing.T) {
+	var m ArticleMockModel
+	id := ColOf(&m, &m.Id)
+	title := ColOf(&m, &m.Title)
+	rating := ColOf(&m, &m.Rating)
+	featured := ColOf(&m, &m.IsFeatured)
+
+	q := From[ArticleMockModel](&mockRaidenContext).
+		Select(id, title).
+		Where(rating.Gte(4), featured.IsTrue()).
+		OrWhere(id.Eq(1), title.Ilike("%raiden%")).
+		OrderDesc(rating).
+		Limit(10).
+		Offset(20)
+
+	assert.Equal(t, "articles?select=id,title&rating=gte.4&or=(id.eq.1,title.ilike.*raiden*)&is_featured=is.true&order=rating.desc&limit=10&offset=20", q.GetQueryURI())
+	assert.Equal(t, "/rest/v1/articles?select=id,title&rating=gte.4&or=(id.eq.1,title.ilike.*raiden*)&is_featured=is.true&order=rating.desc&limit=10&offset=20", q.Query().GetUrl())
+}
+
+func TestTypedQuery_OrWhereQuoteValue(t *testing.T) {
+	var m ArticleMockModel
+	title := ColOf(&m, &m.Title)
+
+	q := From[ArticleMockModel](&mockRaidenContext).
+		OrWhere(title.Eq(`a,(b)"c"`), title.Eq("raiden"))
+
+	assert.Equal(t, `articles?select=*&or=(title.eq."a,(b)\"c\"",title.eq.raiden)`, q.GetQueryURI())
+}
+
+func TestTypedQuery_InvalidColumn(t *testing.T) {
+	q := From[ArticleMockModel](&mockRaidenContext).
+		Select(NewColumn[ArticleMockModel, string]("unknown"))
+
+	rows, err := q.All()
+	assert.Nil(t, rows)
+	assert.EqualError(t, err, "invalid column: \"unknown\" is not available on \"articles\" table")
+
+	row, err := q.Single()
+	assert.Nil(t, row)
+	assert.Error(t, err)
+
+	inserted, err := q.Insert(ArticleMockModel{Title: "raiden"})
+	assert.Nil(t, inserted)
+	assert.Error(t, err)
+}
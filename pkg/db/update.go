@@ -2,43 +2,61 @@ package db
 
 import (
 	"encoding/json"
-	"reflect"
-	"strings"
 
 	"github.com/valyala/fasthttp"
 )
 
 func (q *Query) Update(p interface{}, model interface{}) error {
-	jsonData, err := json.Marshal(p)
+	_, err := q.UpdateWith(p, model, MutationOptions{})
+	return err
+}
+
+// UpdateWith update all row that match the filter and bind updated row into result,
+// version of versioned model is checked and incremented, ErrVersionConflict is returned when no row match
+func (q *Query) UpdateWith(payload interface{}, result interface{}, opts MutationOptions) (MutationResult, error) {
+	payload, err := q.beforeUpdate(payload)
 	if err != nil {
-		return err
+		return MutationResult{}, err
 	}
 
-	url := q.GetUrl()
-
-	var cols []string
-
-	t := reflect.TypeOf(p)
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return MutationResult{}, err
+	}
 
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+	// tenant column is stamped so row can't be moved to other tenant
+	if jsonData, err = q.stampTenant(jsonData); err != nil {
+		return MutationResult{}, err
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		cols = append(cols, field.Name)
+	lock, err := q.getVersionLock(payload)
+	if err != nil {
+		return MutationResult{}, err
 	}
 
-	url = url + "&" + strings.Join(cols, ",")
+	target := q
+	if lock != nil {
+		if target, jsonData, err = lock.apply(q, jsonData); err != nil {
+			return MutationResult{}, err
+		}
+
+		// affected row is needed to detect conflict
+		if opts.Count == "" {
+			opts.Count = "exact"
+		}
+	}
 
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/json"
-	headers["Prefer"] = "return=representation"
+	mutationResult, err := target.mutate(fasthttp.MethodPatch, target.mutationUrl(opts), jsonData, result, opts)
+	if err != nil {
+		return mutationResult, err
+	}
 
-	_, err0 := PostgrestRequest(q.Context, q.credential, fasthttp.MethodPatch, url, jsonData, headers, q.ByPass, model)
-	if err0 != nil {
-		return err0
+	if lock != nil {
+		if mutationResult.Count == 0 {
+			return mutationResult, ErrVersionConflict
+		}
+		lock.commit()
 	}
 
-	return nil
+	return mutationResult, q.afterUpdate(hookTarget(result, payload))
 }
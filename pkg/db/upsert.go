@@ -2,12 +2,21 @@ package db
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/sev-2/raiden"
 	"github.com/valyala/fasthttp"
 )
 
-type UpsertOptions = struct {
+type UpsertOptions struct {
+	// OnConflict is comma separated unique columns used to detect duplicate, for example `org_id,slug`,
+	// MergeDuplicates and IgnoreDuplicates value is treated as Resolution for backward compatibility
 	OnConflict string
+
+	// Resolution is MergeDuplicates or IgnoreDuplicates, default is MergeDuplicates
+	Resolution string
+
+	MutationOptions
 }
 
 const (
@@ -16,22 +25,60 @@ const (
 )
 
 func (q *Query) Upsert(payload []interface{}, opt UpsertOptions) error {
+	_, err := q.UpsertWith(payload, nil, opt)
+	return err
+}
+
+// UpsertWith insert or update payload and bind affected row into result,
+// BeforeInsert and AfterInsert hook is called for upsert
+func (q *Query) UpsertWith(payload interface{}, result interface{}, opt UpsertOptions) (MutationResult, error) {
+	onConflict, resolution := opt.OnConflict, opt.Resolution
+	if onConflict == MergeDuplicates || onConflict == IgnoreDuplicates {
+		onConflict, resolution = "", onConflict
+	}
+
+	if resolution == "" {
+		resolution = MergeDuplicates
+	}
+
+	if resolution != MergeDuplicates && resolution != IgnoreDuplicates {
+		return MutationResult{}, fmt.Errorf("unrecognized resolution options: %s", resolution)
+	}
+
+	// update on conflict doesn't apply tenant filter, so conflict target must contain tenant column
+	column, _, err := q.tenantScope()
+	if err != nil {
+		return MutationResult{}, err
+	}
+
+	if err := raiden.ValidateTenantUpsert(column, onConflict, resolution); err != nil {
+		return MutationResult{}, err
+	}
+
+	payload, err = q.beforeInsert(payload)
+	if err != nil {
+		return MutationResult{}, err
+	}
+
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return MutationResult{}, err
 	}
 
-	url := q.GetUrl()
+	if jsonData, err = q.stampTenant(jsonData); err != nil {
+		return MutationResult{}, err
+	}
 
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/json"
-	headers["Prefer"] = "resolution=" + opt.OnConflict
+	var params []string
+	if onConflict != "" {
+		params = append(params, "on_conflict="+onConflict)
+	}
 
-	var a interface{}
-	_, err0 := PostgrestRequest(q.Context, q.credential, fasthttp.MethodPost, url, jsonData, headers, q.ByPass, &a)
-	if err0 != nil {
-		return err0
+	url := q.unscoped().mutationUrl(opt.MutationOptions, params...)
+	mutationResult, err := q.mutate(fasthttp.MethodPost, url, jsonData, result, opt.MutationOptions, "resolution="+resolution)
+	if err != nil {
+		return mutationResult, err
 	}
 
-	return nil
+	return mutationResult, q.afterInsert(hookTarget(result, payload))
 }
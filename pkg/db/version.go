@@ -0,0 +1,100 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/sev-2/raiden"
+	"github.com/valyala/fasthttp"
+)
+
+// ErrVersionConflict is returned by update of versioned model when no row match the version,
+// the row is already changed by another request or not exist
+var ErrVersionConflict = &raiden.ErrorResponse{
+	StatusCode: fasthttp.StatusConflict,
+	Code:       fasthttp.StatusMessage(fasthttp.StatusConflict),
+	Message:    "version conflict: row is modified by another request or not found",
+	Hint:       "Reload the data and try again",
+}
+
+type versionLock struct {
+	column  string
+	current int64
+	field   reflect.Value
+}
+
+// getVersionLock return version of payload when model have version column,
+// payload must contain the version column to be locked, payload that is not struct is rejected
+// because the version can't be read and the update would run without the lock
+func (q *Query) getVersionLock(payload interface{}) (*versionLock, error) {
+	column, found := getColumnByOption(q.model, VersionOption)
+	if !found {
+		return nil, nil
+	}
+
+	pv := reflect.ValueOf(payload)
+	for pv.IsValid() && pv.Kind() == reflect.Ptr && !pv.IsNil() {
+		pv = pv.Elem()
+	}
+
+	if !pv.IsValid() || pv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid version: payload of versioned model must be struct, got %T", payload)
+	}
+
+	for i := 0; i < pv.NumField(); i++ {
+		field := pv.Type().Field(i)
+		if !field.IsExported() || getColumnName(field) != column {
+			continue
+		}
+
+		value := pv.Field(i)
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil, fmt.Errorf("invalid version: column \"%s\" of payload is nil", column)
+			}
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return &versionLock{column: column, current: value.Int(), field: value}, nil
+		default:
+			return nil, fmt.Errorf("invalid version: column \"%s\" must be integer", column)
+		}
+	}
+
+	return nil, nil
+}
+
+// apply return copy of query filtered by current version and increment version in json payload,
+// filter is not added to the caller query so it can be reused for next update
+func (v *versionLock) apply(q *Query, jsonData []byte) (*Query, []byte, error) {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &data); err != nil {
+		return nil, nil, err
+	}
+	data[v.column] = json.RawMessage(fmt.Sprintf("%d", v.current+1))
+
+	lockedData, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	locked := *q
+	where := make([]string, 0)
+	if q.WhereAndList != nil {
+		where = append(where, *q.WhereAndList...)
+	}
+	locked.WhereAndList = &where
+	locked.Eq(v.column, v.current)
+
+	return &locked, lockedData, nil
+}
+
+// commit set the incremented version back to payload when it is addressable
+func (v *versionLock) commit() {
+	if v.field.CanSet() {
+		v.field.SetInt(v.current + 1)
+	}
+}
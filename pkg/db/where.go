@@ -175,7 +175,7 @@ func (q *Query) OrLte(column string, value any) *Query {
 	return q
 }
 
-func (q *Query) Gt(column string, value int) *Query {
+func (q *Query) Gt(column string, value any) *Query {
 
 	if q.WhereAndList == nil {
 		q.WhereAndList = &[]string{}
@@ -189,7 +189,7 @@ func (q *Query) Gt(column string, value int) *Query {
 	return q
 }
 
-func (q *Query) NotGt(column string, value int) *Query {
+func (q *Query) NotGt(column string, value any) *Query {
 
 	if q.WhereAndList == nil {
 		q.WhereAndList = &[]string{}
@@ -203,7 +203,7 @@ func (q *Query) NotGt(column string, value int) *Query {
 	return q
 }
 
-func (q *Query) OrGt(column string, value int) *Query {
+func (q *Query) OrGt(column string, value any) *Query {
 
 	if q.WhereOrList == nil {
 		q.WhereOrList = &[]string{}
@@ -0,0 +1,1279 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var ClientLogger hclog.Logger = logger.HcLog().Named("generator.client")
+
+// ----- Define type, variable and constant -----
+type (
+	ClientLanguage string
+
+	GenerateClientData struct {
+		Types  []GenerateClientType
+		Routes []GenerateClientRoute
+		Models []GenerateClientModel
+		Rpc    []GenerateClientRpc
+	}
+
+	// GenerateClientType is typescript declaration of go type,
+	// rendered as interface when Fields is set and as type alias otherwise
+	GenerateClientType struct {
+		Name        string
+		Alias       string
+		Extends     []string
+		Fields      []GenerateClientField
+		IsInterface bool
+	}
+
+	GenerateClientField struct {
+		Name     string
+		Type     string
+		Optional bool
+	}
+
+	GenerateClientRoute struct {
+		FuncName        string
+		Method          string
+		Path            string
+		UrlPath         string
+		PathParams      []string
+		PayloadType     string
+		PayloadOptional bool
+		QueryKeys       []string
+		HasBody         bool
+		ResultType      string
+	}
+
+	GenerateClientModel struct {
+		Name string
+		Type string
+		Path string
+	}
+
+	GenerateClientRpc struct {
+		FuncName       string
+		Name           string
+		ParamsType     string
+		ParamsOptional bool
+		ParamKeys      []GenerateClientKeyMap
+		ReturnType     string
+	}
+
+	GenerateClientKeyMap struct {
+		From string
+		To   string
+	}
+
+	clientPackage struct {
+		dir      string
+		types    map[string]*ast.TypeSpec
+		typeFile map[string]*ast.File
+		consts   map[string]string
+		methods  map[string]map[string]*ast.FuncDecl
+	}
+
+	clientTypeScanner struct {
+		basePath  string
+		packages  map[string]*clientPackage
+		declared  map[string]string
+		usedNames map[string]bool
+		types     []GenerateClientType
+	}
+)
+
+const (
+	ClientLanguageTypescript ClientLanguage = "ts"
+
+	ClientDir        = "client"
+	ClientTsFilename = "raiden_client.ts"
+
+	clientTypeUnknown = "unknown"
+	clientRaidenPath  = "github.com/sev-2/raiden"
+)
+
+var clientPathParamRegex = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// ----- Generate client -----
+
+// GenerateClient generate typed client sdk for custom routes, rest model proxies and rpc,
+// the output path is relative to project path when it is not absolute path
+func GenerateClient(basePath string, mode raiden.Mode, lang ClientLanguage, outputPath string, generateFn GenerateFn) error {
+	if lang != ClientLanguageTypescript {
+		return fmt.Errorf("unsupported client language %s, available language are %s", lang, ClientLanguageTypescript)
+	}
+
+	if outputPath == "" {
+		outputPath = filepath.Join(ClientDir, ClientTsFilename)
+	}
+
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(basePath, outputPath)
+	}
+
+	outputDir := filepath.Dir(outputPath)
+	ClientLogger.Trace("create client folder if not exist", "path", outputDir)
+	if exist := utils.IsFolderExists(outputDir); !exist {
+		if err := utils.CreateFolder(outputDir); err != nil {
+			return err
+		}
+	}
+
+	data, err := BuildClientData(basePath, mode)
+	if err != nil {
+		return err
+	}
+
+	input := GenerateInput{
+		BindData:     data,
+		Template:     ClientTsTemplate,
+		TemplateName: "clientTsTemplate",
+		OutputPath:   outputPath,
+		FuncMap: []template.FuncMap{
+			{"FormatField": formatClientField, "JsonKeys": clientJsonKeys, "Join": strings.Join},
+		},
+	}
+
+	ClientLogger.Debug("generate client", "lang", lang, "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// BuildClientData scan controllers, models, rpc and types in project
+// and map all go type used by the endpoint to typescript declaration
+func BuildClientData(basePath string, mode raiden.Mode) (data GenerateClientData, err error) {
+	scanner := &clientTypeScanner{
+		basePath:  basePath,
+		packages:  make(map[string]*clientPackage),
+		declared:  make(map[string]string),
+		usedNames: make(map[string]bool),
+	}
+
+	funcNames := make(map[string]int)
+
+	controllerPath := filepath.Join(basePath, ControllerDir)
+	if utils.IsFolderExists(controllerPath) {
+		routes, e := WalkScanControllers(mode, controllerPath)
+		if e != nil {
+			return data, e
+		}
+
+		sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+		modelNames := make(map[string]int)
+		for _, r := range routes {
+			if e := scanner.buildRoute(&data, r, funcNames, modelNames); e != nil {
+				return data, e
+			}
+		}
+	}
+
+	rpcPath := filepath.Join(basePath, RpcDir)
+	if utils.IsFolderExists(rpcPath) {
+		pkg, e := scanner.loadPackage(RpcDir)
+		if e != nil {
+			return data, e
+		}
+
+		rpcFuncNames := make(map[string]int)
+		for _, name := range pkg.sortedTypeNames() {
+			st, isStruct := pkg.types[name].Type.(*ast.StructType)
+			if !isStruct || !isEmbedRpcBase(st) {
+				continue
+			}
+			data.Rpc = append(data.Rpc, scanner.buildRpc(pkg, name, st, rpcFuncNames))
+		}
+	}
+
+	data.Types = scanner.types
+	sort.Slice(data.Types, func(i, j int) bool { return data.Types[i].Name < data.Types[j].Name })
+	return data, nil
+}
+
+func (s *clientTypeScanner) buildRoute(data *GenerateClientData, r GenerateRouteItem, funcNames, modelNames map[string]int) error {
+	routePath, err := strconv.Unquote(r.Path)
+	if err != nil {
+		routePath = r.Path
+	}
+
+	routeType := raiden.RouteType(strings.ToLower(strings.TrimPrefix(r.Type, "raiden.RouteType")))
+	if routeType == raiden.RouteTypeStorage || routeType == raiden.RouteTypeRealtime || r.File == "" {
+		return nil
+	}
+
+	relDir, err := filepath.Rel(s.basePath, filepath.Dir(r.File))
+	if err != nil {
+		return err
+	}
+
+	pkg, err := s.loadPackage(relDir)
+	if err != nil {
+		return err
+	}
+
+	ts, exist := pkg.types[r.Name]
+	if !exist {
+		return nil
+	}
+
+	st, isStruct := ts.Type.(*ast.StructType)
+	if !isStruct {
+		return nil
+	}
+
+	file := pkg.typeFile[r.Name]
+	var payloadType, resultType, modelType ast.Expr
+	for _, f := range st.Fields.List {
+		for _, n := range f.Names {
+			switch n.Name {
+			case "Payload":
+				payloadType = f.Type
+			case "Result":
+				resultType = f.Type
+			case "Model":
+				modelType = f.Type
+			}
+		}
+	}
+
+	if routeType == raiden.RouteTypeRest {
+		if modelType == nil {
+			return nil
+		}
+
+		_, segment := filepath.Split(routePath)
+		name := uniqueClientName(toClientIdentifier(segment), modelNames)
+		data.Models = append(data.Models, GenerateClientModel{
+			Name: name,
+			Type: s.toTs(modelType, pkg, file),
+			Path: "/rest/v1" + routePath,
+		})
+		return nil
+	}
+
+	var methods []string
+	switch routeType {
+	case raiden.RouteTypeRpc, raiden.RouteTypeFunction:
+		methods = []string{"POST"}
+	default:
+		for _, m := range pkg.sortedMethodNames(r.Name) {
+			upper := strings.ToUpper(m)
+			switch upper {
+			case "GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD":
+				methods = append(methods, upper)
+			}
+		}
+	}
+
+	urlPrefix := ""
+	switch routeType {
+	case raiden.RouteTypeRpc:
+		urlPrefix = "/rest/v1/rpc"
+	case raiden.RouteTypeFunction:
+		urlPrefix = "/functions/v1"
+	}
+
+	// path placeholder become function argument and url is rendered as string concatenation
+	var pathParams, urlParts []string
+	fullPath, lastIndex := urlPrefix+routePath, 0
+	for _, loc := range clientPathParamRegex.FindAllStringSubmatchIndex(fullPath, -1) {
+		param := toClientIdentifier(fullPath[loc[2]:loc[3]])
+		pathParams = append(pathParams, param)
+		urlParts = append(urlParts, strconv.Quote(fullPath[lastIndex:loc[0]]), fmt.Sprintf("encodeURIComponent(String(%s))", param))
+		lastIndex = loc[1]
+	}
+	if lastIndex < len(fullPath) {
+		urlParts = append(urlParts, strconv.Quote(fullPath[lastIndex:]))
+	}
+	urlPath := strings.Join(urlParts, " + ")
+
+	resultTs := clientTypeUnknown
+	if resultType != nil {
+		resultTs = s.toTs(resultType, pkg, file)
+	}
+
+	var payloadTs string
+	var payloadOptional bool
+	var queryKeys, pathKeys []string
+	if payloadType != nil {
+		payloadTs = s.toTs(payloadType, pkg, file)
+		if payloadStruct := s.resolveStruct(payloadType, pkg, file); payloadStruct != nil {
+			payloadOptional = true
+			for _, f := range payloadStruct.Fields.List {
+				if len(f.Names) == 0 || !f.Names[0].IsExported() {
+					continue
+				}
+
+				tag := getClientFieldTag(f)
+				name, optional, skip := getClientFieldName(f.Names[0].Name, f, tag)
+				if skip {
+					continue
+				}
+
+				switch {
+				case tag.Get("path") != "":
+					pathKeys = append(pathKeys, name)
+					continue
+				case tag.Get("query") != "":
+					queryKeys = append(queryKeys, name)
+				}
+
+				if !optional && !isClientPointer(f.Type) {
+					payloadOptional = false
+				}
+			}
+
+			if len(pathKeys) > 0 {
+				payloadTs = fmt.Sprintf("Omit<%s, %s>", payloadTs, joinClientStringUnion(pathKeys))
+			}
+		}
+	}
+
+	for _, method := range methods {
+		baseName := strings.TrimSuffix(r.Name, "Controller")
+		funcName := uniqueClientName(strings.ToLower(method)+utils.SnakeCaseToPascalCase(utils.ToSnakeCase(baseName)), funcNames)
+		data.Routes = append(data.Routes, GenerateClientRoute{
+			FuncName:        funcName,
+			Method:          method,
+			Path:            fullPath,
+			UrlPath:         urlPath,
+			PathParams:      pathParams,
+			PayloadType:     payloadTs,
+			PayloadOptional: payloadOptional,
+			QueryKeys:       queryKeys,
+			HasBody:         method != "GET" && method != "HEAD",
+			ResultType:      resultTs,
+		})
+	}
+	return nil
+}
+
+func (s *clientTypeScanner) buildRpc(pkg *clientPackage, name string, st *ast.StructType, funcNames map[string]int) GenerateClientRpc {
+	file := pkg.typeFile[name]
+	rpc := GenerateClientRpc{
+		FuncName:   uniqueClientName(toClientIdentifier(name), funcNames),
+		Name:       utils.ToSnakeCase(name),
+		ReturnType: clientTypeUnknown,
+	}
+
+	useParamPrefix := true
+	if m, exist := pkg.methods[name]["GetName"]; exist {
+		if lit := findReturnStringLiteral(m.Body); lit != nil {
+			if value, err := strconv.Unquote(lit.Value); err == nil {
+				rpc.Name = value
+			}
+		}
+	}
+
+	if m, exist := pkg.methods[name]["UseParamPrefix"]; exist {
+		if ident, ok := findReturnExpr(m.Body).(*ast.Ident); ok && ident.Name == "false" {
+			useParamPrefix = false
+		}
+	}
+
+	for _, f := range st.Fields.List {
+		for _, n := range f.Names {
+			switch n.Name {
+			case "Params":
+				rpc.ParamsType = s.toTs(f.Type, pkg, file)
+				paramStruct := s.resolveStruct(f.Type, pkg, file)
+				if paramStruct == nil {
+					continue
+				}
+
+				rpc.ParamsOptional = true
+				for _, pf := range paramStruct.Fields.List {
+					if len(pf.Names) == 0 || !pf.Names[0].IsExported() {
+						continue
+					}
+
+					tag := getClientFieldTag(pf)
+					tsName, optional, skip := getClientFieldName(pf.Names[0].Name, pf, tag)
+					if skip {
+						continue
+					}
+
+					if !optional && !isClientPointer(pf.Type) {
+						rpc.ParamsOptional = false
+					}
+
+					key := utils.ToSnakeCase(pf.Names[0].Name)
+					if columnTag := tag.Get("column"); columnTag != "" {
+						if ct, err := raiden.UnmarshalRpcParamTag(columnTag); err == nil && ct.Name != "" {
+							key = ct.Name
+						}
+					}
+
+					if useParamPrefix {
+						key = raiden.DefaultRpcParamPrefix + key
+					}
+					rpc.ParamKeys = append(rpc.ParamKeys, GenerateClientKeyMap{From: tsName, To: strings.ToLower(key)})
+				}
+			case "Return":
+				rpc.ReturnType = s.toTs(f.Type, pkg, file)
+			}
+		}
+	}
+
+	return rpc
+}
+
+// ----- Go type scanner -----
+
+func (s *clientTypeScanner) loadPackage(relDir string) (*clientPackage, error) {
+	relDir = filepath.ToSlash(filepath.Clean(relDir))
+	if pkg, exist := s.packages[relDir]; exist {
+		return pkg, nil
+	}
+
+	pkg := &clientPackage{
+		dir:      relDir,
+		types:    make(map[string]*ast.TypeSpec),
+		typeFile: make(map[string]*ast.File),
+		consts:   make(map[string]string),
+		methods:  make(map[string]map[string]*ast.FuncDecl),
+	}
+	s.packages[relDir] = pkg
+
+	entries, err := os.ReadDir(filepath.Join(s.basePath, relDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pkg, nil
+		}
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(s.basePath, relDir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch sp := spec.(type) {
+					case *ast.TypeSpec:
+						pkg.types[sp.Name.Name] = sp
+						pkg.typeFile[sp.Name.Name] = file
+					case *ast.ValueSpec:
+						if d.Tok != token.CONST {
+							continue
+						}
+						for i, n := range sp.Names {
+							if i >= len(sp.Values) {
+								continue
+							}
+							if lit, ok := sp.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								if value, err := strconv.Unquote(lit.Value); err == nil {
+									pkg.consts[n.Name] = value
+								}
+							}
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 || d.Body == nil {
+					continue
+				}
+
+				receiver := getReceiverName(d.Recv.List[0].Type)
+				if _, exist := pkg.methods[receiver]; !exist {
+					pkg.methods[receiver] = make(map[string]*ast.FuncDecl)
+				}
+				pkg.methods[receiver][d.Name.Name] = d
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+// toTs map go type expression to typescript type, named type is declared when needed
+func (s *clientTypeScanner) toTs(expr ast.Expr, pkg *clientPackage, file *ast.File) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "byte", "rune":
+			return "number"
+		case "any", "error":
+			return clientTypeUnknown
+		}
+		return s.declare(pkg, e.Name)
+	case *ast.StarExpr:
+		return s.toTs(e.X, pkg, file)
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return "string"
+		}
+
+		elt := s.toTs(e.Elt, pkg, file)
+		if strings.Contains(elt, " ") {
+			elt = fmt.Sprintf("(%s)", elt)
+		}
+		return elt + "[]"
+	case *ast.MapType:
+		return fmt.Sprintf("Record<string, %s>", s.toTs(e.Value, pkg, file))
+	case *ast.StructType:
+		fields, _ := s.buildFields(e, pkg, file)
+		if len(fields) == 0 {
+			return "Record<string, unknown>"
+		}
+
+		var parts []string
+		for _, f := range fields {
+			parts = append(parts, formatClientField(f))
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(parts, " "))
+	case *ast.SelectorExpr:
+		return s.resolveSelector(e, file)
+	}
+	return clientTypeUnknown
+}
+
+func (s *clientTypeScanner) resolveSelector(se *ast.SelectorExpr, file *ast.File) string {
+	importPath := findClientImportPath(se, file)
+	switch importPath {
+	case "time":
+		if se.Sel.Name == "Time" {
+			return "string"
+		}
+		return clientTypeUnknown
+	case "github.com/google/uuid":
+		return "string"
+	case clientRaidenPath + "/pkg/postgres":
+		switch se.Sel.Name {
+		case "Date":
+			return "PgDate"
+		case "DateTime":
+			return "PgDateTime"
+		case "Point":
+			return "PgPoint"
+		}
+		return clientTypeUnknown
+	}
+
+	// user type from other internal package, ex : models.Candidate or types.OrderStatus
+	idx := strings.Index(importPath, "/internal/")
+	if idx < 0 {
+		return clientTypeUnknown
+	}
+
+	pkg, err := s.loadPackage(importPath[idx+1:])
+	if err != nil {
+		ClientLogger.Warn("failed load package", "path", importPath, "error", err)
+		return clientTypeUnknown
+	}
+	return s.declare(pkg, se.Sel.Name)
+}
+
+// declare register typescript declaration of named type and return the typescript name
+func (s *clientTypeScanner) declare(pkg *clientPackage, name string) string {
+	key := pkg.dir + "." + name
+	if tsName, exist := s.declared[key]; exist {
+		return tsName
+	}
+
+	ts, exist := pkg.types[name]
+	if !exist {
+		return clientTypeUnknown
+	}
+
+	tsName := name
+	if s.usedNames[tsName] {
+		_, pkgName := filepath.Split(pkg.dir)
+		tsName = utils.SnakeCaseToPascalCase(pkgName) + name
+	}
+	for i := 2; s.usedNames[tsName]; i++ {
+		tsName = fmt.Sprintf("%s%d", name, i)
+	}
+	s.usedNames[tsName] = true
+
+	// register first for handle recursive type
+	s.declared[key] = tsName
+	file := pkg.typeFile[name]
+
+	decl := GenerateClientType{Name: tsName}
+	if st, isStruct := ts.Type.(*ast.StructType); isStruct {
+		if isEmbedClientTypeBase(st, file) {
+			decl.Alias = s.buildUserType(pkg, name)
+		} else {
+			decl.IsInterface = true
+			decl.Fields, decl.Extends = s.buildFields(st, pkg, file)
+		}
+	} else {
+		decl.Alias = s.toTs(ts.Type, pkg, file)
+	}
+
+	s.types = append(s.types, decl)
+	return tsName
+}
+
+// buildUserType map registered type to union of enum value
+func (s *clientTypeScanner) buildUserType(pkg *clientPackage, name string) string {
+	m, exist := pkg.methods[name]["Enums"]
+	if !exist {
+		return "Record<string, unknown>"
+	}
+
+	lit, ok := findReturnExpr(m.Body).(*ast.CompositeLit)
+	if !ok || len(lit.Elts) == 0 {
+		return "Record<string, unknown>"
+	}
+
+	var enums []string
+	for _, elt := range lit.Elts {
+		switch v := elt.(type) {
+		case *ast.BasicLit:
+			if value, err := strconv.Unquote(v.Value); err == nil {
+				enums = append(enums, value)
+			}
+		case *ast.Ident:
+			if value, exist := pkg.consts[v.Name]; exist {
+				enums = append(enums, value)
+			}
+		}
+	}
+
+	if len(enums) == 0 {
+		return "string"
+	}
+	return joinClientStringUnion(enums)
+}
+
+func (s *clientTypeScanner) buildFields(st *ast.StructType, pkg *clientPackage, file *ast.File) (fields []GenerateClientField, extends []string) {
+	for _, f := range st.Fields.List {
+		tag := getClientFieldTag(f)
+
+		// embedded struct become parent interface
+		if len(f.Names) == 0 {
+			if name, _, _ := getClientFieldName("", f, tag); name != "" {
+				continue
+			}
+
+			if isClientRaidenType(f.Type, file) {
+				continue
+			}
+
+			if parent := s.toTs(f.Type, pkg, file); parent != clientTypeUnknown {
+				extends = append(extends, parent)
+			}
+			continue
+		}
+
+		for _, n := range f.Names {
+			if !n.IsExported() || isClientRaidenType(f.Type, file) {
+				continue
+			}
+
+			name, optional, skip := getClientFieldName(n.Name, f, tag)
+			if skip {
+				continue
+			}
+
+			fieldType := s.toTs(f.Type, pkg, file)
+			if isClientPointer(f.Type) {
+				optional = true
+				fieldType += " | null"
+			}
+
+			fields = append(fields, GenerateClientField{
+				Name:     name,
+				Type:     fieldType,
+				Optional: optional,
+			})
+		}
+	}
+	return
+}
+
+func (s *clientTypeScanner) resolveStruct(expr ast.Expr, pkg *clientPackage, file *ast.File) *ast.StructType {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return s.resolveStruct(e.X, pkg, file)
+	case *ast.StructType:
+		return e
+	case *ast.Ident:
+		if ts, exist := pkg.types[e.Name]; exist {
+			return s.resolveStruct(ts.Type, pkg, pkg.typeFile[e.Name])
+		}
+	case *ast.SelectorExpr:
+		importPath := findClientImportPath(e, file)
+		if idx := strings.Index(importPath, "/internal/"); idx >= 0 {
+			if otherPkg, err := s.loadPackage(importPath[idx+1:]); err == nil {
+				return s.resolveStruct(&ast.Ident{Name: e.Sel.Name}, otherPkg, nil)
+			}
+		}
+	}
+	return nil
+}
+
+// ----- Helper -----
+
+func (p *clientPackage) sortedTypeNames() []string {
+	names := make([]string, 0, len(p.types))
+	for name := range p.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *clientPackage) sortedMethodNames(receiver string) []string {
+	names := make([]string, 0, len(p.methods[receiver]))
+	for name := range p.methods[receiver] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func getClientFieldTag(f *ast.Field) reflect.StructTag {
+	if f.Tag == nil {
+		return ""
+	}
+
+	tagValue, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tagValue)
+}
+
+// getClientFieldName return serialized field name, json tag has highest priority
+// followed by query and path tag that used by controller payload
+func getClientFieldName(fieldName string, f *ast.Field, tag reflect.StructTag) (name string, optional bool, skip bool) {
+	name = fieldName
+	if jsonTag, exist := tag.Lookup("json"); exist {
+		if jsonTag == "-" {
+			return "", false, true
+		}
+
+		parts := strings.Split(jsonTag, ",")
+		if parts[0] != "" {
+			name = parts[0]
+		}
+
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" || opt == "omitzero" {
+				optional = true
+			}
+		}
+		return
+	}
+
+	if query := tag.Get("query"); query != "" {
+		return query, isClientPointer(f.Type), false
+	}
+
+	if path := tag.Get("path"); path != "" {
+		return path, false, false
+	}
+	return
+}
+
+func findClientImportPath(se *ast.SelectorExpr, file *ast.File) string {
+	ident, ok := se.X.(*ast.Ident)
+	if !ok || file == nil {
+		return ""
+	}
+
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		alias := importPath[strings.LastIndex(importPath, "/")+1:]
+		if imp.Name != nil {
+			alias = imp.Name.Name
+		}
+
+		if alias == ident.Name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// isClientRaidenType check type is framework type like raiden.Acl or db.ModelBase that not serialized
+func isClientRaidenType(expr ast.Expr, file *ast.File) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	se, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	importPath := findClientImportPath(se, file)
+	return importPath == clientRaidenPath || importPath == clientRaidenPath+"/pkg/db"
+}
+
+func isEmbedClientTypeBase(st *ast.StructType, file *ast.File) bool {
+	for _, f := range st.Fields.List {
+		if len(f.Names) > 0 {
+			continue
+		}
+
+		if se, ok := f.Type.(*ast.SelectorExpr); ok && se.Sel.Name == reflect.TypeOf(raiden.TypeBase{}).Name() && findClientImportPath(se, file) == clientRaidenPath {
+			return true
+		}
+	}
+	return false
+}
+
+func isClientPointer(expr ast.Expr) bool {
+	_, ok := expr.(*ast.StarExpr)
+	return ok
+}
+
+func formatClientField(f GenerateClientField) string {
+	name := f.Name
+	if !isClientIdentifier(name) {
+		name = strconv.Quote(name)
+	}
+
+	if f.Optional {
+		return fmt.Sprintf("%s?: %s;", name, f.Type)
+	}
+	return fmt.Sprintf("%s: %s;", name, f.Type)
+}
+
+func isClientIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		isLetter := r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func joinClientStringUnion(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, " | ")
+}
+
+func toClientIdentifier(s string) string {
+	pascal := utils.SnakeCaseToPascalCase(utils.ToSnakeCase(s))
+	if pascal == "" {
+		return pascal
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
+
+func uniqueClientName(name string, used map[string]int) string {
+	used[name]++
+	if used[name] == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s%d", name, used[name])
+}
+
+func clientJsonKeys(keys []string) string {
+	quoted := make([]string, 0, len(keys))
+	for _, k := range keys {
+		quoted = append(quoted, strconv.Quote(k))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+const ClientTsTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+/* eslint-disable */
+
+// ----- Postgres type -----
+
+// date with format YYYY-MM-DD
+export type PgDate = string;
+
+// timestamp in RFC3339 format
+export type PgDateTime = string;
+
+// point with format (x,y)
+export type PgPoint = string;
+
+// ----- Generated type -----
+{{- range .Types }}
+{{ if .IsInterface }}
+export interface {{ .Name }}{{ if .Extends }} extends {{ Join .Extends ", " }}{{ end }} {
+{{- range .Fields }}
+  {{ FormatField . }}
+{{- end }}
+}
+{{- else }}
+export type {{ .Name }} = {{ .Alias }};
+{{- end }}
+{{- end }}
+
+// ----- Runtime -----
+
+export interface RaidenClientOptions {
+  baseUrl: string;
+  apiKey?: string;
+  accessToken?: string | (() => string | undefined | Promise<string | undefined>);
+  headers?: Record<string, string>;
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  headers?: Record<string, string>;
+  signal?: AbortSignal;
+}
+
+export interface RaidenRequest {
+  query?: Record<string, unknown> | [string, string][];
+  body?: unknown;
+  headers?: Record<string, string>;
+}
+
+export type CountMethod = "exact" | "planned" | "estimated";
+
+type Column<T> = Extract<keyof T, string>;
+
+export class RaidenError extends Error {
+  readonly status: number;
+  readonly code?: string;
+  readonly details?: unknown;
+  readonly hint?: string;
+
+  constructor(status: number, body: any) {
+    super((body && body.message) || "request failed with status " + status);
+    this.name = "RaidenError";
+    this.status = status;
+    this.code = body && body.code;
+    this.details = body && body.details;
+    this.hint = body && body.hint;
+  }
+}
+
+function pickKeys(source: any, keys: string[]): Record<string, unknown> {
+  const result: Record<string, unknown> = {};
+  for (const key of keys) {
+    if (source && source[key] !== undefined) {
+      result[key] = source[key];
+    }
+  }
+  return result;
+}
+
+function omitKeys(source: any, keys: string[]): Record<string, unknown> {
+  const result: Record<string, unknown> = {};
+  for (const key of Object.keys(source || {})) {
+    if (!keys.includes(key) && source[key] !== undefined) {
+      result[key] = source[key];
+    }
+  }
+  return result;
+}
+
+function renameKeys(source: any, keys: [string, string][]): Record<string, unknown> {
+  const result: Record<string, unknown> = {};
+  for (const [from, to] of keys) {
+    if (source && source[from] !== undefined) {
+      result[to] = source[from];
+    }
+  }
+  return result;
+}
+
+function formatFilterValue(value: unknown): string {
+  if (Array.isArray(value)) {
+    return "(" + value.map((v) => formatListItem(v)).join(",") + ")";
+  }
+  if (value === null) {
+    return "null";
+  }
+  return String(value);
+}
+
+function formatListItem(value: unknown): string {
+  const str = String(value);
+  return /[,.:()"\s]/.test(str) ? JSON.stringify(str) : str;
+}
+
+// QueryBuilder build postgrest query for rest model proxy
+export class QueryBuilder<T> {
+  private readonly params: [string, string][] = [];
+  private readonly prefer: string[] = [];
+  private readonly headers: Record<string, string> = {};
+
+  constructor(private readonly client: RaidenClient, private readonly path: string) {}
+
+  select(...columns: (Column<T> | string)[]): this {
+    this.params.push(["select", columns.length > 0 ? columns.join(",") : "*"]);
+    return this;
+  }
+
+  eq<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "eq", value);
+  }
+
+  neq<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "neq", value);
+  }
+
+  gt<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "gt", value);
+  }
+
+  gte<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "gte", value);
+  }
+
+  lt<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "lt", value);
+  }
+
+  lte<K extends Column<T>>(column: K, value: T[K]): this {
+    return this.filter(column, "lte", value);
+  }
+
+  like<K extends Column<T>>(column: K, pattern: string): this {
+    return this.filter(column, "like", pattern);
+  }
+
+  ilike<K extends Column<T>>(column: K, pattern: string): this {
+    return this.filter(column, "ilike", pattern);
+  }
+
+  is<K extends Column<T>>(column: K, value: null | boolean): this {
+    return this.filter(column, "is", value);
+  }
+
+  in<K extends Column<T>>(column: K, values: T[K][]): this {
+    return this.filter(column, "in", values);
+  }
+
+  not<K extends Column<T>>(column: K, operator: string, value: unknown): this {
+    return this.filter(column, "not." + operator, value);
+  }
+
+  or(filters: string): this {
+    this.params.push(["or", "(" + filters + ")"]);
+    return this;
+  }
+
+  filter(column: string, operator: string, value: unknown): this {
+    this.params.push([column, operator + "." + formatFilterValue(value)]);
+    return this;
+  }
+
+  order<K extends Column<T>>(column: K, options: { ascending?: boolean; nullsFirst?: boolean } = {}): this {
+    let value = column + (options.ascending === false ? ".desc" : ".asc");
+    if (options.nullsFirst !== undefined) {
+      value += options.nullsFirst ? ".nullsfirst" : ".nullslast";
+    }
+    this.params.push(["order", value]);
+    return this;
+  }
+
+  limit(count: number): this {
+    this.params.push(["limit", String(count)]);
+    return this;
+  }
+
+  offset(count: number): this {
+    this.params.push(["offset", String(count)]);
+    return this;
+  }
+
+  count(method: CountMethod = "exact"): this {
+    this.prefer.push("count=" + method);
+    return this;
+  }
+
+  get(options?: RequestOptions): Promise<T[]> {
+    return this.send<T[]>("GET", undefined, options);
+  }
+
+  async getWithCount(options?: RequestOptions): Promise<{ data: T[]; count: number | null }> {
+    if (!this.prefer.some((p) => p.startsWith("count="))) {
+      this.count();
+    }
+
+    const { data, response } = await this.client.raw<T[]>("GET", this.path, this.buildRequest(undefined), options);
+    const total = (response.headers.get("content-range") || "").split("/")[1];
+    return { data, count: total && total !== "*" ? Number(total) : null };
+  }
+
+  single(options?: RequestOptions): Promise<T> {
+    this.headers["Accept"] = "application/vnd.pgrst.object+json";
+    return this.send<T>("GET", undefined, options);
+  }
+
+  insert(values: Partial<T> | Partial<T>[], options?: RequestOptions): Promise<T[]> {
+    this.prefer.push("return=representation");
+    return this.send<T[]>("POST", values, options);
+  }
+
+  upsert(values: Partial<T> | Partial<T>[], options?: RequestOptions): Promise<T[]> {
+    this.prefer.push("resolution=merge-duplicates", "return=representation");
+    return this.send<T[]>("POST", values, options);
+  }
+
+  update(values: Partial<T>, options?: RequestOptions): Promise<T[]> {
+    this.prefer.push("return=representation");
+    return this.send<T[]>("PATCH", values, options);
+  }
+
+  delete(options?: RequestOptions): Promise<T[]> {
+    this.prefer.push("return=representation");
+    return this.send<T[]>("DELETE", undefined, options);
+  }
+
+  private buildRequest(body: unknown): RaidenRequest {
+    const headers: Record<string, string> = { ...this.headers };
+    if (this.prefer.length > 0) {
+      headers["Prefer"] = this.prefer.join(",");
+    }
+    return { query: this.params, body, headers };
+  }
+
+  private send<R>(method: string, body: unknown, options?: RequestOptions): Promise<R> {
+    return this.client.request<R>(method, this.path, this.buildRequest(body), options);
+  }
+}
+
+export class RaidenClient {
+  private readonly options: RaidenClientOptions;
+
+  constructor(options: RaidenClientOptions) {
+    this.options = { ...options, baseUrl: options.baseUrl.replace(/\/+$/, "") };
+  }
+
+  async request<R>(method: string, path: string, req: RaidenRequest = {}, options: RequestOptions = {}): Promise<R> {
+    const { data } = await this.raw<R>(method, path, req, options);
+    return data;
+  }
+
+  async raw<R>(method: string, path: string, req: RaidenRequest = {}, options: RequestOptions = {}): Promise<{ data: R; response: Response }> {
+    const query = new URLSearchParams();
+    if (Array.isArray(req.query)) {
+      for (const [key, value] of req.query) {
+        query.append(key, value);
+      }
+    } else if (req.query) {
+      for (const [key, value] of Object.entries(req.query)) {
+        if (value === undefined || value === null) {
+          continue;
+        }
+        for (const v of Array.isArray(value) ? value : [value]) {
+          query.append(key, String(v));
+        }
+      }
+    }
+
+    let url = this.options.baseUrl + path;
+    const queryString = query.toString();
+    if (queryString) {
+      url += (url.includes("?") ? "&" : "?") + queryString;
+    }
+
+    const headers: Record<string, string> = { Accept: "application/json", ...this.options.headers, ...req.headers, ...options.headers };
+    if (this.options.apiKey) {
+      headers["apikey"] = this.options.apiKey;
+    }
+
+    const token = typeof this.options.accessToken === "function" ? await this.options.accessToken() : this.options.accessToken;
+    if (token) {
+      headers["Authorization"] = "Bearer " + token;
+    }
+
+    let body: string | undefined;
+    if (req.body !== undefined && method !== "GET" && method !== "HEAD") {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(req.body);
+    }
+
+    const fetchFn = this.options.fetch || fetch;
+    const response = await fetchFn(url, { method, headers, body, signal: options.signal });
+    const text = await response.text();
+
+    let data: any = undefined;
+    if (text) {
+      try {
+        data = JSON.parse(text);
+      } catch {
+        data = text;
+      }
+    }
+
+    if (!response.ok) {
+      throw new RaidenError(response.status, data);
+    }
+    return { data: data as R, response };
+  }
+
+  from<T>(path: string): QueryBuilder<T> {
+    return new QueryBuilder<T>(this, path);
+  }
+
+  // ----- Routes -----
+{{- range .Routes }}
+
+  /** {{ .Method }} {{ .Path }} */
+  {{ .FuncName }}({{ range .PathParams }}{{ . }}: string | number, {{ end }}{{ if .PayloadType }}payload{{ if .PayloadOptional }}?{{ end }}: {{ .PayloadType }}, {{ end }}options?: RequestOptions): Promise<{{ .ResultType }}> {
+    return this.request<{{ .ResultType }}>("{{ .Method }}", {{ .UrlPath }}, {
+{{- if .PayloadType }}
+      query: pickKeys(payload, {{ JsonKeys .QueryKeys }}),
+{{- if .HasBody }}
+      body: omitKeys(payload, {{ JsonKeys .QueryKeys }}),
+{{- end }}
+{{- end }}
+    }, options);
+  }
+{{- end }}
+
+  // ----- Rest model proxy -----
+  readonly rest = {
+{{- range .Models }}
+    {{ .Name }}: (): QueryBuilder<{{ .Type }}> => this.from<{{ .Type }}>({{ printf "%q" .Path }}),
+{{- end }}
+  };
+
+  // ----- Rpc -----
+  readonly rpc = {
+{{- range .Rpc }}
+    /** POST /rest/v1/rpc/{{ .Name }} */
+    {{ .FuncName }}: ({{ if .ParamsType }}params{{ if .ParamsOptional }}?{{ end }}: {{ .ParamsType }}, {{ end }}options?: RequestOptions): Promise<{{ .ReturnType }}> =>
+      this.request<{{ .ReturnType }}>("POST", "/rest/v1/rpc/{{ .Name }}", {
+        body: {{ if .ParamsType }}renameKeys(params, [{{ range $i, $k := .ParamKeys }}{{ if $i }}, {{ end }}[{{ printf "%q" $k.From }}, {{ printf "%q" $k.To }}]{{ end }}]){{ else }}{}{{ end }},
+      }, options),
+{{- end }}
+  };
+}
+`
@@ -0,0 +1,257 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+const clientTypeSource = `package types
+
+import "github.com/sev-2/raiden"
+
+const (
+	OrderStatusPending = "pending"
+	OrderStatusPaid    = "paid"
+)
+
+type OrderStatus struct {
+	raiden.TypeBase
+}
+
+func (t *OrderStatus) Name() string {
+	return "order_status"
+}
+
+func (r *OrderStatus) Enums() []string {
+	return []string{OrderStatusPending, OrderStatusPaid}
+}
+`
+
+const clientModelSource = `package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/db"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"testproject/internal/types"
+)
+
+type Order struct {
+	db.ModelBase
+	Id        int64             ` + "`json:\"id,omitempty\" column:\"name:id;type:bigint;primaryKey\"`" + `
+	Code      uuid.UUID         ` + "`json:\"code\" column:\"name:code;type:uuid\"`" + `
+	Status    types.OrderStatus ` + "`json:\"status\" column:\"name:status;type:order_status\"`" + `
+	ShipDate  *postgres.Date    ` + "`json:\"ship_date\" column:\"name:ship_date;type:date\"`" + `
+	Location  postgres.Point    ` + "`json:\"location\" column:\"name:location;type:point\"`" + `
+	CreatedAt time.Time         ` + "`json:\"created_at\" column:\"name:created_at;type:timestamp\"`" + `
+
+	Metadata string ` + "`json:\"-\" schema:\"public\" tableName:\"order\"`" + `
+
+	Acl raiden.Acl
+
+	Items []*OrderItem ` + "`json:\"items,omitempty\" join:\"joinType:hasMany;primaryKey:id;foreignKey:order_id\"`" + `
+}
+
+type OrderItem struct {
+	db.ModelBase
+	Id    int64 ` + "`json:\"id\" column:\"name:id;type:bigint;primaryKey\"`" + `
+	Order *Order ` + "`json:\"order,omitempty\" join:\"joinType:hasOne;primaryKey:id;foreignKey:order_id\"`" + `
+}
+`
+
+const clientCustomControllerSource = `package orders
+
+import (
+	"github.com/sev-2/raiden"
+	"testproject/internal/models"
+)
+
+type OrderDetailRequest struct {
+	Id     int64  ` + "`path:\"id\"`" + `
+	Expand string ` + "`query:\"expand\"`" + `
+	Note   string ` + "`json:\"note\"`" + `
+}
+
+type OrderDetailResponse struct {
+	Order   models.Order      ` + "`json:\"order\"`" + `
+	Tags    map[string]string ` + "`json:\"tags\"`" + `
+	Summary struct {
+		Total float64 ` + "`json:\"total\"`" + `
+	} ` + "`json:\"summary\"`" + `
+}
+
+type OrderDetailController struct {
+	raiden.ControllerBase
+	Http    string ` + "`path:\"/orders/{id}\" type:\"custom\"`" + `
+	Payload *OrderDetailRequest
+	Result  OrderDetailResponse
+}
+
+func (c *OrderDetailController) Get(ctx raiden.Context) error {
+	return ctx.SendJson(c.Result)
+}
+
+func (c *OrderDetailController) Patch(ctx raiden.Context) error {
+	return ctx.SendJson(c.Result)
+}
+`
+
+const clientRestControllerSource = `package orders
+
+import (
+	"github.com/sev-2/raiden"
+	"testproject/internal/models"
+)
+
+type OrdersController struct {
+	raiden.ControllerBase
+	Http  string ` + "`path:\"/orders\" type:\"rest\"`" + `
+	Model models.Order
+}
+`
+
+const clientRpcSource = `package rpc
+
+import (
+	"github.com/sev-2/raiden"
+	"testproject/internal/types"
+)
+
+type GetOrdersParams struct {
+	Status types.OrderStatus ` + "`json:\"status\" column:\"name:status;type:order_status\"`" + `
+	Limit  *int64            ` + "`json:\"limit,omitempty\" column:\"name:max_row;type:integer\"`" + `
+}
+
+type GetOrdersItem struct {
+	Id   int64  ` + "`json:\"id\"`" + `
+	Code string ` + "`json:\"code\"`" + `
+}
+
+type GetOrdersResult []GetOrdersItem
+
+type GetOrders struct {
+	raiden.RpcBase
+	Params *GetOrdersParams ` + "`json:\"-\"`" + `
+	Return GetOrdersResult  ` + "`json:\"-\"`" + `
+}
+
+func (r *GetOrders) GetName() string {
+	return "get_orders"
+}
+
+type CountOrders struct {
+	raiden.RpcBase
+	Params *struct{} ` + "`json:\"-\"`" + `
+	Return int64     ` + "`json:\"-\"`" + `
+}
+
+func (r *CountOrders) UseParamPrefix() bool {
+	return false
+}
+`
+
+func writeClientSource(t *testing.T, basePath string, relPath string, source string) {
+	t.Helper()
+	filePath := filepath.Join(basePath, relPath)
+	assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	assert.NoError(t, os.WriteFile(filePath, []byte(source), 0o644))
+}
+
+func setupClientProject(t *testing.T) string {
+	dir := t.TempDir()
+	writeClientSource(t, dir, "internal/types/order_status.go", clientTypeSource)
+	writeClientSource(t, dir, "internal/models/order.go", clientModelSource)
+	writeClientSource(t, dir, "internal/controllers/orders/_id/custom.go", clientCustomControllerSource)
+	writeClientSource(t, dir, "internal/controllers/orders/rest.go", clientRestControllerSource)
+	writeClientSource(t, dir, "internal/rpc/get_orders.go", clientRpcSource)
+	return dir
+}
+
+func TestBuildClientData(t *testing.T) {
+	dir := setupClientProject(t)
+
+	data, err := generator.BuildClientData(dir, raiden.BffMode)
+	assert.NoError(t, err)
+
+	mapTypes := make(map[string]generator.GenerateClientType)
+	for _, ty := range data.Types {
+		mapTypes[ty.Name] = ty
+	}
+
+	assert.Equal(t, `"pending" | "paid"`, mapTypes["OrderStatus"].Alias)
+	assert.Equal(t, "GetOrdersItem[]", mapTypes["GetOrdersResult"].Alias)
+	assert.Equal(t, []generator.GenerateClientField{
+		{Name: "id", Type: "number", Optional: true},
+		{Name: "code", Type: "string"},
+		{Name: "status", Type: "OrderStatus"},
+		{Name: "ship_date", Type: "PgDate | null", Optional: true},
+		{Name: "location", Type: "PgPoint"},
+		{Name: "created_at", Type: "string"},
+		{Name: "items", Type: "OrderItem[]", Optional: true},
+	}, mapTypes["Order"].Fields)
+	assert.Equal(t, "Order | null", mapTypes["OrderItem"].Fields[1].Type)
+	assert.Equal(t, "Record<string, string>", mapTypes["OrderDetailResponse"].Fields[1].Type)
+	assert.Equal(t, "{ total: number; }", mapTypes["OrderDetailResponse"].Fields[2].Type)
+
+	assert.Len(t, data.Routes, 2)
+	assert.Equal(t, "getOrderDetail", data.Routes[0].FuncName)
+	assert.Equal(t, "GET", data.Routes[0].Method)
+	assert.Equal(t, "/orders/{id}", data.Routes[0].Path)
+	assert.Equal(t, `"/orders/" + encodeURIComponent(String(id))`, data.Routes[0].UrlPath)
+	assert.Equal(t, []string{"id"}, data.Routes[0].PathParams)
+	assert.Equal(t, `Omit<OrderDetailRequest, "id">`, data.Routes[0].PayloadType)
+	assert.Equal(t, []string{"expand"}, data.Routes[0].QueryKeys)
+	assert.False(t, data.Routes[0].HasBody)
+	assert.Equal(t, "patchOrderDetail", data.Routes[1].FuncName)
+	assert.True(t, data.Routes[1].HasBody)
+
+	assert.Equal(t, []generator.GenerateClientModel{
+		{Name: "orders", Type: "Order", Path: "/rest/v1/orders"},
+	}, data.Models)
+
+	assert.Len(t, data.Rpc, 2)
+	assert.Equal(t, "countOrders", data.Rpc[0].FuncName)
+	assert.Equal(t, "count_orders", data.Rpc[0].Name)
+	assert.Equal(t, "number", data.Rpc[0].ReturnType)
+	assert.Equal(t, "getOrders", data.Rpc[1].FuncName)
+	assert.Equal(t, "GetOrdersResult", data.Rpc[1].ReturnType)
+	assert.Equal(t, []generator.GenerateClientKeyMap{
+		{From: "status", To: "in_status"},
+		{From: "limit", To: "in_max_row"},
+	}, data.Rpc[1].ParamKeys)
+	assert.False(t, data.Rpc[1].ParamsOptional)
+}
+
+func TestGenerateClient(t *testing.T) {
+	dir := setupClientProject(t)
+
+	err := generator.GenerateClient(dir, raiden.BffMode, generator.ClientLanguageTypescript, "", generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ClientDir, generator.ClientTsFilename))
+	assert.NoError(t, err)
+
+	output := string(content)
+	assert.Contains(t, output, `export type OrderStatus = "pending" | "paid";`)
+	assert.Contains(t, output, "export interface Order {")
+	assert.Contains(t, output, "  ship_date?: PgDate | null;")
+	assert.Contains(t, output, `getOrderDetail(id: string | number, payload: Omit<OrderDetailRequest, "id">, options?: RequestOptions): Promise<OrderDetailResponse> {`)
+	assert.Contains(t, output, `return this.request<OrderDetailResponse>("GET", "/orders/" + encodeURIComponent(String(id)), {`)
+	assert.Contains(t, output, `body: omitKeys(payload, ["expand"]),`)
+	assert.Contains(t, output, `orders: (): QueryBuilder<Order> => this.from<Order>("/rest/v1/orders"),`)
+	assert.Contains(t, output, `getOrders: (params: GetOrdersParams, options?: RequestOptions): Promise<GetOrdersResult> =>`)
+	assert.Contains(t, output, `body: renameKeys(params, [["status", "in_status"], ["limit", "in_max_row"]]),`)
+}
+
+func TestGenerateClient_UnsupportedLanguage(t *testing.T) {
+	err := generator.GenerateClient(t.TempDir(), raiden.BffMode, "dart", "", generator.Generate)
+	assert.EqualError(t, err, "unsupported client language dart, available language are ts")
+}
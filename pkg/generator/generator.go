@@ -17,6 +17,7 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/acl"
 	"github.com/sev-2/raiden/pkg/builder"
 	"github.com/sev-2/raiden/pkg/logger"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
@@ -180,6 +181,7 @@ type aclInfo struct {
 	UseBuilder     bool
 	UseRoles       bool
 	UseNativeRoles bool
+	UsePreset      bool
 }
 
 type modelRoleRef struct {
@@ -191,6 +193,12 @@ type modelRoleRef struct {
 
 type aclBuildOptions struct {
 	StorageBucketName string
+
+	// ColumnPrivileges is written as column grant of model acl
+	ColumnPrivileges objects.ColumnPrivileges
+
+	// TablePrivileges is written as table grant of model acl
+	TablePrivileges objects.TablePrivileges
 }
 
 func normalizeImports(imports []string) []string {
@@ -216,7 +224,15 @@ func normalizeImports(imports []string) []string {
 
 func buildAclInfo(structName, receiver string, table objects.Table, policies objects.Policies, roleMap map[string]string, nativeRoleMap map[string]raiden.Role, opts *aclBuildOptions) (aclInfo, error) {
 	info := aclInfo{}
-	if !table.RLSEnabled && !table.RLSForced && len(policies) == 0 {
+
+	var columnPrivileges objects.ColumnPrivileges
+	var tablePrivileges objects.TablePrivileges
+	if opts != nil {
+		columnPrivileges = opts.ColumnPrivileges
+		tablePrivileges = opts.TablePrivileges
+	}
+
+	if !table.RLSEnabled && !table.RLSForced && len(policies) == 0 && len(columnPrivileges) == 0 && len(tablePrivileges) == 0 {
 		return info, nil
 	}
 
@@ -253,7 +269,39 @@ func buildAclInfo(structName, receiver string, table objects.Table, policies obj
 	categoryRules := map[string][]string{}
 	qualifier := builder.ClauseQualifier{Schema: table.Schema, Table: table.Name}
 
+	// policy produced by built-in preset is written as preset call instead of each rule
+	covered := make(map[string]bool)
+	if !isStorageScope {
+		for _, m := range acl.MatchPresets(table, policies) {
+			roleArgs, useRoles, useNative, err := resolvePolicyRoles(m.Roles, roleDecls, varNames, roleMap, nativeRoleMap)
+			if err != nil {
+				return info, err
+			}
+			if useRoles {
+				info.UseRoles = true
+			}
+			if useNative {
+				info.UseNativeRoles = true
+			}
+
+			category := "Preset Rule"
+			if m.Preset == acl.PresetTenantIsolation {
+				category = "Tenant Rule"
+			}
+			categoryRules[category] = append(categoryRules[category], formatModelPreset(receiver, m, roleArgs))
+			info.UsePreset = true
+
+			for _, name := range m.Policies {
+				covered[name] = true
+			}
+		}
+	}
+
 	for _, policy := range policiesCopy {
+		if covered[policy.Name] {
+			continue
+		}
+
 		definition, check := normalizePolicyClausesForModel(policy)
 		if isStorageScope {
 			definition = builder.StripStorageBucketFilter(definition, bucketName)
@@ -312,6 +360,40 @@ func buildAclInfo(structName, receiver string, table objects.Table, policies obj
 		categoryRules[category] = append(categoryRules[category], ruleLine)
 	}
 
+	for _, p := range sortTablePrivileges(tablePrivileges) {
+		roleArgs, useRoles, useNative, err := resolvePolicyRoles([]string{p.Role}, roleDecls, varNames, roleMap, nativeRoleMap)
+		if err != nil {
+			return info, err
+		}
+		if len(roleArgs) == 0 {
+			continue
+		}
+		if useRoles {
+			info.UseRoles = true
+		}
+		if useNative {
+			info.UseNativeRoles = true
+		}
+		categoryRules["Table Privilege"] = append(categoryRules["Table Privilege"], formatModelTableGrant(p, roleArgs))
+	}
+
+	for _, p := range sortColumnPrivileges(columnPrivileges) {
+		roleArgs, useRoles, useNative, err := resolvePolicyRoles([]string{p.Role}, roleDecls, varNames, roleMap, nativeRoleMap)
+		if err != nil {
+			return info, err
+		}
+		if len(roleArgs) == 0 {
+			continue
+		}
+		if useRoles {
+			info.UseRoles = true
+		}
+		if useNative {
+			info.UseNativeRoles = true
+		}
+		categoryRules["Column Privilege"] = append(categoryRules["Column Privilege"], formatModelColumnGrant(p, roleArgs))
+	}
+
 	if len(roleDecls) > 0 {
 		if len(body) > 0 {
 			body = append(body, "")
@@ -323,7 +405,7 @@ func buildAclInfo(structName, receiver string, table objects.Table, policies obj
 		}
 	}
 
-	order := []string{"Read Rule", "Write Rule", "All Action", "Additional Rule"}
+	order := []string{"Tenant Rule", "Preset Rule", "Read Rule", "Write Rule", "All Action", "Additional Rule", "Table Privilege", "Column Privilege"}
 	for _, category := range order {
 		rules := categoryRules[category]
 		if len(rules) == 0 {
@@ -332,8 +414,18 @@ func buildAclInfo(structName, receiver string, table objects.Table, policies obj
 		if len(body) > 0 {
 			body = append(body, "")
 		}
+
+		method := "Define"
+		switch category {
+		case "Tenant Rule", "Preset Rule":
+			method = "Use"
+		case "Table Privilege":
+			method = "GrantTable"
+		case "Column Privilege":
+			method = "GrantColumns"
+		}
 		body = append(body, fmt.Sprintf("\t// %s", category))
-		body = append(body, fmt.Sprintf("\t%s.Acl.Define(", receiver))
+		body = append(body, fmt.Sprintf("\t%s.Acl.%s(", receiver, method))
 		body = append(body, strings.Join(rules, "\n"))
 		body = append(body, "\t)")
 	}
@@ -483,6 +575,97 @@ func categorizePolicyCommand(cmd objects.PolicyCommand) string {
 	}
 }
 
+// formatModelPreset write preset call, column is referred by pointer of model field
+func formatModelPreset(receiver string, m acl.PresetMatch, roleArgs []string) string {
+	args := []string{receiver}
+	if m.Column != "" {
+		args = append(args, fmt.Sprintf("&%s.%s", receiver, utils.SnakeCaseToPascalCase(m.Column)))
+	}
+	if m.Preset == acl.PresetTenantIsolation {
+		args = append(args, fmt.Sprintf("%q", m.Claim))
+	}
+	args = append(args, roleArgs...)
+	return fmt.Sprintf("\t\tacl.Preset.%s(%s),", m.Preset, strings.Join(args, ", "))
+}
+
+func formatModelColumnGrant(p objects.ColumnPrivilege, roleArgs []string) string {
+	columns := make([]string, 0, len(p.Columns))
+	for _, c := range p.Columns {
+		columns = append(columns, fmt.Sprintf("%q", c))
+	}
+
+	privilege := "raiden.Privilege" + utils.SnakeCaseToPascalCase(strings.ToLower(p.Privilege))
+	return fmt.Sprintf("\t\traiden.ColumnGrant(%s).For(%s).Columns(%s),", privilege, strings.Join(roleArgs, ", "), strings.Join(columns, ", "))
+}
+
+func formatModelTableGrant(p objects.TablePrivilege, roleArgs []string) string {
+	exist := make(map[string]bool, len(p.Privileges))
+	for _, privilege := range p.Privileges {
+		exist[strings.ToUpper(privilege)] = true
+	}
+
+	commands := make([]string, 0, len(p.Privileges))
+	for _, c := range []raiden.Command{raiden.CommandSelect, raiden.CommandInsert, raiden.CommandUpdate, raiden.CommandDelete} {
+		if exist[string(c)] {
+			commands = append(commands, "raiden.Command"+utils.SnakeCaseToPascalCase(strings.ToLower(string(c))))
+		}
+	}
+
+	if len(commands) == 4 {
+		commands = []string{"raiden.CommandAll"}
+	}
+	return fmt.Sprintf("\t\traiden.TableGrant(%s).For(%s),", strings.Join(commands, ", "), strings.Join(roleArgs, ", "))
+}
+
+// sortTablePrivileges order privilege by role so generated code is stable
+func sortTablePrivileges(privileges objects.TablePrivileges) objects.TablePrivileges {
+	sorted := make(objects.TablePrivileges, 0, len(privileges))
+	for _, p := range privileges {
+		if len(p.Privileges) == 0 {
+			continue
+		}
+		sorted = append(sorted, p)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Role < sorted[j].Role
+	})
+	return sorted
+}
+
+// sortColumnPrivileges order privilege by privilege type and role so generated code is stable
+func sortColumnPrivileges(privileges objects.ColumnPrivileges) objects.ColumnPrivileges {
+	sorted := make(objects.ColumnPrivileges, 0, len(privileges))
+	for _, p := range privileges {
+		if p.AllColumns || len(p.Columns) == 0 {
+			continue
+		}
+		sorted = append(sorted, p)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := privilegeOrder(sorted[i].Privilege), privilegeOrder(sorted[j].Privilege)
+		if pi == pj {
+			return sorted[i].Role < sorted[j].Role
+		}
+		return pi < pj
+	})
+	return sorted
+}
+
+func privilegeOrder(privilege string) int {
+	switch strings.ToUpper(privilege) {
+	case string(raiden.PrivilegeSelect):
+		return 0
+	case string(raiden.PrivilegeInsert):
+		return 1
+	case string(raiden.PrivilegeUpdate):
+		return 2
+	default:
+		return 3
+	}
+}
+
 func formatModelRule(name string, roleArgs []string, command, using, check, mode string) string {
 	base := fmt.Sprintf("\t\traiden.Rule(%q)", name)
 	if len(roleArgs) > 0 {
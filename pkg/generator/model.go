@@ -3,6 +3,7 @@ package generator
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"text/template"
@@ -49,6 +50,20 @@ type (
 		Relations      []state.Relation
 		Policies       objects.Policies
 		ValidationTags state.ModelValidationTag
+
+		// ColumnOptions is column tag option of existing model that is not stored in database,
+		// for example softDelete and version, it is written back to regenerated column tag
+		ColumnOptions state.ModelColumnOption
+
+		// TenantColumn mark table as tenant scoped when table has the column,
+		// tenant isolation rule is only written when the policy exist in database
+		TenantColumn string
+
+		// ColumnPrivileges is privilege granted to subset of table column
+		ColumnPrivileges objects.ColumnPrivileges
+
+		// TablePrivileges is privilege granted to the whole table per role
+		TablePrivileges objects.TablePrivileges
 	}
 )
 
@@ -130,6 +145,8 @@ func GenerateModel(folderPath string, projectName string, input *GenerateModelIn
 
 	// map column data
 	columns, importsPath := MapTableAttributes(projectName, input.Table, mapDataType, input.ValidationTags)
+	markColumnOptions(columns, input.ColumnOptions)
+	markTenantColumn(columns, input.TenantColumn)
 	raidenPkgDbPath := "github.com/sev-2/raiden/pkg/db"
 	importsPath = append(importsPath, raidenPkgDbPath)
 
@@ -144,7 +161,15 @@ func GenerateModel(folderPath string, projectName string, input *GenerateModelIn
 	moduleName := utils.ToGoModuleName(projectName)
 	rolesImportPath := fmt.Sprintf("%s/internal/roles", moduleName)
 
-	aclInfo, err := buildAclInfo(structName, receiverName, input.Table, input.Policies, roleMap, nativeRoleMap, nil)
+	var aclOpts *aclBuildOptions
+	if len(input.ColumnPrivileges) > 0 || len(input.TablePrivileges) > 0 {
+		aclOpts = &aclBuildOptions{
+			ColumnPrivileges: input.ColumnPrivileges,
+			TablePrivileges:  input.TablePrivileges,
+		}
+	}
+
+	aclInfo, err := buildAclInfo(structName, receiverName, input.Table, input.Policies, roleMap, nativeRoleMap, aclOpts)
 	if err != nil {
 		return err
 	}
@@ -153,6 +178,9 @@ func GenerateModel(folderPath string, projectName string, input *GenerateModelIn
 	if aclInfo.UseBuilder {
 		importsPath = append(importsPath, `st "github.com/sev-2/raiden/pkg/builder"`)
 	}
+	if aclInfo.UsePreset {
+		importsPath = append(importsPath, "github.com/sev-2/raiden/pkg/acl")
+	}
 	if aclInfo.UseRoles {
 		importsPath = append(importsPath, fmt.Sprintf("roles %q", rolesImportPath))
 	}
@@ -274,6 +302,45 @@ func MapTableAttributes(projectName string, table objects.Table, mapDataType map
 	return
 }
 
+// markTenantColumn add tenant option to column tag of tenant column,
+// empty string is returned when table doesn't have the column
+func markTenantColumn(columns []GenerateModelColumn, tenantColumn string) string {
+	if tenantColumn == "" {
+		return ""
+	}
+
+	for i := range columns {
+		if columns[i].Name != tenantColumn {
+			continue
+		}
+
+		appendColumnOption(&columns[i], raiden.TenantOption)
+		return tenantColumn
+	}
+	return ""
+}
+
+// markColumnOptions add option of existing model to column tag,
+// option of column that no longer exist is dropped
+func markColumnOptions(columns []GenerateModelColumn, columnOptions state.ModelColumnOption) {
+	for i := range columns {
+		for _, option := range columnOptions[columns[i].Name] {
+			appendColumnOption(&columns[i], option)
+		}
+	}
+}
+
+// appendColumnOption add option to the end of column tag when column doesn't have it yet
+func appendColumnOption(column *GenerateModelColumn, option string) {
+	tag := reflect.StructTag(column.Tag).Get("column")
+	for _, item := range strings.Split(tag, ";") {
+		if item == option {
+			return
+		}
+	}
+	column.Tag = strings.TrimSuffix(column.Tag, "\"") + ";" + option + "\""
+}
+
 func resolvePolicyColumns(modelName, storageName string, tableMap map[string]objects.Table) (string, []objects.Column) {
 	if modelName != "" {
 		if table, ok := tableMap[modelName]; ok {
@@ -63,6 +63,19 @@ func TestGenerateClauseCodeHandlesVariants(t *testing.T) {
 	require.Equal(t, `st.Clause("invalid syntax $")`, fallback)
 }
 
+func TestGenerateClauseCodeSubquery(t *testing.T) {
+	columns := []objects.Column{{Name: "id"}, {Name: "org_id"}}
+	qualifier := builder.ClauseQualifier{Schema: "public", Table: "projects"}
+
+	sql := "(EXISTS ( SELECT 1\n   FROM memberships\n  WHERE ((memberships.project_id = projects.id) AND (memberships.user_id = auth.uid()))))"
+	code := generateClauseCode(sql, qualifier, "p.model", columns)
+	require.Equal(t, `st.ExistsQuery(st.SelectFrom("memberships").Where(st.Eq("memberships.project_id", st.Ident("projects.id")), st.Eq("memberships.user_id", st.AuthUID())))`, code)
+
+	sql = "(org_id IN ( SELECT memberships.org_id\n   FROM memberships\n  WHERE (memberships.user_id = auth.uid())))"
+	code = generateClauseCode(sql, qualifier, "p.model", columns)
+	require.Equal(t, `st.InQuery(st.ColOf(p.model, p.model.OrgId), st.SelectFrom("memberships", "memberships.org_id").Where(st.Eq("memberships.user_id", st.AuthUID())))`, code)
+}
+
 func TestBuildColumnAndRelationHelpers(t *testing.T) {
 	col := objects.Column{
 		Name:         "is_active",
@@ -92,3 +105,125 @@ func TestBuildColumnAndRelationHelpers(t *testing.T) {
 	require.Equal(t, 1, len(fields))
 	require.True(t, strings.Contains(fields[0].Table, "Profiles"))
 }
+
+func TestBuildAclInfo_TenantRule(t *testing.T) {
+	table := objects.Table{
+		Name:   "projects",
+		Schema: "public",
+		Columns: []objects.Column{
+			{Name: "id", DataType: string(postgres.BigIntType)},
+			{Name: "org_id", DataType: string(postgres.UuidType)},
+		},
+	}
+
+	// tenant isolation is not added when rls is disabled or the policy doesn't exist in database
+	info, err := buildAclInfo("Projects", "p", table, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.False(t, info.HasConfigure)
+
+	table.RLSEnabled = true
+	info, err = buildAclInfo("Projects", "p", table, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.NotContains(t, info.Body, "// Tenant Rule")
+
+	// existing tenant isolation policy is written as preset
+	tenant := "(org_id = ((current_setting('request.jwt.claims'::text))::jsonb ->> 'org_id'::text))::uuid"
+	policies := objects.Policies{{Name: "projects_tenant_isolation", Command: objects.PolicyCommandAll, Action: "RESTRICTIVE", Roles: []string{"public"}, Definition: tenant, Check: &tenant}}
+	info, err = buildAclInfo("Projects", "p", table, policies, nil, nil, nil)
+	require.NoError(t, err)
+	require.True(t, info.UsePreset)
+	require.Contains(t, info.Body, "p.Acl.Enable()")
+	require.Contains(t, info.Body, "// Tenant Rule\n\tp.Acl.Use(\n\t\tacl.Preset.TenantIsolation(p, &p.OrgId, \"org_id\"),\n\t)")
+
+	columns := []GenerateModelColumn{{Name: "org_id", Tag: `json:"org_id,omitempty" column:"name:org_id;type:uuid"`}}
+	require.Equal(t, "org_id", markTenantColumn(columns, "org_id"))
+	require.Equal(t, `json:"org_id,omitempty" column:"name:org_id;type:uuid;tenant"`, columns[0].Tag)
+	require.Equal(t, "", markTenantColumn(columns, "workspace_id"))
+}
+
+func TestBuildAclInfo_Preset(t *testing.T) {
+	table := objects.Table{
+		Name:       "projects",
+		Schema:     "public",
+		RLSEnabled: true,
+		Columns: []objects.Column{
+			{Name: "id", DataType: string(postgres.BigIntType)},
+			{Name: "user_id", DataType: string(postgres.UuidType)},
+			{Name: "org_id", DataType: string(postgres.UuidType)},
+		},
+	}
+
+	check := "(user_id = auth.uid())"
+	allCheck := "true"
+	tenant := "(org_id = (((current_setting('request.jwt.claims'::text))::jsonb ->> 'org_id'::text))::uuid)"
+	policies := objects.Policies{
+		{Name: "projects_owner_select", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Roles: []string{"authenticated"}, Definition: "(auth.uid() = user_id)"},
+		{Name: "projects_owner_insert", Command: objects.PolicyCommandInsert, Action: "PERMISSIVE", Roles: []string{"authenticated"}, Check: &check},
+		{Name: "projects_owner_update", Command: objects.PolicyCommandUpdate, Action: "PERMISSIVE", Roles: []string{"authenticated"}, Definition: "(user_id = auth.uid())", Check: &check},
+		{Name: "projects_owner_delete", Command: objects.PolicyCommandDelete, Action: "PERMISSIVE", Roles: []string{"authenticated"}, Definition: "(user_id = auth.uid())"},
+		{Name: "projects_service_role_all", Command: objects.PolicyCommandAll, Action: "PERMISSIVE", Roles: []string{"service_role"}, Definition: "true", Check: &allCheck},
+		{Name: "projects_tenant_isolation", Command: objects.PolicyCommandAll, Action: "RESTRICTIVE", Roles: []string{"public"}, Definition: tenant, Check: &tenant},
+		{Name: "projects_archived_read", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Definition: "(id > 10)"},
+	}
+
+	info, err := buildAclInfo("Projects", "p", table, policies, nil, map[string]raiden.Role{}, nil)
+	require.NoError(t, err)
+	require.True(t, info.UsePreset)
+	require.Contains(t, info.Body, "// Tenant Rule\n\tp.Acl.Use(\n\t\tacl.Preset.TenantIsolation(p, &p.OrgId, \"org_id\"),\n\t)")
+	require.Contains(t, info.Body, "// Preset Rule\n\tp.Acl.Use(")
+	require.Regexp(t, `acl\.Preset\.OwnerCRUD\(p, &p\.UserId, \w+\.Name\(\)\)`, info.Body)
+	require.Contains(t, info.Body, "acl.Preset.ServiceRoleAll(p),")
+	require.Contains(t, info.Body, `raiden.Rule("projects_archived_read")`)
+	require.NotContains(t, info.Body, `raiden.Rule("projects_owner_select")`)
+
+	// policy that differ from preset is kept as rule
+	policies[3].Definition = "(id = 1)"
+	info, err = buildAclInfo("Projects", "p", table, policies, nil, map[string]raiden.Role{}, nil)
+	require.NoError(t, err)
+	require.NotContains(t, info.Body, "acl.Preset.OwnerCRUD")
+	require.Contains(t, info.Body, `raiden.Rule("projects_owner_select")`)
+}
+
+func TestBuildAclInfo_ColumnPrivilege(t *testing.T) {
+	table := objects.Table{Name: "articles", Schema: "public"}
+	opts := &aclBuildOptions{
+		ColumnPrivileges: objects.ColumnPrivileges{
+			{Schema: "public", Table: "articles", Role: "editor", Privilege: "UPDATE", Columns: []string{"body", "title"}},
+			{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id", "title"}},
+			{Schema: "public", Table: "articles", Role: "authenticated", Privilege: "SELECT", AllColumns: true},
+		},
+	}
+
+	info, err := buildAclInfo("Articles", "a", table, nil, map[string]string{"editor": "editor"}, map[string]raiden.Role{"anon": nil}, opts)
+	require.NoError(t, err)
+	require.True(t, info.HasConfigure)
+	require.True(t, info.UseRoles)
+	require.True(t, info.UseNativeRoles)
+	require.Contains(t, info.Body, "// Column Privilege\n\ta.Acl.GrantColumns(\n"+
+		"\t\traiden.ColumnGrant(raiden.PrivilegeSelect).For(anon.Name()).Columns(\"id\", \"title\"),\n"+
+		"\t\traiden.ColumnGrant(raiden.PrivilegeUpdate).For(editor.Name()).Columns(\"body\", \"title\"),\n\t)")
+	require.NotContains(t, info.Body, "authenticated")
+}
+
+func TestBuildAclInfo_TablePrivilege(t *testing.T) {
+	table := objects.Table{Name: "articles", Schema: "public"}
+	opts := &aclBuildOptions{
+		TablePrivileges: objects.TablePrivileges{
+			{Schema: "public", Table: "articles", Role: "editor", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+			{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT"}},
+		},
+		ColumnPrivileges: objects.ColumnPrivileges{
+			{Schema: "public", Table: "articles", Role: "anon", Privilege: "UPDATE", Columns: []string{"title"}},
+		},
+	}
+
+	info, err := buildAclInfo("Articles", "a", table, nil, map[string]string{"editor": "editor"}, map[string]raiden.Role{"anon": nil}, opts)
+	require.NoError(t, err)
+	require.True(t, info.HasConfigure)
+	require.True(t, info.UseRoles)
+	require.True(t, info.UseNativeRoles)
+	require.Contains(t, info.Body, "// Table Privilege\n\ta.Acl.GrantTable(\n"+
+		"\t\traiden.TableGrant(raiden.CommandSelect).For(anon.Name()),\n"+
+		"\t\traiden.TableGrant(raiden.CommandAll).For(editor.Name()),\n\t)\n\n"+
+		"\t// Column Privilege\n\ta.Acl.GrantColumns(")
+}
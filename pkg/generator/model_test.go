@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/postgres"
@@ -65,6 +66,50 @@ func TestGenerateModels(t *testing.T) {
 	assert.FileExists(t, dir+"/internal/models/test_table.go")
 }
 
+type roundTripPost struct {
+	Id        int64      `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;nullable:false"`
+	Title     string     `json:"title,omitempty" column:"name:title;type:text;nullable:false"`
+	Version   int64      `json:"version,omitempty" column:"name:version;type:bigint;nullable:false;default:1;version"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" column:"name:deleted_at;type:timestamptz;nullable;softDelete"`
+
+	Metadata string `json:"-" schema:"public" tableName:"posts"`
+}
+
+func TestGenerateModels_KeepColumnOptions(t *testing.T) {
+	extracted, err := state.ExtractTable(nil, []any{&roundTripPost{}}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, extracted.New, 1)
+
+	item := extracted.New[0]
+	assert.Equal(t, state.ModelColumnOption{"version": {"version"}, "deleted_at": {"softDelete"}}, item.ColumnOptions)
+
+	dir, err := os.MkdirTemp("", "model")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, utils.CreateFolder(filepath.Join(dir, "internal")))
+
+	// remote table doesn't have column option, it is written back from existing model
+	remote := objects.Table{
+		Name:        "posts",
+		Schema:      "public",
+		PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		Columns: []objects.Column{
+			{Name: "id", DataType: "bigint", IsNullable: false},
+			{Name: "title", DataType: "text", IsNullable: false},
+			{Name: "version", DataType: "bigint", IsNullable: false},
+			{Name: "deleted_at", DataType: "timestamp with time zone", IsNullable: true},
+		},
+	}
+	tables := []*generator.GenerateModelInput{{Table: remote, ColumnOptions: item.ColumnOptions}}
+	assert.NoError(t, generator.GenerateModels(dir, "test-project", tables, nil, nil, nil, generator.GenerateFn(generator.Generate)))
+
+	content, err := os.ReadFile(filepath.Join(dir, "internal/models/posts.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `column:"name:version;type:bigint;nullable:false;version"`)
+	assert.Contains(t, string(content), `column:"name:deleted_at;type:timestampz;nullable;softDelete"`)
+	assert.Contains(t, string(content), `column:"name:title;type:text;nullable:false"`)
+}
+
 func TestGenerateModels_WithCustomType(t *testing.T) {
 	dir, err := os.MkdirTemp("", "model")
 	assert.NoError(t, err)
@@ -163,12 +163,18 @@ func GenerateRole(folderPath string, role objects.Role, generateFn GenerateFn) e
 		validUntil = role.ValidUntil.Format(raiden.DefaultRoleValidUntilLayout)
 	}
 
-	// set inherit roles
+	// set inherit roles, membership is kept even when role is not inherit privilege
 	var inheritRoles []string
-	if role.InheritRole && len(role.InheritRoles) > 0 {
-		for _, r := range role.InheritRoles {
-			inheritRoles = append(inheritRoles, fmt.Sprintf("&%s{}", utils.SnakeCaseToPascalCase(r.Name)))
+	for _, r := range role.InheritRoles {
+		if r == nil || r.Name == "" {
+			continue
 		}
+
+		inheritRole := fmt.Sprintf("&%s{}", utils.SnakeCaseToPascalCase(r.Name))
+		if r.AdminOption {
+			inheritRole = fmt.Sprintf("raiden.WithAdminOption(%s)", inheritRole)
+		}
+		inheritRoles = append(inheritRoles, inheritRole)
 	}
 
 	// execute the template and write to the file
@@ -73,3 +73,30 @@ func TestGenerateRoles_WithInheritance(t *testing.T) {
 	assert.Contains(t, generated, "return []raiden.Role{ &ChildRole{} }")
 	assert.Contains(t, generated, "github.com/sev-2/raiden")
 }
+
+func TestGenerateRoles_WithAdminOption(t *testing.T) {
+	dir, err := os.MkdirTemp("", "role-admin-option")
+	assert.NoError(t, err)
+
+	rolePath := filepath.Join(dir, "internal")
+	assert.NoError(t, utils.CreateFolder(rolePath))
+
+	roles := []objects.Role{
+		{
+			Name:        "editor",
+			InheritRole: false,
+			InheritRoles: []*objects.Role{
+				{Name: "author", AdminOption: true},
+			},
+		},
+	}
+
+	assert.NoError(t, generator.GenerateRoles(dir, roles, generator.GenerateFn(generator.Generate)))
+
+	content, readErr := os.ReadFile(filepath.Join(dir, "internal", "roles", "editor.go"))
+	assert.NoError(t, readErr)
+	generated := string(content)
+
+	assert.Contains(t, generated, "func (r *Editor) IsInheritRole() bool")
+	assert.Contains(t, generated, "return []raiden.Role{ raiden.WithAdminOption(&Author{}) }")
+}
@@ -34,6 +34,10 @@ type (
 		Controller string
 		Model      string
 		Storage    string
+
+		// controller struct name and source file, used by other generator like client sdk
+		Name string
+		File string
 	}
 
 	GenerateRouterData struct {
@@ -49,6 +53,7 @@ type (
 		}
 		Package string
 		Name    string
+		File    string
 		Type    string
 		Path    string
 		Methods []string
@@ -239,6 +244,7 @@ func getRoutes(mode raiden.Mode, controllerPath, controllerType, routePath strin
 					foundRoute := &FoundRoute{
 						Type: controllerType,
 						Path: routePath,
+						File: controllerPath,
 					}
 					if fr, exist := foundRouteMap[routePath]; exist {
 						foundRoute = fr
@@ -372,6 +378,8 @@ func BuildRouteItem(mode raiden.Mode, foundRoute *FoundRoute) (GenerateRouteItem
 	r.Controller = fmt.Sprintf("%s.%s{}", foundRoute.Package, foundRoute.Name)
 	r.Model = foundRoute.Model
 	r.Storage = foundRoute.Storage
+	r.Name = foundRoute.Name
+	r.File = foundRoute.File
 	r.Type = foundRoute.Type
 	r.Path = fmt.Sprintf("%q", foundRoute.Path)
 	r.Methods = GenerateArrayDeclaration(reflect.ValueOf(foundRoute.Methods), true)
@@ -35,6 +35,7 @@ type (
 	ExtractRpcDataResult struct {
 		Rpc                raiden.RpcBase
 		MapScannedTable    map[string]*RpcScannedTable
+		MapDataType        map[string]objects.Type
 		OriginalReturnType string
 		UseParamPrefix     bool
 	}
@@ -150,6 +151,11 @@ func (r *{{ .Name }}) GetRawDefinition() string {
 )
 
 func GenerateRpc(basePath string, projectName string, functions []objects.Function, tables []objects.Table, generateFn GenerateFn) (err error) {
+	return GenerateRpcWithTypes(basePath, projectName, functions, tables, nil, generateFn)
+}
+
+// GenerateRpcWithTypes generate rpc and resolve user defined type (enum or composite) used by rpc from mapDataType
+func GenerateRpcWithTypes(basePath string, projectName string, functions []objects.Function, tables []objects.Table, mapDataType map[string]objects.Type, generateFn GenerateFn) (err error) {
 	folderPath := filepath.Join(basePath, RpcDir)
 	RpcLogger.Trace("create rpc folder if not exist", "path", folderPath)
 	if exist := utils.IsFolderExists(folderPath); !exist {
@@ -160,7 +166,7 @@ func GenerateRpc(basePath string, projectName string, functions []objects.Functi
 
 	for i := range functions {
 		f := functions[i]
-		if err := generateRpcItem(folderPath, projectName, &f, tables, generateFn); err != nil {
+		if err := generateRpcItem(folderPath, projectName, &f, tables, mapDataType, generateFn); err != nil {
 			return err
 		}
 	}
@@ -168,7 +174,7 @@ func GenerateRpc(basePath string, projectName string, functions []objects.Functi
 	return nil
 }
 
-func generateRpcItem(folderPath string, projectName string, function *objects.Function, tables []objects.Table, generateFn GenerateFn) error {
+func generateRpcItem(folderPath string, projectName string, function *objects.Function, tables []objects.Table, mapDataType map[string]objects.Type, generateFn GenerateFn) error {
 	// define binding func
 	funcMaps := []template.FuncMap{
 		{"ToSnakeCase": utils.ToSnakeCase},
@@ -185,7 +191,7 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 	filePath := filepath.Join(folderPath, fmt.Sprintf("%s.%s", utils.ToSnakeCase(function.Name), "go"))
 
 	// // extract rpc function
-	result, err := ExtractRpcFunction(function, tables)
+	result, err := ExtractRpcFunctionWithTypes(function, tables, mapDataType)
 	if err != nil {
 		return err
 	}
@@ -200,7 +206,7 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 		return err
 	}
 
-	returnTypeDecl, err := raiden.GetValidRpcReturnNameDecl(result.Rpc.ReturnType, true)
+	returnTypeDecl, err := result.GetReturnTypeDecl()
 	if err != nil {
 		return err
 	}
@@ -211,6 +217,11 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 		importsMap[modePath] = true
 	}
 
+	if result.HasUserDefinedType() {
+		typesImportPath := fmt.Sprintf("%s/%s", utils.ToGoModuleName(projectName), TypeDir)
+		importsMap[fmt.Sprintf("%q", typesImportPath)] = true
+	}
+
 	var importsPath []string
 	for key := range importsMap {
 		importsPath = append(importsPath, key)
@@ -251,8 +262,13 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 }
 
 func ExtractRpcFunction(fn *objects.Function, tables []objects.Table) (result ExtractRpcDataResult, err error) {
+	return ExtractRpcFunctionWithTypes(fn, tables, nil)
+}
+
+// ExtractRpcFunctionWithTypes extract rpc function and resolve user defined type from mapDataType
+func ExtractRpcFunctionWithTypes(fn *objects.Function, tables []objects.Table, mapDataType map[string]objects.Type) (result ExtractRpcDataResult, err error) {
 	//  extract param
-	params, usePrefix, e := ExtractRpcParam(fn)
+	params, usePrefix, e := ExtractRpcParamWithTypes(fn, mapDataType)
 	if e != nil {
 		err = e
 		return
@@ -300,6 +316,8 @@ func ExtractRpcFunction(fn *objects.Function, tables []objects.Table) (result Ex
 		returnType = raiden.RpcReturnDataTypeSetOf
 	} else if strings.Contains(returnTypeLc, "table") {
 		returnType = raiden.RpcReturnDataTypeTable
+	} else if _, _, isUserType := findRpcUserDataType(fn.ReturnType, mapDataType); isUserType {
+		returnType = raiden.RpcReturnDataType(returnTypeLc)
 	} else {
 		returnType, err = raiden.GetValidRpcReturnType(fn.ReturnType, true)
 		if err != nil {
@@ -320,12 +338,18 @@ func ExtractRpcFunction(fn *objects.Function, tables []objects.Table) (result Ex
 
 	result.OriginalReturnType = fn.ReturnType
 	result.MapScannedTable = mapScannedTable
+	result.MapDataType = mapDataType
 	result.UseParamPrefix = usePrefix
 
 	return
 }
 
 func ExtractRpcParam(fn *objects.Function) (params []raiden.RpcParam, usePrefix bool, err error) {
+	return ExtractRpcParamWithTypes(fn, nil)
+}
+
+// ExtractRpcParamWithTypes extract rpc param and resolve user defined type from mapDataType
+func ExtractRpcParamWithTypes(fn *objects.Function, mapDataType map[string]objects.Type) (params []raiden.RpcParam, usePrefix bool, err error) {
 	mapParam := make(map[string]string)
 
 	// bind param to map
@@ -403,12 +427,16 @@ func ExtractRpcParam(fn *objects.Function) (params []raiden.RpcParam, usePrefix
 		// get data type
 		if pt, isParamExist := mapParam[fa.Name]; isParamExist {
 			pt = strings.TrimLeft(strings.TrimRight(pt, " "), " ")
-			paramType, err := raiden.GetValidRpcParamType(pt, true)
-			if err != nil {
-				err = fmt.Errorf("got error in rpc '%s' return param type > %s", fn.Name, err.Error())
-				return params, usePrefix, fmt.Errorf("%s %s", fa.Name, err.Error())
+			if _, _, isUserType := findRpcUserDataType(pt, mapDataType); isUserType {
+				p.Type = raiden.RpcParamDataType(strings.ToLower(pt))
+			} else {
+				paramType, err := raiden.GetValidRpcParamType(pt, true)
+				if err != nil {
+					err = fmt.Errorf("got error in rpc '%s' return param type > %s", fn.Name, err.Error())
+					return params, usePrefix, fmt.Errorf("%s %s", fa.Name, err.Error())
+				}
+				p.Type = paramType
 			}
-			p.Type = paramType
 		}
 
 		// get default value
@@ -701,7 +729,7 @@ func (r *ExtractRpcDataResult) GetParams(mapImports map[string]bool) (columns []
 		}
 
 		// Determine if the type should be a pointer based on having a default value
-		goType := raiden.RpcParamToGoType(p.Type)
+		goType := r.paramToGoType(p.Type)
 		hasDefault := p.Default != nil
 
 		// Make it a pointer type if it has any default value
@@ -737,8 +765,12 @@ func (r *ExtractRpcDataResult) GetParams(mapImports map[string]bool) (columns []
 			case "postgres":
 				importPackageName = "github.com/sev-2/raiden/pkg/postgres"
 			}
-			key := fmt.Sprintf("%q", importPackageName)
-			mapImports[key] = true
+
+			// types package import is resolved by project name in generateRpcItem
+			if importPackageName != "" {
+				key := fmt.Sprintf("%q", importPackageName)
+				mapImports[key] = true
+			}
 		}
 
 		columns = append(columns, c)
@@ -780,6 +812,12 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 		}
 
 		tableName := split[1]
+		if dataType, _, isUserType := findRpcUserDataType(tableName, r.MapDataType); isUserType {
+			isReturnArr = true
+			returnDecl = fmt.Sprintf("types.%s", utils.SnakeCaseToPascalCase(dataType.Name))
+			return
+		}
+
 		_, isExist := r.MapScannedTable[tableName]
 		if !isExist {
 			err = fmt.Errorf("table %s is not declare in definition function of rpc %s", tableName, r.Rpc.Name)
@@ -798,10 +836,16 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 				continue
 			}
 			cName := splitC[0]
-			cType, e := raiden.GetValidRpcParamType(splitC[1], true)
-			if e != nil {
-				err = fmt.Errorf("got error in rpc '%s' return table in column %s > %s", r.Rpc.Name, splitC[0], e.Error())
-				return
+			var cType raiden.RpcParamDataType
+			if _, _, isUserType := findRpcUserDataType(splitC[1], r.MapDataType); isUserType {
+				cType = raiden.RpcParamDataType(strings.TrimSpace(splitC[1]))
+			} else {
+				validType, e := raiden.GetValidRpcParamType(splitC[1], true)
+				if e != nil {
+					err = fmt.Errorf("got error in rpc '%s' return table in column %s > %s", r.Rpc.Name, splitC[0], e.Error())
+					return
+				}
+				cType = validType
 			}
 
 			tag := raiden.RpcParamTag{
@@ -817,7 +861,7 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 
 			c := RpcColumn{
 				Field: utils.SnakeCaseToPascalCase(cName),
-				Type:  raiden.RpcParamToGoType(cType),
+				Type:  r.paramToGoType(cType),
 				Tag:   fmt.Sprintf("json:%q column:%q", cName, rpcTag),
 			}
 
@@ -845,8 +889,11 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 				case "postgres":
 					importPackageName = "github.com/sev-2/raiden/pkg/postgres"
 				}
-				key := fmt.Sprintf("%q", importPackageName)
-				mapImports[key] = true
+
+				if importPackageName != "" {
+					key := fmt.Sprintf("%q", importPackageName)
+					mapImports[key] = true
+				}
 			}
 
 			isReturnArr = true
@@ -855,6 +902,14 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 
 	default:
 		isReturnArr = false
+		if dataType, isArray, isUserType := findRpcUserDataType(r.OriginalReturnType, r.MapDataType); isUserType {
+			returnDecl = fmt.Sprintf("types.%s", utils.SnakeCaseToPascalCase(dataType.Name))
+			if isArray {
+				returnDecl = "[]" + returnDecl
+			}
+			return
+		}
+
 		returnName := strings.ToUpper(string(r.OriginalReturnType))
 		returnDecl = raiden.RpcReturnToGoType(raiden.RpcReturnDataType(returnName))
 
@@ -873,6 +928,92 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 	return
 }
 
+// GetReturnTypeDecl return constant name of rpc return type,
+// user defined type and type with precision doesn't have constant so it declared as literal
+func (r *ExtractRpcDataResult) GetReturnTypeDecl() (string, error) {
+	literal := fmt.Sprintf("RpcReturnDataType(%q)", strings.ToLower(string(r.Rpc.ReturnType)))
+	if _, _, isUserType := findRpcUserDataType(string(r.Rpc.ReturnType), r.MapDataType); isUserType {
+		return literal, nil
+	}
+
+	// validate base type of precision type, ex : NUMERIC(10,2) or VARCHAR(255)
+	baseType, modifier := raiden.SplitRpcTypeModifier(string(r.Rpc.ReturnType))
+	if modifier == "" {
+		return raiden.GetValidRpcReturnNameDecl(r.Rpc.ReturnType, true)
+	}
+
+	if _, err := raiden.GetValidRpcReturnNameDecl(raiden.RpcReturnDataType(strings.ToUpper(baseType)), true); err != nil {
+		return "", err
+	}
+	return literal, nil
+}
+
+// HasUserDefinedType check if params or return type use user defined type (enum or composite)
+func (r *ExtractRpcDataResult) HasUserDefinedType() bool {
+	if len(r.MapDataType) == 0 {
+		return false
+	}
+
+	for _, p := range r.Rpc.Params {
+		if _, _, isUserType := findRpcUserDataType(string(p.Type), r.MapDataType); isUserType {
+			return true
+		}
+	}
+
+	returnType := strings.ToLower(r.OriginalReturnType)
+	switch r.Rpc.ReturnType {
+	case raiden.RpcReturnDataTypeSetOf:
+		returnType = strings.TrimSpace(strings.TrimPrefix(returnType, "setof"))
+	case raiden.RpcReturnDataTypeTable:
+		rsType := strings.TrimRight(strings.ReplaceAll(returnType, "table(", ""), ")")
+		for _, v := range strings.Split(rsType, ",") {
+			splitC := strings.SplitN(strings.TrimSpace(v), " ", 2)
+			if len(splitC) != 2 {
+				continue
+			}
+			if _, _, isUserType := findRpcUserDataType(splitC[1], r.MapDataType); isUserType {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, _, isUserType := findRpcUserDataType(returnType, r.MapDataType)
+	return isUserType
+}
+
+func (r *ExtractRpcDataResult) paramToGoType(pType raiden.RpcParamDataType) string {
+	if dataType, isArray, isUserType := findRpcUserDataType(string(pType), r.MapDataType); isUserType {
+		goType := fmt.Sprintf("types.%s", utils.SnakeCaseToPascalCase(dataType.Name))
+		if isArray {
+			goType = "[]" + goType
+		}
+		return goType
+	}
+	return raiden.RpcParamToGoType(pType)
+}
+
+// findRpcUserDataType find user defined type from rpc type declaration,
+// ex : status, public.status or status[]
+func findRpcUserDataType(pType string, mapDataType map[string]objects.Type) (dataType objects.Type, isArray bool, found bool) {
+	if len(mapDataType) == 0 {
+		return
+	}
+
+	name := strings.TrimSpace(pType)
+	if strings.HasSuffix(name, "[]") {
+		isArray = true
+		name = strings.TrimSuffix(name, "[]")
+	}
+
+	if split := strings.Split(name, "."); len(split) == 2 {
+		name = split[1]
+	}
+
+	dataType, found = mapDataType[strings.Trim(name, `"`)]
+	return
+}
+
 func (r *ExtractRpcDataResult) GetSecurity() (security string) {
 	switch r.Rpc.SecurityType {
 	case raiden.RpcSecurityTypeDefiner:
@@ -1068,6 +1068,116 @@ func TestGetParams_WithImports(t *testing.T) {
 	assert.True(t, importsMap[`"github.com/google/uuid"`])
 }
 
+func TestExtractRpcFunction_WithUserDefinedType(t *testing.T) {
+	mapDataType := map[string]objects.Type{
+		"order_status": {Name: "order_status", Schema: "public", Format: "", Enums: []string{"pending", "paid"}},
+		"address":      {Name: "address", Schema: "billing"},
+	}
+
+	fn := objects.Function{
+		Schema:   "public",
+		Name:     "get_order_statuses",
+		Language: "plpgsql",
+		Args: []objects.FunctionArg{
+			{Mode: "in", Name: "in_status", HasDefault: true},
+			{Mode: "in", Name: "in_statuses"},
+			{Mode: "in", Name: "in_flags"},
+			{Mode: "in", Name: "in_addresses"},
+		},
+		ArgumentTypes: "in_status order_status DEFAULT 'pending'::order_status, in_statuses order_status[], in_flags boolean[], in_addresses billing.address[]",
+		ReturnType:    "order_status[]",
+		Definition:    "BEGIN RETURN ARRAY[in_status]; END;",
+	}
+
+	result, err := generator.ExtractRpcFunctionWithTypes(&fn, []objects.Table{}, mapDataType)
+	assert.NoError(t, err)
+	assert.True(t, result.HasUserDefinedType())
+	assert.Equal(t, raiden.RpcReturnDataType("order_status[]"), result.Rpc.ReturnType)
+
+	importsMap := map[string]bool{}
+	columns, err := result.GetParams(importsMap)
+	assert.NoError(t, err)
+	assert.Len(t, columns, 4)
+	assert.Equal(t, "*types.OrderStatus", columns[0].Type)
+	assert.Equal(t, `json:"status" column:"name:status;type:order_status;default:pending"`, columns[0].Tag)
+	assert.Equal(t, "[]types.OrderStatus", columns[1].Type)
+	assert.Equal(t, "[]bool", columns[2].Type)
+	assert.Equal(t, "[]types.Address", columns[3].Type)
+	assert.NotContains(t, importsMap, `""`)
+
+	returnDecl, _, isReturnArr, err := result.GetReturn(importsMap)
+	assert.NoError(t, err)
+	assert.False(t, isReturnArr)
+	assert.Equal(t, "[]types.OrderStatus", returnDecl)
+
+	returnTypeDecl, err := result.GetReturnTypeDecl()
+	assert.NoError(t, err)
+	assert.Equal(t, `RpcReturnDataType("order_status[]")`, returnTypeDecl)
+}
+
+func TestGetReturnTypeDecl_PrecisionType(t *testing.T) {
+	for returnType, expected := range map[string]string{
+		"numeric(10,2)":          `RpcReturnDataType("numeric(10,2)")`,
+		"character varying(255)": `RpcReturnDataType("varchar(255)")`,
+		"numeric(10,2)[]":        `RpcReturnDataType("numeric(10,2)[]")`,
+	} {
+		fn := objects.Function{
+			Schema:            "public",
+			Name:              "get_amount",
+			Language:          "sql",
+			ReturnType:        returnType,
+			Definition:        "SELECT 1",
+			CompleteStatement: "CREATE OR REPLACE FUNCTION public.get_amount() RETURNS " + returnType + " LANGUAGE sql AS $function$ SELECT 1 $function$",
+		}
+
+		result, err := generator.ExtractRpcFunction(&fn, []objects.Table{})
+		assert.NoError(t, err)
+
+		returnTypeDecl, err := result.GetReturnTypeDecl()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, returnTypeDecl)
+	}
+
+	result := generator.ExtractRpcDataResult{Rpc: raiden.RpcBase{ReturnType: raiden.RpcReturnDataType("UNKNOWN(10)")}}
+	_, err := result.GetReturnTypeDecl()
+	assert.Error(t, err)
+}
+
+func TestGenerateRpc_WithUserDefinedType(t *testing.T) {
+	mapDataType := map[string]objects.Type{
+		"order_status": {Name: "order_status", Schema: "public", Enums: []string{"pending", "paid"}},
+	}
+
+	fn := objects.Function{
+		Schema:        "public",
+		Name:          "count_by_status",
+		Language:      "plpgsql",
+		Args:          []objects.FunctionArg{{Mode: "in", Name: "status"}},
+		ArgumentTypes: "status order_status",
+		ReturnType:    "TABLE(status order_status, total numeric)",
+		Definition:    "BEGIN RETURN QUERY SELECT status, 1::numeric; END;",
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "internal"), 0o755))
+
+	var generated string
+	custom := generator.GenerateFn(func(input generator.GenerateInput, writer io.Writer) error {
+		buf := &bytes.Buffer{}
+		if err := generator.Generate(input, buf); err != nil {
+			return err
+		}
+		generated = buf.String()
+		return nil
+	})
+
+	err := generator.GenerateRpcWithTypes(dir, "test_project", []objects.Function{fn}, nil, mapDataType, custom)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, `"testproject/internal/types"`)
+	assert.Contains(t, generated, "Status types.OrderStatus `json:\"status\" column:\"name:status;type:order_status\"`")
+	assert.Contains(t, generated, "Total float64")
+}
+
 func generateRPCOutput(t *testing.T, projectName string, fn objects.Function) string {
 	t.Helper()
 	dir := t.TempDir()
@@ -0,0 +1,612 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var RpcValidateLogger hclog.Logger = logger.HcLog().Named("generator.rpc_validate")
+
+// ----- Define type, variable and constant -----
+type (
+	RpcValidationSeverity string
+
+	RpcValidationIssue struct {
+		File     string
+		Line     int
+		Rpc      string
+		Severity RpcValidationSeverity
+		Message  string
+	}
+
+	// rpcValidateDecl hold all information of rpc struct collected from source code
+	rpcValidateDecl struct {
+		Name           string
+		Pos            token.Position
+		ParamsType     ast.Expr
+		ReturnType     ast.Expr
+		ReturnTypeDecl string
+		ReturnTypePos  token.Position
+		Definition     *string
+		DefinitionPos  token.Position
+		ModelAliases   map[string]bool
+	}
+
+	rpcValidateParam struct {
+		Name string
+		Pos  token.Position
+	}
+)
+
+const (
+	RpcValidationSeverityError   RpcValidationSeverity = "error"
+	RpcValidationSeverityWarning RpcValidationSeverity = "warning"
+
+	rpcReturnCategoryRows   = "rows"
+	rpcReturnCategoryNumber = "number"
+	rpcReturnCategoryString = "string"
+	rpcReturnCategoryBool   = "bool"
+	rpcReturnCategoryArray  = "array"
+)
+
+// ErrInvalidRpcDefinition is returned by ValidateRpc when at least one rpc has error issue
+var ErrInvalidRpcDefinition = errors.New("invalid rpc definition")
+
+var (
+	rpcDollarQuoteRegex = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+	rpcIdentifierRegex  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+func (i RpcValidationIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s: rpc %s %s", i.File, i.Line, i.Severity, i.Rpc, i.Message)
+}
+
+// ValidateRpc check all rpc definition in project before register and build,
+// warning is only logged and error issue will stop the process
+func ValidateRpc(basePath string) error {
+	rpcDir := filepath.Join(basePath, RpcDir)
+	if !utils.IsFolderExists(rpcDir) {
+		return nil
+	}
+
+	issues, err := WalkValidateRpc(rpcDir)
+	if err != nil {
+		return err
+	}
+
+	var errMessages []string
+	for _, issue := range issues {
+		if relPath, e := filepath.Rel(basePath, issue.File); e == nil {
+			issue.File = relPath
+		}
+
+		if issue.Severity == RpcValidationSeverityWarning {
+			RpcValidateLogger.Warn(issue.String())
+			continue
+		}
+		errMessages = append(errMessages, issue.String())
+	}
+
+	if len(errMessages) > 0 {
+		return fmt.Errorf("%w :\n%s", ErrInvalidRpcDefinition, strings.Join(errMessages, "\n"))
+	}
+	return nil
+}
+
+// WalkValidateRpc scan rpc source code in rpc directory and return all founded issue
+func WalkValidateRpc(rpcDir string) ([]RpcValidationIssue, error) {
+	RpcValidateLogger.Trace("validate all rpc", "path", rpcDir)
+
+	mapDirFiles := make(map[string][]string)
+	err := filepath.Walk(rpcDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			dir := filepath.Dir(path)
+			mapDirFiles[dir] = append(mapDirFiles[dir], path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(mapDirFiles))
+	for dir := range mapDirFiles {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var issues []RpcValidationIssue
+	for _, dir := range dirs {
+		dirIssues, err := validateRpcPackage(mapDirFiles[dir])
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, dirIssues...)
+	}
+
+	return issues, nil
+}
+
+func validateRpcPackage(files []string) (issues []RpcValidationIssue, err error) {
+	fset := token.NewFileSet()
+	mapTypes := make(map[string]*ast.TypeSpec)
+	mapRpc := make(map[string]*rpcValidateDecl)
+	var methods []*ast.FuncDecl
+
+	sort.Strings(files)
+	for _, f := range files {
+		file, e := parser.ParseFile(fset, f, nil, parser.ParseComments)
+		if e != nil {
+			return nil, e
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range d.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					mapTypes[typeSpec.Name.Name] = typeSpec
+
+					st, ok := typeSpec.Type.(*ast.StructType)
+					if !ok || !isEmbedRpcBase(st) {
+						continue
+					}
+
+					rpcDecl := &rpcValidateDecl{
+						Name:         typeSpec.Name.Name,
+						Pos:          fset.Position(typeSpec.Pos()),
+						ModelAliases: make(map[string]bool),
+					}
+
+					for _, field := range st.Fields.List {
+						for _, n := range field.Names {
+							switch n.Name {
+							case "Params":
+								rpcDecl.ParamsType = field.Type
+							case "Return":
+								rpcDecl.ReturnType = field.Type
+							}
+						}
+					}
+					mapRpc[rpcDecl.Name] = rpcDecl
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) > 0 && d.Body != nil {
+					methods = append(methods, d)
+				}
+			}
+		}
+	}
+
+	for _, m := range methods {
+		rpcDecl, exist := mapRpc[getReceiverName(m.Recv.List[0].Type)]
+		if !exist {
+			continue
+		}
+
+		switch m.Name.Name {
+		case "GetRawDefinition":
+			if lit := findReturnStringLiteral(m.Body); lit != nil {
+				if value, e := strconv.Unquote(lit.Value); e == nil {
+					rpcDecl.Definition = &value
+					rpcDecl.DefinitionPos = fset.Position(lit.Pos())
+				}
+			}
+		case "GetReturnType":
+			if expr := findReturnExpr(m.Body); expr != nil {
+				if se, ok := expr.(*ast.SelectorExpr); ok {
+					rpcDecl.ReturnTypeDecl = se.Sel.Name
+				}
+				rpcDecl.ReturnTypePos = fset.Position(expr.Pos())
+			}
+		case "BindModels":
+			ast.Inspect(m.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				se, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || se.Sel.Name != "BindModel" || len(call.Args) != 2 {
+					return true
+				}
+
+				if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if alias, e := strconv.Unquote(lit.Value); e == nil {
+						rpcDecl.ModelAliases[alias] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	names := make([]string, 0, len(mapRpc))
+	for name := range mapRpc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		issues = append(issues, validateRpcDecl(mapRpc[name], mapTypes, fset)...)
+	}
+	return issues, nil
+}
+
+func validateRpcDecl(rpcDecl *rpcValidateDecl, mapTypes map[string]*ast.TypeSpec, fset *token.FileSet) (issues []RpcValidationIssue) {
+	addIssue := func(pos token.Position, severity RpcValidationSeverity, format string, args ...any) {
+		issues = append(issues, RpcValidationIssue{
+			File:     pos.Filename,
+			Line:     pos.Line,
+			Rpc:      rpcDecl.Name,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if rpcDecl.ParamsType == nil {
+		addIssue(rpcDecl.Pos, RpcValidationSeverityError, "doesn't have Params field")
+	}
+
+	if rpcDecl.ReturnType == nil {
+		addIssue(rpcDecl.Pos, RpcValidationSeverityError, "doesn't have Return field")
+	}
+
+	// collect params
+	var params []rpcValidateParam
+	if rpcDecl.ParamsType != nil {
+		if st := resolveRpcStruct(rpcDecl.ParamsType, mapTypes); st != nil {
+			params = collectRpcValidateParams(st, fset)
+		}
+	}
+
+	// validate return type
+	if rpcDecl.ReturnType != nil && rpcDecl.ReturnTypeDecl != "" {
+		expected := getRpcReturnCategory(rpcDecl.ReturnTypeDecl)
+		actual := getRpcGoTypeCategory(rpcDecl.ReturnType, mapTypes)
+		if expected == rpcReturnCategoryRows && actual != "" && actual != rpcReturnCategoryRows {
+			addIssue(rpcDecl.ReturnTypePos, RpcValidationSeverityError, "return type %s require Return field to be slice of struct", rpcDecl.ReturnTypeDecl)
+		} else if expected != "" && expected != rpcReturnCategoryRows && actual != "" && expected != actual {
+			addIssue(rpcDecl.ReturnTypePos, RpcValidationSeverityError, "return type %s doesn't match with Return field type %s", rpcDecl.ReturnTypeDecl, exprToString(rpcDecl.ReturnType))
+		}
+	}
+
+	if rpcDecl.Definition == nil {
+		return
+	}
+
+	definition := *rpcDecl.Definition
+	pos := rpcDecl.DefinitionPos
+
+	// validate dollar quoting
+	mapQuoteCount := make(map[string]int)
+	var quoteOrder []string
+	for _, q := range rpcDollarQuoteRegex.FindAllString(definition, -1) {
+		if _, exist := mapQuoteCount[q]; !exist {
+			quoteOrder = append(quoteOrder, q)
+		}
+		mapQuoteCount[q]++
+	}
+
+	for _, q := range quoteOrder {
+		if q == "$function$" {
+			addIssue(pos, RpcValidationSeverityError, "definition can't use %s quote, it is reserved for function body", q)
+			continue
+		}
+
+		if mapQuoteCount[q]%2 != 0 {
+			addIssue(pos, RpcValidationSeverityError, "definition has unbalanced dollar quote %s", q)
+		}
+	}
+
+	// validate placeholder
+	mapParam := make(map[string]bool)
+	for _, p := range params {
+		mapParam[p.Name] = true
+	}
+
+	mapUsed := make(map[string]bool)
+	for _, placeholder := range findRpcPlaceholders(definition) {
+		if mapUsed[placeholder] {
+			continue
+		}
+		mapUsed[placeholder] = true
+
+		if !mapParam[placeholder] && !rpcDecl.ModelAliases[placeholder] {
+			addIssue(pos, RpcValidationSeverityError, "placeholder :%s has no matching Params field or model alias bound by BindModel", placeholder)
+		}
+	}
+
+	for _, p := range params {
+		if !mapUsed[p.Name] {
+			addIssue(p.Pos, RpcValidationSeverityWarning, "param %s is never used in definition", p.Name)
+		}
+	}
+
+	aliases := make([]string, 0, len(rpcDecl.ModelAliases))
+	for alias := range rpcDecl.ModelAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		if !mapUsed[alias] {
+			addIssue(pos, RpcValidationSeverityWarning, "model alias :%s is bound but never used in definition", alias)
+		}
+	}
+
+	return
+}
+
+// findRpcPlaceholders return all :name token in definition,
+// string literal, comment and type cast (::type) is ignored
+func findRpcPlaceholders(definition string) (placeholders []string) {
+	cleanDef := stripRpcLiteralAndComment(definition)
+	for i := 0; i < len(cleanDef); i++ {
+		if cleanDef[i] != ':' {
+			continue
+		}
+
+		// skip type cast and assignment
+		if i+1 < len(cleanDef) && (cleanDef[i+1] == ':' || cleanDef[i+1] == '=') {
+			i++
+			continue
+		}
+
+		if i > 0 {
+			prev := rune(cleanDef[i-1])
+			if prev == ':' || prev == ']' || prev == ')' || prev == '_' || (prev >= '0' && prev <= '9') ||
+				(prev >= 'a' && prev <= 'z') || (prev >= 'A' && prev <= 'Z') {
+				continue
+			}
+		}
+
+		name := rpcIdentifierRegex.FindString(cleanDef[i+1:])
+		if name == "" {
+			continue
+		}
+		placeholders = append(placeholders, name)
+		i += len(name)
+	}
+	return
+}
+
+func stripRpcLiteralAndComment(definition string) string {
+	var sb strings.Builder
+	inString, inLineComment, inBlockComment := false, false, false
+	for i := 0; i < len(definition); i++ {
+		c := definition[i]
+		switch {
+		case inLineComment:
+			if c == '\n' {
+				inLineComment = false
+				sb.WriteByte(c)
+			}
+		case inBlockComment:
+			if c == '*' && i+1 < len(definition) && definition[i+1] == '/' {
+				inBlockComment = false
+				i++
+			}
+		case inString:
+			if c == '\'' {
+				if i+1 < len(definition) && definition[i+1] == '\'' {
+					i++
+					continue
+				}
+				inString = false
+			}
+		case c == '\'':
+			inString = true
+			sb.WriteByte(' ')
+		case c == '-' && i+1 < len(definition) && definition[i+1] == '-':
+			inLineComment = true
+			i++
+		case c == '/' && i+1 < len(definition) && definition[i+1] == '*':
+			inBlockComment = true
+			i++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func collectRpcValidateParams(st *ast.StructType, fset *token.FileSet) (params []rpcValidateParam) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+
+		tagValue, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		columnTag := reflect.StructTag(tagValue).Get("column")
+		if columnTag == "" {
+			continue
+		}
+
+		name := utils.ToSnakeCase(field.Names[0].Name)
+		for _, part := range strings.Split(columnTag, ";") {
+			if strings.HasPrefix(part, "name:") {
+				name = strings.TrimPrefix(part, "name:")
+			}
+		}
+
+		params = append(params, rpcValidateParam{
+			Name: name,
+			Pos:  fset.Position(field.Pos()),
+		})
+	}
+	return
+}
+
+func resolveRpcStruct(expr ast.Expr, mapTypes map[string]*ast.TypeSpec) *ast.StructType {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return resolveRpcStruct(e.X, mapTypes)
+	case *ast.StructType:
+		return e
+	case *ast.Ident:
+		if ts, exist := mapTypes[e.Name]; exist {
+			return resolveRpcStruct(ts.Type, mapTypes)
+		}
+	}
+	return nil
+}
+
+// getRpcReturnCategory map return type constant to simple category for comparison
+func getRpcReturnCategory(returnDecl string) string {
+	name := strings.TrimPrefix(returnDecl, "RpcReturnDataType")
+	switch {
+	case name == "SetOf" || name == "Table":
+		return rpcReturnCategoryRows
+	case strings.HasPrefix(name, "ArrayOf"):
+		return rpcReturnCategoryArray
+	}
+
+	switch name {
+	case "Integer", "BigInt", "Real", "DoublePreci", "Numeric":
+		return rpcReturnCategoryNumber
+	case "Text", "Varchar", "VarcharAlias", "Interval", "Inet":
+		return rpcReturnCategoryString
+	case "Boolean":
+		return rpcReturnCategoryBool
+	}
+	return ""
+}
+
+// getRpcGoTypeCategory map Return field type to simple category, return empty string if unknown
+func getRpcGoTypeCategory(expr ast.Expr, mapTypes map[string]*ast.TypeSpec) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return getRpcGoTypeCategory(e.X, mapTypes)
+	case *ast.ArrayType:
+		if isRpcRowType(e.Elt, mapTypes) {
+			return rpcReturnCategoryRows
+		}
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return ""
+		}
+		return rpcReturnCategoryArray
+	case *ast.Ident:
+		switch e.Name {
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64":
+			return rpcReturnCategoryNumber
+		case "string":
+			return rpcReturnCategoryString
+		case "bool":
+			return rpcReturnCategoryBool
+		}
+
+		if ts, exist := mapTypes[e.Name]; exist {
+			if _, isStruct := ts.Type.(*ast.StructType); isStruct {
+				return ""
+			}
+			return getRpcGoTypeCategory(ts.Type, mapTypes)
+		}
+	}
+	return ""
+}
+
+func isRpcRowType(expr ast.Expr, mapTypes map[string]*ast.TypeSpec) bool {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return isRpcRowType(e.X, mapTypes)
+	case *ast.StructType:
+		return true
+	case *ast.SelectorExpr:
+		// model from other package, ex : models.Places
+		return e.Sel.Name != "UUID" && e.Sel.Name != "Date" && e.Sel.Name != "DateTime" && e.Sel.Name != "Point"
+	case *ast.Ident:
+		if ts, exist := mapTypes[e.Name]; exist {
+			_, isStruct := ts.Type.(*ast.StructType)
+			return isStruct
+		}
+	}
+	return false
+}
+
+func isEmbedRpcBase(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		if len(f.Names) > 0 {
+			continue
+		}
+
+		if se, ok := f.Type.(*ast.SelectorExpr); ok && se.Sel.Name == reflect.TypeOf(raiden.RpcBase{}).Name() {
+			return true
+		}
+	}
+	return false
+}
+
+func getReceiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return getReceiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func findReturnExpr(body *ast.BlockStmt) ast.Expr {
+	for _, stmt := range body.List {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			return ret.Results[0]
+		}
+	}
+	return nil
+}
+
+func findReturnStringLiteral(body *ast.BlockStmt) *ast.BasicLit {
+	if lit, ok := findReturnExpr(body).(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		return lit
+	}
+	return nil
+}
+
+func exprToString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + exprToString(e.X)
+	case *ast.ArrayType:
+		return "[]" + exprToString(e.Elt)
+	case *ast.SelectorExpr:
+		return exprToString(e.X) + "." + e.Sel.Name
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", exprToString(e.Key), exprToString(e.Value))
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package generator_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+const validRpcSource = `package rpc
+
+import "github.com/sev-2/raiden"
+
+type GetVotesParams struct {
+	CandidateName string ` + "`json:\"candidate_name\" column:\"name:candidate_name;type:varchar\"`" + `
+	MinTotal      int64  ` + "`json:\"min_total\" column:\"name:min_total;type:integer\"`" + `
+}
+
+type GetVotesItem struct {
+	Name  string ` + "`json:\"name\" column:\"name:name;type:varchar\"`" + `
+	Total int64  ` + "`json:\"total\" column:\"name:total;type:integer\"`" + `
+}
+
+type GetVotesResult []GetVotesItem
+
+type GetVotes struct {
+	raiden.RpcBase
+	Params *GetVotesParams ` + "`json:\"-\"`" + `
+	Return GetVotesResult  ` + "`json:\"-\"`" + `
+}
+
+func (r *GetVotes) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeTable
+}
+
+func (r *GetVotes) BindModels() {
+	r.BindModel(Candidate{}, "c")
+}
+
+func (r *GetVotes) GetRawDefinition() string {
+	return ` + "`" + `BEGIN
+	-- :ignored_in_comment
+	RETURN QUERY SELECT c.name, count(*)::bigint AS total FROM :c c
+	WHERE c.name = :candidate_name AND c.note <> 'at 10:30 :not_param'
+	GROUP BY c.name HAVING count(*) >= :min_total;
+END;` + "`" + `
+}
+`
+
+const invalidRpcSource = `package rpc
+
+import "github.com/sev-2/raiden"
+
+type BrokenParams struct {
+	Name   string ` + "`json:\"name\" column:\"name:name;type:varchar\"`" + `
+	Unused string ` + "`json:\"unused\" column:\"name:unused;type:varchar\"`" + `
+}
+
+type Broken struct {
+	raiden.RpcBase
+	Params *BrokenParams ` + "`json:\"-\"`" + `
+	Return string        ` + "`json:\"-\"`" + `
+}
+
+func (r *Broken) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeInteger
+}
+
+func (r *Broken) BindModels() {
+	r.BindModel(Candidate{}, "c")
+}
+
+func (r *Broken) GetRawDefinition() string {
+	return ` + "`" + `BEGIN EXECUTE $q$ SELECT 1 FROM :v v WHERE v.name = :nme; END;` + "`" + `
+}
+
+type BrokenSetOf struct {
+	raiden.RpcBase
+	Params *BrokenParams ` + "`json:\"-\"`" + `
+	Return string        ` + "`json:\"-\"`" + `
+}
+
+func (r *BrokenSetOf) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeSetOf
+}
+
+func (r *BrokenSetOf) GetRawDefinition() string {
+	return ` + "`" + `BEGIN RETURN QUERY SELECT :name, :unused; END;` + "`" + `
+}
+`
+
+func writeRpcSource(t *testing.T, basePath string, fileName string, source string) {
+	t.Helper()
+	rpcDir := filepath.Join(basePath, generator.RpcDir)
+	assert.NoError(t, os.MkdirAll(rpcDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(rpcDir, fileName), []byte(source), 0o644))
+}
+
+func TestWalkValidateRpc_Valid(t *testing.T) {
+	dir := t.TempDir()
+	writeRpcSource(t, dir, "get_votes.go", validRpcSource)
+
+	issues, err := generator.WalkValidateRpc(filepath.Join(dir, generator.RpcDir))
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+
+	assert.NoError(t, generator.ValidateRpc(dir))
+}
+
+func TestWalkValidateRpc_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeRpcSource(t, dir, "broken.go", invalidRpcSource)
+
+	issues, err := generator.WalkValidateRpc(filepath.Join(dir, generator.RpcDir))
+	assert.NoError(t, err)
+
+	var messages []string
+	for _, issue := range issues {
+		assert.True(t, strings.HasSuffix(issue.File, "broken.go"))
+		assert.Greater(t, issue.Line, 0)
+		messages = append(messages, string(issue.Severity)+" "+issue.Rpc+" "+issue.Message)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"error Broken return type RpcReturnDataTypeInteger doesn't match with Return field type string",
+		"error Broken definition has unbalanced dollar quote $q$",
+		"error Broken placeholder :v has no matching Params field or model alias bound by BindModel",
+		"error Broken placeholder :nme has no matching Params field or model alias bound by BindModel",
+		"warning Broken param name is never used in definition",
+		"warning Broken param unused is never used in definition",
+		"warning Broken model alias :c is bound but never used in definition",
+		"error BrokenSetOf return type RpcReturnDataTypeSetOf require Return field to be slice of struct",
+	}, messages)
+}
+
+func TestValidateRpc_ReturnErrorWithSourceFile(t *testing.T) {
+	dir := t.TempDir()
+	writeRpcSource(t, dir, "broken.go", invalidRpcSource)
+
+	err := generator.ValidateRpc(dir)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, generator.ErrInvalidRpcDefinition))
+	assert.Contains(t, err.Error(), filepath.Join(generator.RpcDir, "broken.go")+":")
+	assert.NotContains(t, err.Error(), "never used")
+}
+
+func TestValidateRpc_NoRpcFolder(t *testing.T) {
+	assert.NoError(t, generator.ValidateRpc(t.TempDir()))
+}
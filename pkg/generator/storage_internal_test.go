@@ -42,3 +42,19 @@ func TestGenerateStorageDataBinding(t *testing.T) {
 	require.Contains(t, data.AllowedMimeTypes, "image/jpeg")
 	require.Contains(t, data.Imports, "\"github.com/sev-2/raiden\"")
 }
+
+func TestBuildStorageAclInfo_StorageHelper(t *testing.T) {
+	check := "((bucket_id = 'avatars'::text) AND ((storage.foldername(name))[1] = (auth.uid())::text) AND (storage.extension(name) = 'png'::text))"
+	policies := objects.Policies{
+		{Name: "avatar_read", Command: objects.PolicyCommandSelect, Action: "PERMISSIVE", Definition: "((bucket_id = 'avatars'::text) AND ((storage.foldername(name))[1] = (auth.uid())::text))"},
+		{Name: "avatar_upload", Command: objects.PolicyCommandInsert, Action: "PERMISSIVE", Check: &check},
+		{Name: "avatar_delete", Command: objects.PolicyCommandDelete, Action: "PERMISSIVE", Definition: "((bucket_id = 'avatars'::text) AND (owner_id = (auth.uid())::text))"},
+	}
+
+	info, err := buildStorageAclInfo("Avatars", "a", objects.Bucket{Name: "avatars"}, policies, nil, nil)
+	require.NoError(t, err)
+	require.True(t, info.UseBuilder)
+	require.Contains(t, info.Body, "st.StorageUsingClause(a.Name(), st.StorageFolderIsAuth(1))")
+	require.Contains(t, info.Body, "st.StorageCheckClause(a.Name(), st.And(st.StorageFolderIsAuth(1), st.StorageExtensionIs(\"png\")))")
+	require.Contains(t, info.Body, "st.StorageUsingClause(a.Name(), st.StorageOwnerIsAuth())")
+}
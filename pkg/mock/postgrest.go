@@ -0,0 +1,743 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sev-2/raiden"
+	"github.com/valyala/fasthttp"
+)
+
+// MockPostgrest is in-process fake of postgrest backed by in-memory table,
+// table is registered from raiden model and relation is resolved from join tag.
+// It implement subset of postgrest that emitted by pkg/db :
+//   - select with column, alias, json path and embedded resource
+//   - filter eq, neq, gt, gte, lt, lte, like, ilike, match, imatch, in, is, cs, cd, ov, `not.` and `and` / `or` group
+//   - order, limit and offset, including embedded resource
+//   - insert, upsert (on_conflict and resolution), update and delete
+//   - `return`, `count` and object accept header
+//
+// example :
+//
+//	pg := mock.NewMockPostgrest(&models.Users{}, &models.Articles{})
+//	pg.Seed(models.Users{Id: 1, Username: "john"})
+//
+//	// route pkg/db request to emulator, GetClientFn is global so test using it can't run in parallel
+//	db.GetClientFn = func() db.Client { return pg }
+//	defer func() { db.GetClientFn = db.DefaultGetClientFn }()
+//
+//	// route rest controller request to emulator
+//	restore := raiden.SetRestProxyDoTimeout(pg.DoTimeout)
+//	defer restore()
+type MockPostgrest struct {
+	mu     sync.Mutex
+	tables map[string]*mockTable
+}
+
+type mockTable struct {
+	name      string
+	columns   []mockColumn
+	relations []mockRelation
+	rows      []map[string]any
+	sequence  int64
+}
+
+type mockColumn struct {
+	name          string
+	primaryKey    bool
+	autoIncrement bool
+	defaultValue  *string
+}
+
+type mockRelation struct {
+	alias string
+	table string
+	join  raiden.JoinTag
+}
+
+// MockPostgrestError is error response of postgrest
+type MockPostgrestError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	Hint    string `json:"hint"`
+}
+
+func (e *MockPostgrestError) Error() string {
+	return e.Message
+}
+
+func newMockPostgrestError(status int, code string, format string, args ...any) *MockPostgrestError {
+	return &MockPostgrestError{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func NewMockPostgrest(models ...any) *MockPostgrest {
+	m := &MockPostgrest{tables: make(map[string]*mockTable)}
+	m.Register(models...)
+	return m
+}
+
+// Register create empty table for every model and the related model
+func (m *MockPostgrest) Register(models ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, model := range models {
+		m.register(reflect.TypeOf(model))
+	}
+}
+
+// Seed insert model as row, value can be model, pointer to model or slice of model,
+// zero value of auto increment and default column is filled like real insert
+func (m *MockPostgrest) Seed(values ...any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, value := range values {
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+
+		items := []reflect.Value{rv}
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			items = items[:0]
+			for i := 0; i < rv.Len(); i++ {
+				item := rv.Index(i)
+				for item.Kind() == reflect.Ptr {
+					item = item.Elem()
+				}
+				items = append(items, item)
+			}
+		}
+
+		for _, item := range items {
+			if item.Kind() != reflect.Struct {
+				return fmt.Errorf("seed : expected model, got %s", item.Kind())
+			}
+
+			t := m.register(item.Type())
+			row, err := t.newRow(modelToRow(item), true)
+			if err != nil {
+				return err
+			}
+			t.rows = append(t.rows, row)
+		}
+	}
+
+	return nil
+}
+
+// Rows return copy of all row in table, it can be used to assert result of mutation
+func (m *MockPostgrest) Rows(table string) []map[string]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tables[table]
+	if !ok {
+		return nil
+	}
+
+	rows := make([]map[string]any, 0, len(t.rows))
+	for _, r := range t.rows {
+		rows = append(rows, copyRow(r))
+	}
+	return rows
+}
+
+// Reset delete all row and restart sequence, registered table is kept
+func (m *MockPostgrest) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, t := range m.tables {
+		t.rows = nil
+		t.sequence = 0
+	}
+}
+
+// Do serve request in process, it satisfy http client used by pkg/db and pkg/client
+func (m *MockPostgrest) Do(req *fasthttp.Request, res *fasthttp.Response) error {
+	var ctx fasthttp.RequestCtx
+	ctx.Init(req, nil, nil)
+	m.Handler(&ctx)
+	ctx.Response.CopyTo(res)
+	return nil
+}
+
+func (m *MockPostgrest) DoTimeout(req *fasthttp.Request, res *fasthttp.Response, timeout time.Duration) error {
+	return m.Do(req, res)
+}
+
+// Handler serve postgrest request, table is the last segment of request path
+// so both `/rest/v1/articles` and `/articles` is accepted
+func (m *MockPostgrest) Handler(ctx *fasthttp.RequestCtx) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path := strings.Trim(string(ctx.Path()), "/")
+	tableName := path[strings.LastIndex(path, "/")+1:]
+
+	t, ok := m.tables[tableName]
+	if !ok {
+		writeMockPostgrestError(ctx, newMockPostgrestError(fasthttp.StatusNotFound, "42P01", "relation \"public.%s\" does not exist", tableName))
+		return
+	}
+
+	var err error
+	switch string(ctx.Method()) {
+	case fasthttp.MethodGet, fasthttp.MethodHead:
+		err = m.handleRead(ctx, t)
+	case fasthttp.MethodPost:
+		err = m.handleInsert(ctx, t)
+	case fasthttp.MethodPatch:
+		err = m.handleUpdate(ctx, t)
+	case fasthttp.MethodDelete:
+		err = m.handleDelete(ctx, t)
+	default:
+		err = newMockPostgrestError(fasthttp.StatusMethodNotAllowed, "PGRST117", "Unsupported HTTP method: %s", ctx.Method())
+	}
+
+	if err != nil {
+		if pgErr, ok := err.(*MockPostgrestError); ok {
+			writeMockPostgrestError(ctx, pgErr)
+			return
+		}
+		writeMockPostgrestError(ctx, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "%s", err.Error()))
+	}
+}
+
+func (m *MockPostgrest) handleRead(ctx *fasthttp.RequestCtx, t *mockTable) error {
+	q, err := parseMockQuery(ctx.QueryArgs())
+	if err != nil {
+		return err
+	}
+
+	rows, total, err := m.selectRows(t, t.rows, q)
+	if err != nil {
+		return err
+	}
+
+	countTotal := "*"
+	if getMockPreference(ctx, "count") != "" {
+		countTotal = strconv.Itoa(total)
+	}
+
+	contentRange := fmt.Sprintf("*/%s", countTotal)
+	if len(rows) > 0 {
+		contentRange = fmt.Sprintf("%d-%d/%s", q.offset, q.offset+len(rows)-1, countTotal)
+	}
+	ctx.Response.Header.Set("Content-Range", contentRange)
+
+	if ctx.IsHead() {
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		return nil
+	}
+
+	return writeMockRows(ctx, fasthttp.StatusOK, rows)
+}
+
+func (m *MockPostgrest) handleInsert(ctx *fasthttp.RequestCtx, t *mockTable) error {
+	items, err := parseMockPayload(ctx.PostBody())
+	if err != nil {
+		return err
+	}
+
+	resolution := getMockPreference(ctx, "resolution")
+	conflictColumns := t.primaryKeys()
+	if onConflict := string(ctx.QueryArgs().Peek("on_conflict")); onConflict != "" {
+		conflictColumns = strings.Split(onConflict, ",")
+	}
+
+	for _, c := range conflictColumns {
+		if !t.hasColumn(c) {
+			return newMockPostgrestError(fasthttp.StatusBadRequest, "42703", "column %s.%s does not exist", t.name, c)
+		}
+	}
+
+	// work on copy, so failed request does not change table
+	rows := make([]map[string]any, 0, len(t.rows)+len(items))
+	rows = append(rows, t.rows...)
+	sequence := t.sequence
+
+	affected := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		item = filterMockPayloadColumns(ctx, item)
+		if err := t.validatePayload(item); err != nil {
+			t.sequence = sequence
+			return err
+		}
+
+		conflict := findMockConflict(rows, item, conflictColumns)
+		if conflict < 0 {
+			row, err := t.newRow(item, false)
+			if err != nil {
+				t.sequence = sequence
+				return err
+			}
+			rows = append(rows, row)
+			affected = append(affected, row)
+			continue
+		}
+
+		switch resolution {
+		case "merge-duplicates":
+			row := copyRow(rows[conflict])
+			for k, v := range item {
+				row[k] = v
+			}
+			rows[conflict] = row
+			affected = append(affected, row)
+		case "ignore-duplicates":
+			continue
+		default:
+			t.sequence = sequence
+			return &MockPostgrestError{
+				Status:  fasthttp.StatusConflict,
+				Code:    "23505",
+				Message: fmt.Sprintf("duplicate key value violates unique constraint \"%s_pkey\"", t.name),
+				Details: fmt.Sprintf("Key (%s) already exists.", strings.Join(conflictColumns, ", ")),
+			}
+		}
+	}
+
+	t.rows = rows
+	return m.writeMutation(ctx, t, fasthttp.StatusCreated, affected)
+}
+
+func (m *MockPostgrest) handleUpdate(ctx *fasthttp.RequestCtx, t *mockTable) error {
+	q, err := parseMockQuery(ctx.QueryArgs())
+	if err != nil {
+		return err
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(ctx.PostBody(), &payload); err != nil {
+		return newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST102", "Empty or invalid json")
+	}
+
+	payload = filterMockPayloadColumns(ctx, payload)
+	if err := t.validatePayload(payload); err != nil {
+		return err
+	}
+
+	matched, err := m.filterRows(t, t.rows, q.filters)
+	if err != nil {
+		return err
+	}
+
+	affected := make([]map[string]any, 0, len(matched))
+	for i, row := range t.rows {
+		if !containsMockRow(matched, row) {
+			continue
+		}
+
+		updated := copyRow(row)
+		for k, v := range payload {
+			updated[k] = v
+		}
+		t.rows[i] = updated
+		affected = append(affected, updated)
+	}
+
+	return m.writeMutation(ctx, t, fasthttp.StatusOK, affected)
+}
+
+func (m *MockPostgrest) handleDelete(ctx *fasthttp.RequestCtx, t *mockTable) error {
+	q, err := parseMockQuery(ctx.QueryArgs())
+	if err != nil {
+		return err
+	}
+
+	matched, err := m.filterRows(t, t.rows, q.filters)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]map[string]any, 0, len(t.rows))
+	for _, row := range t.rows {
+		if !containsMockRow(matched, row) {
+			rows = append(rows, row)
+		}
+	}
+	t.rows = rows
+
+	return m.writeMutation(ctx, t, fasthttp.StatusOK, matched)
+}
+
+func (m *MockPostgrest) writeMutation(ctx *fasthttp.RequestCtx, t *mockTable, status int, affected []map[string]any) error {
+	if getMockPreference(ctx, "count") != "" {
+		contentRange := fmt.Sprintf("*/%d", len(affected))
+		if len(affected) > 0 {
+			contentRange = fmt.Sprintf("0-%d/%d", len(affected)-1, len(affected))
+		}
+		ctx.Response.Header.Set("Content-Range", contentRange)
+	}
+
+	if getMockPreference(ctx, "return") != "representation" {
+		if status == fasthttp.StatusOK {
+			status = fasthttp.StatusNoContent
+		}
+		ctx.SetStatusCode(status)
+		return nil
+	}
+
+	q, err := parseMockQuery(ctx.QueryArgs())
+	if err != nil {
+		return err
+	}
+
+	// representation only use select, filter is already applied by mutation
+	q = &mockQuery{columns: q.columns, star: q.star, embeds: q.embeds, limit: -1}
+	rows, _, err := m.selectRows(t, affected, q)
+	if err != nil {
+		return err
+	}
+
+	return writeMockRows(ctx, status, rows)
+}
+
+func (m *MockPostgrest) register(rt reflect.Type) *mockTable {
+	for rt.Kind() == reflect.Ptr || rt.Kind() == reflect.Slice {
+		rt = rt.Elem()
+	}
+
+	name := raiden.GetTableName(reflect.New(rt).Interface())
+	if t, ok := m.tables[name]; ok {
+		return t
+	}
+
+	t := &mockTable{name: name}
+	m.tables[name] = t
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+
+		if joinTag := field.Tag.Get("join"); joinTag != "" {
+			related := field.Type
+			for related.Kind() == reflect.Ptr || related.Kind() == reflect.Slice {
+				related = related.Elem()
+			}
+
+			relatedTable := m.register(related)
+			t.relations = append(t.relations, mockRelation{
+				alias: getMockJsonName(field),
+				table: relatedTable.name,
+				join:  raiden.UnmarshalJoinTag(joinTag),
+			})
+			continue
+		}
+
+		if column, ok := getMockColumn(field); ok {
+			t.columns = append(t.columns, column)
+		}
+	}
+
+	return t
+}
+
+func getMockColumn(field reflect.StructField) (mockColumn, bool) {
+	if columnTag := field.Tag.Get("column"); columnTag != "" {
+		tag := raiden.UnmarshalColumnTag(columnTag)
+		if tag.Name == "" {
+			tag.Name = getMockJsonName(field)
+		}
+
+		column := mockColumn{name: tag.Name, primaryKey: tag.PrimaryKey, autoIncrement: tag.AutoIncrement}
+		if tag.Default != nil {
+			column.defaultValue = tag.Default.(*string)
+		}
+		return column, true
+	}
+
+	name := getMockJsonName(field)
+	if name == "" || name == "-" || field.Name == "Metadata" || field.Name == "Acl" {
+		return mockColumn{}, false
+	}
+	return mockColumn{name: name}, true
+}
+
+func getMockJsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func (t *mockTable) hasColumn(name string) bool {
+	for _, c := range t.columns {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *mockTable) primaryKeys() []string {
+	var keys []string
+	for _, c := range t.columns {
+		if c.primaryKey {
+			keys = append(keys, c.name)
+		}
+	}
+	return keys
+}
+
+func (t *mockTable) validatePayload(payload map[string]any) error {
+	for k := range payload {
+		if !t.hasColumn(k) {
+			return newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST204", "Could not find the '%s' column of '%s' in the schema cache", k, t.name)
+		}
+	}
+	return nil
+}
+
+// newRow build row from payload, missing column is filled with default value,
+// seed also treat zero value as missing because model can't tell unset field
+func (t *mockTable) newRow(payload map[string]any, zeroAsMissing bool) (map[string]any, error) {
+	row := make(map[string]any, len(t.columns))
+	for _, c := range t.columns {
+		value, exist := payload[c.name]
+		if exist && !(zeroAsMissing && isMockZeroValue(value) && (c.autoIncrement || c.defaultValue != nil)) {
+			if c.autoIncrement {
+				if n, ok := value.(float64); ok && int64(n) > t.sequence {
+					t.sequence = int64(n)
+				}
+			}
+			row[c.name] = value
+			continue
+		}
+
+		switch {
+		case c.autoIncrement:
+			t.sequence++
+			row[c.name] = float64(t.sequence)
+		case c.defaultValue != nil:
+			row[c.name] = getMockDefaultValue(*c.defaultValue)
+		default:
+			row[c.name] = nil
+		}
+	}
+	return row, nil
+}
+
+func getMockDefaultValue(value string) any {
+	switch strings.ToLower(value) {
+	case "now()", "current_timestamp":
+		return time.Now().UTC().Format(time.RFC3339Nano)
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n
+	}
+	return strings.Trim(value, "'")
+}
+
+func isMockZeroValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case float64:
+		return v == 0
+	case string:
+		return v == "" || v == "0001-01-01T00:00:00Z"
+	}
+	return false
+}
+
+// modelToRow convert every column of model to json value
+func modelToRow(model reflect.Value) map[string]any {
+	row := make(map[string]any)
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Type().Field(i)
+		if field.Anonymous || !field.IsExported() || field.Tag.Get("join") != "" {
+			continue
+		}
+
+		column, ok := getMockColumn(field)
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(model.Field(i).Interface())
+		if err != nil {
+			continue
+		}
+
+		var value any
+		if err := json.Unmarshal(data, &value); err == nil {
+			row[column.name] = value
+		}
+	}
+	return row
+}
+
+func parseMockPayload(body []byte) ([]map[string]any, error) {
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var items []map[string]any
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST102", "Empty or invalid json")
+		}
+		return items, nil
+	}
+
+	var item map[string]any
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST102", "Empty or invalid json")
+	}
+	return []map[string]any{item}, nil
+}
+
+// filterMockPayloadColumns keep only key listed in `columns` param
+func filterMockPayloadColumns(ctx *fasthttp.RequestCtx, payload map[string]any) map[string]any {
+	columns := string(ctx.QueryArgs().Peek("columns"))
+	if columns == "" {
+		return payload
+	}
+
+	filtered := make(map[string]any)
+	for _, c := range strings.Split(columns, ",") {
+		if v, ok := payload[c]; ok {
+			filtered[c] = v
+		}
+	}
+	return filtered
+}
+
+func findMockConflict(rows []map[string]any, item map[string]any, columns []string) int {
+	if len(columns) == 0 {
+		return -1
+	}
+
+	for i, row := range rows {
+		matched := true
+		for _, c := range columns {
+			value, ok := item[c]
+			if !ok || value == nil || !isMockValueEqual(row[c], value) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsMockRow(rows []map[string]any, row map[string]any) bool {
+	for _, r := range rows {
+		if reflect.ValueOf(r).Pointer() == reflect.ValueOf(row).Pointer() {
+			return true
+		}
+	}
+	return false
+}
+
+func copyRow(row map[string]any) map[string]any {
+	copied := make(map[string]any, len(row))
+	for k, v := range row {
+		copied[k] = v
+	}
+	return copied
+}
+
+// getMockPreference return value of preference in Prefer header, for example `return` of `return=minimal`
+func getMockPreference(ctx *fasthttp.RequestCtx, key string) string {
+	for _, pref := range strings.Split(string(ctx.Request.Header.Peek("Prefer")), ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(pref), "=")
+		if found && k == key {
+			return v
+		}
+	}
+	return ""
+}
+
+func writeMockRows(ctx *fasthttp.RequestCtx, status int, rows []map[string]any) error {
+	var body any = rows
+	if strings.Contains(string(ctx.Request.Header.Peek(fasthttp.HeaderAccept)), "application/vnd.pgrst.object+json") {
+		if len(rows) != 1 {
+			return &MockPostgrestError{
+				Status:  fasthttp.StatusNotAcceptable,
+				Code:    "PGRST116",
+				Message: "JSON object requested, multiple (or no) rows returned",
+				Details: fmt.Sprintf("The result contains %d rows", len(rows)),
+			}
+		}
+		body = rows[0]
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx.SetStatusCode(status)
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetBody(data)
+	return nil
+}
+
+func writeMockPostgrestError(ctx *fasthttp.RequestCtx, err *MockPostgrestError) {
+	data, _ := json.Marshal(err)
+	ctx.Response.Header.Del("Content-Range")
+	ctx.SetStatusCode(err.Status)
+	ctx.SetContentType("application/json; charset=utf-8")
+	ctx.SetBody(data)
+}
+
+// sortMockRows sort row with postgres default, null is placed last on ascending
+// and first on descending order
+func sortMockRows(rows []mockResultRow, orders []mockOrder) {
+	if len(orders) == 0 {
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, o := range orders {
+			a, _ := resolveMockColumn(rows[i].source, o.column)
+			b, _ := resolveMockColumn(rows[j].source, o.column)
+
+			if a == nil || b == nil {
+				if a == nil && b == nil {
+					continue
+				}
+				nullsFirst := o.desc
+				if o.nulls != "" {
+					nullsFirst = o.nulls == "nullsfirst"
+				}
+				return (a == nil) == nullsFirst
+			}
+
+			cmp, ok := compareMockValue(a, b)
+			if !ok || cmp == 0 {
+				continue
+			}
+
+			if o.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
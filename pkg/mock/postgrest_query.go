@@ -0,0 +1,834 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+type mockQuery struct {
+	star    bool
+	columns []mockSelectColumn
+	embeds  []*mockEmbed
+	filters []mockFilter
+	order   []mockOrder
+	limit   int
+	offset  int
+}
+
+type mockSelectColumn struct {
+	alias  string
+	column string
+}
+
+type mockEmbed struct {
+	alias string
+	name  string
+	hint  string
+	inner bool
+	query *mockQuery
+}
+
+type mockFilter struct {
+	column   string
+	operator string
+	value    string
+	negate   bool
+
+	// logical group, operator is `and` or `or`
+	group   string
+	filters []mockFilter
+}
+
+type mockOrder struct {
+	column string
+	desc   bool
+	nulls  string
+}
+
+type mockResultRow struct {
+	source map[string]any
+	embeds map[string]any
+}
+
+var mockJsonPathPattern = regexp.MustCompile(`->>?`)
+
+// parseMockQuery parse query param into query tree,
+// param of embedded resource is prefixed with the embed path like `user.team_id=eq.1`
+func parseMockQuery(args *fasthttp.Args) (*mockQuery, error) {
+	root := &mockQuery{star: true, limit: -1}
+	if args.Has("select") {
+		if err := parseMockSelect(root, string(args.Peek("select"))); err != nil {
+			return nil, err
+		}
+	}
+
+	var parseErr error
+	args.VisitAll(func(k, v []byte) {
+		if parseErr != nil {
+			return
+		}
+
+		key, value := string(k), string(v)
+		switch key {
+		case "select", "columns", "on_conflict":
+			return
+		}
+
+		path := strings.Split(key, ".")
+		name := path[len(path)-1]
+		prefix := path[:len(path)-1]
+		if len(prefix) > 0 && prefix[len(prefix)-1] == "not" && (name == "and" || name == "or") {
+			prefix = prefix[:len(prefix)-1]
+			name = "not." + name
+		}
+
+		q, err := findMockEmbedQuery(root, prefix)
+		if err != nil {
+			parseErr = err
+			return
+		}
+
+		switch name {
+		case "order":
+			q.order, parseErr = parseMockOrder(value)
+		case "limit":
+			q.limit, parseErr = strconv.Atoi(value)
+		case "offset":
+			q.offset, parseErr = strconv.Atoi(value)
+		case "and", "or", "not.and", "not.or":
+			var f mockFilter
+			f, parseErr = parseMockGroup(name, value)
+			q.filters = append(q.filters, f)
+		default:
+			var f mockFilter
+			f, parseErr = parseMockCondition(name, value)
+			q.filters = append(q.filters, f)
+		}
+	})
+
+	if parseErr != nil {
+		if _, ok := parseErr.(*MockPostgrestError); !ok {
+			parseErr = newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "%s", parseErr.Error())
+		}
+		return nil, parseErr
+	}
+	return root, nil
+}
+
+func findMockEmbedQuery(root *mockQuery, path []string) (*mockQuery, error) {
+	q := root
+	for _, segment := range path {
+		var found *mockEmbed
+		for _, e := range q.embeds {
+			if e.alias == segment || e.name == segment {
+				found = e
+				break
+			}
+		}
+
+		if found == nil {
+			return nil, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST108", "'%s' is not an embedded resource in this request", strings.Join(path, "."))
+		}
+		q = found.query
+	}
+	return q, nil
+}
+
+// parseMockSelect parse select like `id,name:title,user:users!user_id!inner(id,team:teams(*))`
+func parseMockSelect(q *mockQuery, value string) error {
+	q.star = false
+	for _, item := range splitMockTopLevel(value) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if open := strings.Index(item, "("); open >= 0 {
+			if !strings.HasSuffix(item, ")") {
+				return newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "failed to parse select parameter (%s)", value)
+			}
+
+			if open == len(item)-2 || strings.Contains(item[:open], ".") {
+				return newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "mock postgrest does not support aggregate function (%s)", item)
+			}
+
+			embed := &mockEmbed{query: &mockQuery{limit: -1}}
+			head := item[:open]
+			if alias, rest, found := strings.Cut(head, ":"); found {
+				embed.alias, head = alias, rest
+			}
+
+			parts := strings.Split(head, "!")
+			embed.name = parts[0]
+			for _, p := range parts[1:] {
+				switch p {
+				case "inner":
+					embed.inner = true
+				case "left":
+				default:
+					embed.hint = p
+				}
+			}
+
+			if embed.alias == "" {
+				embed.alias = embed.name
+			}
+
+			if err := parseMockSelect(embed.query, item[open+1:len(item)-1]); err != nil {
+				return err
+			}
+			q.embeds = append(q.embeds, embed)
+			continue
+		}
+
+		if item == "*" {
+			q.star = true
+			continue
+		}
+
+		// cast is ignored, value is returned as stored
+		if idx := strings.Index(item, "::"); idx >= 0 {
+			item = item[:idx]
+		}
+
+		column := mockSelectColumn{column: item}
+		if alias, rest, found := strings.Cut(item, ":"); found {
+			column.alias, column.column = alias, rest
+		}
+
+		if column.alias == "" {
+			paths := mockJsonPathPattern.Split(column.column, -1)
+			column.alias = paths[len(paths)-1]
+		}
+		q.columns = append(q.columns, column)
+	}
+	return nil
+}
+
+func parseMockOrder(value string) ([]mockOrder, error) {
+	var orders []mockOrder
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(item, ".")
+		o := mockOrder{column: parts[0]}
+		for _, p := range parts[1:] {
+			switch p {
+			case "asc":
+			case "desc":
+				o.desc = true
+			case "nullsfirst", "nullslast":
+				o.nulls = p
+			default:
+				return nil, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "failed to parse order (%s)", value)
+			}
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+// parseMockCondition parse condition value like `eq.1` or `not.in.(1,2)`
+func parseMockCondition(column string, value string) (mockFilter, error) {
+	f := mockFilter{column: column}
+
+	operator, rest, _ := strings.Cut(value, ".")
+	if operator == "not" {
+		f.negate = true
+		operator, rest, _ = strings.Cut(rest, ".")
+	}
+
+	switch operator {
+	case "eq", "neq", "gt", "gte", "lt", "lte", "like", "ilike", "match", "imatch", "in", "is", "cs", "cd", "ov":
+	default:
+		return f, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "mock postgrest does not support operator \"%s\" (%s=%s)", operator, column, value)
+	}
+
+	f.operator, f.value = operator, rest
+	return f, nil
+}
+
+// parseMockGroup parse logical group like `or=(id.eq.1,and(rating.gte.4,title.ilike.*a*))`
+func parseMockGroup(key string, value string) (mockFilter, error) {
+	f := mockFilter{group: key}
+	if strings.HasPrefix(key, "not.") {
+		f.negate = true
+		f.group = strings.TrimPrefix(key, "not.")
+	}
+
+	if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return f, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "failed to parse logic tree (%s)", value)
+	}
+
+	for _, term := range splitMockTopLevel(value[1 : len(value)-1]) {
+		for _, op := range []string{"and(", "or(", "not.and(", "not.or("} {
+			if strings.HasPrefix(term, op) {
+				child, err := parseMockGroup(strings.TrimSuffix(op, "("), term[len(op)-1:])
+				if err != nil {
+					return f, err
+				}
+				f.filters = append(f.filters, child)
+				term = ""
+				break
+			}
+		}
+
+		if term == "" {
+			continue
+		}
+
+		column, rest, found := strings.Cut(term, ".")
+		if !found {
+			return f, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST100", "failed to parse logic tree (%s)", value)
+		}
+
+		child, err := parseMockCondition(column, rest)
+		if err != nil {
+			return f, err
+		}
+		child.value = unquoteMockValue(child.value)
+		f.filters = append(f.filters, child)
+	}
+	return f, nil
+}
+
+// splitMockTopLevel split value by comma that not inside parentheses, braces or double quote
+func splitMockTopLevel(value string) []string {
+	var items []string
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case '(', '{':
+			if !quoted {
+				depth++
+			}
+		case ')', '}':
+			if !quoted {
+				depth--
+			}
+		case ',':
+			if !quoted && depth == 0 {
+				items = append(items, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(items, value[start:])
+}
+
+func unquoteMockValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		value = value[1 : len(value)-1]
+		value = strings.ReplaceAll(value, "\\\"", "\"")
+		value = strings.ReplaceAll(value, "\\\\", "\\")
+	}
+	return value
+}
+
+// selectRows apply filter, embed, order, offset and limit of query to rows,
+// the total count before offset and limit is returned for Content-Range
+func (m *MockPostgrest) selectRows(t *mockTable, rows []map[string]any, q *mockQuery) ([]map[string]any, int, error) {
+	for _, c := range q.columns {
+		if !t.hasColumn(getMockBaseColumn(c.column)) {
+			return nil, 0, newMockPostgrestError(fasthttp.StatusBadRequest, "42703", "column %s.%s does not exist", t.name, getMockBaseColumn(c.column))
+		}
+	}
+
+	for _, o := range q.order {
+		if !t.hasColumn(getMockBaseColumn(o.column)) {
+			return nil, 0, newMockPostgrestError(fasthttp.StatusBadRequest, "42703", "column %s.%s does not exist", t.name, getMockBaseColumn(o.column))
+		}
+	}
+
+	filtered, err := m.filterRows(t, rows, q.filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]mockResultRow, 0, len(filtered))
+	for _, row := range filtered {
+		result := mockResultRow{source: row, embeds: make(map[string]any)}
+
+		included := true
+		for _, e := range q.embeds {
+			value, err := m.embedRows(t, row, e)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if e.inner && (value == nil || len(asMockRows(value)) == 0) {
+				included = false
+				break
+			}
+			result.embeds[e.alias] = value
+		}
+
+		if included {
+			results = append(results, result)
+		}
+	}
+
+	sortMockRows(results, q.order)
+
+	total := len(results)
+	if q.offset > 0 {
+		results = results[min(q.offset, len(results)):]
+	}
+
+	if q.limit >= 0 && q.limit < len(results) {
+		results = results[:q.limit]
+	}
+
+	output := make([]map[string]any, 0, len(results))
+	for _, r := range results {
+		item := make(map[string]any)
+		if q.star || (len(q.columns) == 0 && len(q.embeds) == 0) {
+			for k, v := range r.source {
+				item[k] = v
+			}
+		}
+
+		for _, c := range q.columns {
+			item[c.alias], _ = resolveMockColumn(r.source, c.column)
+		}
+
+		for k, v := range r.embeds {
+			item[k] = v
+		}
+		output = append(output, item)
+	}
+
+	return output, total, nil
+}
+
+func (m *MockPostgrest) filterRows(t *mockTable, rows []map[string]any, filters []mockFilter) ([]map[string]any, error) {
+	for _, f := range filters {
+		if err := validateMockFilter(t, f); err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		ok := true
+		for _, f := range filters {
+			if !f.match(row) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			matched = append(matched, row)
+		}
+	}
+	return matched, nil
+}
+
+func validateMockFilter(t *mockTable, f mockFilter) error {
+	if f.group != "" {
+		for _, child := range f.filters {
+			if err := validateMockFilter(t, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !t.hasColumn(getMockBaseColumn(f.column)) {
+		return newMockPostgrestError(fasthttp.StatusBadRequest, "42703", "column %s.%s does not exist", t.name, getMockBaseColumn(f.column))
+	}
+	return nil
+}
+
+// embedRows return embedded resource of row, to-one relation return single row or nil
+// and to-many relation return list of row
+func (m *MockPostgrest) embedRows(parent *mockTable, row map[string]any, e *mockEmbed) (any, error) {
+	relation, err := parent.findRelation(e)
+	if err != nil {
+		return nil, err
+	}
+
+	related := m.tables[relation.table]
+	join := relation.join
+
+	var children []map[string]any
+	toOne := false
+	switch join.JoinType {
+	case "hasOne":
+		toOne = true
+		if parent.hasColumn(join.ForeignKey) {
+			children = findMockRows(related.rows, join.PrimaryKey, row[join.ForeignKey])
+		} else {
+			children = findMockRows(related.rows, join.ForeignKey, row[join.PrimaryKey])
+		}
+	case "hasMany":
+		children = findMockRows(related.rows, join.ForeignKey, row[join.PrimaryKey])
+	case "manyToMany":
+		through, ok := m.tables[join.Through]
+		if !ok {
+			return nil, newMockPostgrestError(fasthttp.StatusBadRequest, "PGRST200", "Could not find join table '%s' of '%s' and '%s' in the schema cache", join.Through, parent.name, related.name)
+		}
+
+		for _, j := range findMockRows(through.rows, join.SourceForeignKey, row[join.SourcePrimaryKey]) {
+			children = append(children, findMockRows(related.rows, join.TargetPrimaryKey, j[join.TargetForeignKey])...)
+		}
+	}
+
+	result, _, err := m.selectRows(related, children, e.query)
+	if err != nil {
+		return nil, err
+	}
+
+	if toOne {
+		if len(result) == 0 {
+			return nil, nil
+		}
+		return result[0], nil
+	}
+	return result, nil
+}
+
+// findRelation find relation by table name or json name of relation field,
+// hint is matched with foreign key when available
+func (t *mockTable) findRelation(e *mockEmbed) (mockRelation, error) {
+	for _, r := range t.relations {
+		if r.table != e.name && r.alias != e.name {
+			continue
+		}
+
+		if e.hint != "" && e.hint != r.join.ForeignKey && e.hint != r.join.SourceForeignKey && e.hint != r.join.Through {
+			continue
+		}
+		return r, nil
+	}
+
+	return mockRelation{}, &MockPostgrestError{
+		Status:  fasthttp.StatusBadRequest,
+		Code:    "PGRST200",
+		Message: fmt.Sprintf("Could not find a relationship between '%s' and '%s' in the schema cache", t.name, e.name),
+		Hint:    "add join tag to the model field",
+	}
+}
+
+func findMockRows(rows []map[string]any, column string, value any) []map[string]any {
+	var matched []map[string]any
+	if value == nil {
+		return matched
+	}
+
+	for _, r := range rows {
+		if isMockValueEqual(r[column], value) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+func asMockRows(value any) []map[string]any {
+	switch v := value.(type) {
+	case []map[string]any:
+		return v
+	case map[string]any:
+		return []map[string]any{v}
+	}
+	return nil
+}
+
+func (f mockFilter) match(row map[string]any) bool {
+	var matched bool
+	if f.group != "" {
+		matched = f.group == "and"
+		for _, child := range f.filters {
+			if child.match(row) != matched {
+				matched = !matched
+				break
+			}
+		}
+	} else {
+		value, _ := resolveMockColumn(row, f.column)
+		matched = matchMockCondition(value, f.operator, f.value)
+	}
+
+	if f.negate {
+		return !matched
+	}
+	return matched
+}
+
+func matchMockCondition(value any, operator string, filter string) bool {
+	switch operator {
+	case "is":
+		switch strings.ToLower(filter) {
+		case "null", "unknown":
+			return value == nil
+		case "true":
+			return value == true
+		case "false":
+			return value == false
+		}
+		return false
+	case "in":
+		for _, item := range splitMockTopLevel(strings.TrimSuffix(strings.TrimPrefix(filter, "("), ")")) {
+			if cmp, ok := compareMockFilterValue(value, unquoteMockValue(strings.TrimSpace(item))); ok && cmp == 0 {
+				return true
+			}
+		}
+		return false
+	case "like", "ilike", "match", "imatch":
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+
+		pattern := filter
+		if operator == "like" || operator == "ilike" {
+			pattern = likeToMockPattern(filter)
+		}
+		if operator == "ilike" || operator == "imatch" {
+			pattern = "(?i)" + pattern
+		}
+
+		re, err := regexp.Compile(pattern)
+		return err == nil && re.MatchString(s)
+	case "cs", "cd", "ov":
+		return matchMockContainment(value, operator, filter)
+	}
+
+	cmp, ok := compareMockFilterValue(value, filter)
+	if !ok {
+		return false
+	}
+
+	switch operator {
+	case "eq":
+		return cmp == 0
+	case "neq":
+		return cmp != 0
+	case "gt":
+		return cmp > 0
+	case "gte":
+		return cmp >= 0
+	case "lt":
+		return cmp < 0
+	case "lte":
+		return cmp <= 0
+	}
+	return false
+}
+
+func likeToMockPattern(filter string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range filter {
+		switch r {
+		case '*', '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// matchMockContainment match array column with array literal `{a,b}` or json column with json value
+func matchMockContainment(value any, operator string, filter string) bool {
+	var expected []any
+	if strings.HasPrefix(filter, "{") && strings.HasSuffix(filter, "}") {
+		for _, item := range splitMockTopLevel(filter[1 : len(filter)-1]) {
+			if item != "" {
+				expected = append(expected, unquoteMockValue(item))
+			}
+		}
+	} else if err := json.Unmarshal([]byte(filter), &expected); err != nil {
+		var object map[string]any
+		if err := json.Unmarshal([]byte(filter), &object); err != nil || operator == "ov" {
+			return false
+		}
+
+		row, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		if operator == "cd" {
+			row, object = object, row
+		}
+		for k, v := range object {
+			if !isMockValueEqual(row[k], v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	actual, ok := value.([]any)
+	if !ok {
+		return false
+	}
+
+	contains := func(list []any, item any) bool {
+		for _, v := range list {
+			if isMockValueEqual(v, item) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch operator {
+	case "cs":
+		for _, e := range expected {
+			if !contains(actual, e) {
+				return false
+			}
+		}
+		return true
+	case "cd":
+		for _, a := range actual {
+			if !contains(expected, a) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, e := range expected {
+			if contains(actual, e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// resolveMockColumn return value of column, json path like `data->a->>b` is resolved
+func resolveMockColumn(row map[string]any, column string) (any, bool) {
+	base := getMockBaseColumn(column)
+	value, ok := row[base]
+	if !ok || base == column {
+		return value, ok
+	}
+
+	operators := mockJsonPathPattern.FindAllString(column, -1)
+	keys := mockJsonPathPattern.Split(column, -1)[1:]
+	for i, key := range keys {
+		switch v := value.(type) {
+		case map[string]any:
+			value = v[key]
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, true
+			}
+			value = v[idx]
+		default:
+			return nil, true
+		}
+
+		if operators[i] == "->>" && value != nil {
+			if _, isString := value.(string); !isString {
+				data, _ := json.Marshal(value)
+				value = string(data)
+			}
+		}
+	}
+	return value, true
+}
+
+func getMockBaseColumn(column string) string {
+	if idx := strings.Index(column, "->"); idx >= 0 {
+		return column[:idx]
+	}
+	return column
+}
+
+// compareMockFilterValue compare json value with filter value based on json value type
+func compareMockFilterValue(value any, filter string) (int, bool) {
+	switch v := value.(type) {
+	case nil:
+		return 0, false
+	case float64:
+		n, err := strconv.ParseFloat(filter, 64)
+		if err != nil {
+			return 0, false
+		}
+		return compareMockValue(v, n)
+	case bool:
+		b, err := strconv.ParseBool(filter)
+		if err != nil {
+			return 0, false
+		}
+		return compareMockValue(v, b)
+	case string:
+		return compareMockValue(v, filter)
+	}
+
+	data, _ := json.Marshal(value)
+	return strings.Compare(string(data), filter), true
+}
+
+func compareMockValue(a any, b any) (int, bool) {
+	switch av := a.(type) {
+	case float64:
+		bv, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av < bv:
+			return -1, true
+		case av > bv:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		if av == bv {
+			return 0, true
+		}
+		if !av {
+			return -1, true
+		}
+		return 1, true
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+
+		// timestamp is compared as time, so different precision and zone is still matched
+		at, aErr := time.Parse(time.RFC3339Nano, av)
+		bt, bErr := time.Parse(time.RFC3339Nano, bv)
+		if aErr == nil && bErr == nil {
+			return at.Compare(bt), true
+		}
+		return strings.Compare(av, bv), true
+	}
+	return 0, false
+}
+
+func isMockValueEqual(a any, b any) bool {
+	if cmp, ok := compareMockValue(a, b); ok {
+		return cmp == 0
+	}
+
+	aData, _ := json.Marshal(a)
+	bData, _ := json.Marshal(b)
+	return string(aData) == string(bData)
+}
@@ -0,0 +1,257 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type mockAuthor struct {
+	raiden.ModelBase
+
+	Id   int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement"`
+	Name string `json:"name,omitempty" column:"name:name;type:text"`
+
+	Metadata string `json:"-" schema:"public" tableName:"authors"`
+
+	Books []*mockBook `json:"books,omitempty" join:"joinType:hasMany;primaryKey:id;foreignKey:author_id"`
+}
+
+type mockBook struct {
+	raiden.ModelBase
+
+	Id       int64    `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement"`
+	AuthorId int64    `json:"author_id,omitempty" column:"name:author_id;type:bigint"`
+	Title    string   `json:"title,omitempty" column:"name:title;type:text"`
+	Rating   int64    `json:"rating,omitempty" column:"name:rating;type:bigint"`
+	Note     *string  `json:"note" column:"name:note;type:text;nullable"`
+	Tags     []string `json:"tags,omitempty" column:"name:tags;type:text[];nullable"`
+
+	Metadata string `json:"-" schema:"public" tableName:"books"`
+
+	Author *mockAuthor `json:"author,omitempty" join:"joinType:hasOne;primaryKey:id;foreignKey:author_id"`
+}
+
+func newTestPostgrest(t *testing.T) *MockPostgrest {
+	note := "classic"
+	pg := NewMockPostgrest(&mockBook{})
+	err := pg.Seed(
+		[]mockAuthor{{Id: 1, Name: "ann"}, {Id: 2, Name: "bob"}, {Id: 3, Name: "cid"}},
+		[]mockBook{
+			{Id: 1, AuthorId: 1, Title: "a,b", Rating: 5, Note: &note, Tags: []string{"go"}},
+			{Id: 2, AuthorId: 1, Title: "go tips", Rating: 3},
+			{Id: 3, AuthorId: 2, Title: `say "hi"`, Rating: 4, Tags: []string{"go", "db"}},
+		},
+	)
+	assert.NoError(t, err)
+	return pg
+}
+
+func doMockRequest(pg *MockPostgrest, method string, table string, query url.Values, body string, headers map[string]string) *fasthttp.Response {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+
+	req.Header.SetMethod(method)
+	req.SetRequestURI("http://supabase.local/rest/v1/" + table + "?" + query.Encode())
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if body != "" {
+		req.SetBodyString(body)
+	}
+
+	res := &fasthttp.Response{}
+	_ = pg.Do(req, res)
+	return res
+}
+
+func mockResponseRows(t *testing.T, res *fasthttp.Response) []map[string]any {
+	var rows []map[string]any
+	assert.NoError(t, json.Unmarshal(res.Body(), &rows))
+	return rows
+}
+
+func mockRowIds(rows []map[string]any) []float64 {
+	ids := make([]float64, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r["id"].(float64))
+	}
+	return ids
+}
+
+func TestParseMockGroup(t *testing.T) {
+	f, err := parseMockGroup("or", `(id.eq.1,and(rating.gte.4,title.not.ilike.*a*),not.or(id.eq.2,id.eq.3),title.eq."a,b")`)
+	assert.NoError(t, err)
+	assert.Equal(t, "or", f.group)
+	assert.Len(t, f.filters, 4)
+
+	assert.Equal(t, mockFilter{column: "id", operator: "eq", value: "1"}, f.filters[0])
+
+	assert.Equal(t, "and", f.filters[1].group)
+	assert.Equal(t, mockFilter{column: "title", operator: "ilike", value: "*a*", negate: true}, f.filters[1].filters[1])
+
+	assert.Equal(t, "or", f.filters[2].group)
+	assert.True(t, f.filters[2].negate)
+	assert.Len(t, f.filters[2].filters, 2)
+
+	// quoted value is unquoted, comma inside quote is not a separator
+	assert.Equal(t, mockFilter{column: "title", operator: "eq", value: "a,b"}, f.filters[3])
+
+	_, err = parseMockGroup("or", "id.eq.1")
+	assert.Error(t, err)
+
+	_, err = parseMockGroup("and", "(id)")
+	assert.Error(t, err)
+}
+
+func TestParseMockCondition(t *testing.T) {
+	f, err := parseMockCondition("id", "not.in.(1,2)")
+	assert.NoError(t, err)
+	assert.Equal(t, mockFilter{column: "id", operator: "in", value: "(1,2)", negate: true}, f)
+
+	f, err = parseMockCondition("note", "is.null")
+	assert.NoError(t, err)
+	assert.Equal(t, mockFilter{column: "note", operator: "is", value: "null"}, f)
+
+	_, err = parseMockCondition("title", "fts.go")
+	assert.Error(t, err)
+}
+
+func TestMockPostgrest_Filter(t *testing.T) {
+	pg := newTestPostgrest(t)
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		expected []float64
+	}{
+		{name: "not", query: url.Values{"rating": {"not.eq.5"}}, expected: []float64{2, 3}},
+		{name: "is null", query: url.Values{"note": {"is.null"}}, expected: []float64{2, 3}},
+		{name: "not is null", query: url.Values{"note": {"not.is.null"}}, expected: []float64{1}},
+		{name: "in with quoted value", query: url.Values{"title": {`in.("a,b","say \"hi\"")`}}, expected: []float64{1, 3}},
+		{name: "not in", query: url.Values{"id": {"not.in.(1,2)"}}, expected: []float64{3}},
+		{name: "contains", query: url.Values{"tags": {"cs.{go,db}"}}, expected: []float64{3}},
+		{name: "or group", query: url.Values{"or": {"(id.eq.2,and(rating.gte.4,title.like.say*))"}}, expected: []float64{2, 3}},
+		{name: "not and group", query: url.Values{"not.and": {"(author_id.eq.1,rating.lt.5)"}}, expected: []float64{1, 3}},
+		{name: "quoted value in group", query: url.Values{"or": {`(title.eq."a,b",rating.eq.3)`}}, expected: []float64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Set("order", "id.asc")
+			res := doMockRequest(pg, fasthttp.MethodGet, "books", tt.query, "", nil)
+			assert.Equal(t, fasthttp.StatusOK, res.StatusCode())
+			assert.Equal(t, tt.expected, mockRowIds(mockResponseRows(t, res)))
+		})
+	}
+
+	res := doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"unknown": {"eq.1"}}, "", nil)
+	assert.Equal(t, fasthttp.StatusBadRequest, res.StatusCode())
+	assert.Contains(t, string(res.Body()), "42703")
+}
+
+func TestMockPostgrest_Embed(t *testing.T) {
+	pg := newTestPostgrest(t)
+
+	// to-one relation with alias
+	res := doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"select": {"id,writer:authors(name)"}, "id": {"eq.3"}}, "", nil)
+	assert.Equal(t, fasthttp.StatusOK, res.StatusCode())
+	assert.Equal(t, []map[string]any{{"id": float64(3), "writer": map[string]any{"name": "bob"}}}, mockResponseRows(t, res))
+
+	// to-many relation with order and limit of embedded resource
+	res = doMockRequest(pg, fasthttp.MethodGet, "authors", url.Values{
+		"select":      {"name,books(id)"},
+		"id":          {"eq.1"},
+		"books.order": {"id.desc"},
+		"books.limit": {"1"},
+	}, "", nil)
+	assert.Equal(t, []map[string]any{{"name": "ann", "books": []any{map[string]any{"id": float64(2)}}}}, mockResponseRows(t, res))
+
+	// inner join drop parent without embedded row
+	res = doMockRequest(pg, fasthttp.MethodGet, "authors", url.Values{
+		"select":       {"id,books!inner(id)"},
+		"books.rating": {"gte.4"},
+		"order":        {"id.asc"},
+	}, "", nil)
+	assert.Equal(t, []float64{1, 2}, mockRowIds(mockResponseRows(t, res)))
+
+	res = doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"select": {"id,publishers(*)"}}, "", nil)
+	assert.Equal(t, fasthttp.StatusBadRequest, res.StatusCode())
+	assert.Contains(t, string(res.Body()), "PGRST200")
+
+	res = doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"select": {"id"}, "author.name": {"eq.ann"}}, "", nil)
+	assert.Equal(t, fasthttp.StatusBadRequest, res.StatusCode())
+	assert.Contains(t, string(res.Body()), "PGRST108")
+}
+
+func TestMockPostgrest_Prefer(t *testing.T) {
+	pg := newTestPostgrest(t)
+
+	res := doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"rating": {"gte.4"}, "order": {"id.asc"}}, "", map[string]string{"Prefer": "count=exact"})
+	assert.Equal(t, "0-1/2", string(res.Header.Peek("Content-Range")))
+
+	res = doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"rating": {"gte.4"}}, "", nil)
+	assert.Equal(t, "0-1/*", string(res.Header.Peek("Content-Range")))
+
+	// minimal return is the default
+	res = doMockRequest(pg, fasthttp.MethodPost, "books", nil, `{"author_id":3,"title":"new"}`, nil)
+	assert.Equal(t, fasthttp.StatusCreated, res.StatusCode())
+	assert.Empty(t, res.Body())
+
+	res = doMockRequest(pg, fasthttp.MethodPatch, "books", url.Values{"id": {"eq.4"}}, `{"rating":1}`, nil)
+	assert.Equal(t, fasthttp.StatusNoContent, res.StatusCode())
+
+	res = doMockRequest(pg, fasthttp.MethodPatch, "books", url.Values{"id": {"eq.4"}, "select": {"id,rating"}}, `{"rating":2}`, map[string]string{
+		"Prefer": "return=representation, count=exact",
+	})
+	assert.Equal(t, fasthttp.StatusOK, res.StatusCode())
+	assert.Equal(t, "0-0/1", string(res.Header.Peek("Content-Range")))
+	assert.Equal(t, []map[string]any{{"id": float64(4), "rating": float64(2)}}, mockResponseRows(t, res))
+
+	// object accept header require exactly one row
+	res = doMockRequest(pg, fasthttp.MethodGet, "books", url.Values{"id": {"eq.1"}, "select": {"title"}}, "", map[string]string{
+		fasthttp.HeaderAccept: "application/vnd.pgrst.object+json",
+	})
+	assert.Equal(t, fasthttp.StatusOK, res.StatusCode())
+	assert.JSONEq(t, `{"title":"a,b"}`, string(res.Body()))
+
+	res = doMockRequest(pg, fasthttp.MethodGet, "books", nil, "", map[string]string{
+		fasthttp.HeaderAccept: "application/vnd.pgrst.object+json",
+	})
+	assert.Equal(t, fasthttp.StatusNotAcceptable, res.StatusCode())
+}
+
+func TestMockPostgrest_Upsert(t *testing.T) {
+	pg := newTestPostgrest(t)
+	query := url.Values{"on_conflict": {"title"}}
+
+	res := doMockRequest(pg, fasthttp.MethodPost, "books", query, `{"title":"go tips","rating":1}`, nil)
+	assert.Equal(t, fasthttp.StatusConflict, res.StatusCode())
+	assert.Contains(t, string(res.Body()), "Key (title) already exists.")
+
+	res = doMockRequest(pg, fasthttp.MethodPost, "books", query, `[{"title":"go tips","rating":1},{"title":"new","rating":2}]`, map[string]string{
+		"Prefer": "resolution=merge-duplicates,return=representation",
+	})
+	assert.Equal(t, fasthttp.StatusCreated, res.StatusCode())
+	assert.Equal(t, []float64{2, 4}, mockRowIds(mockResponseRows(t, res)))
+
+	rows := pg.Rows("books")
+	assert.Len(t, rows, 4)
+	assert.Equal(t, float64(1), rows[1]["rating"])
+	assert.Equal(t, float64(1), rows[1]["author_id"])
+
+	res = doMockRequest(pg, fasthttp.MethodPost, "books", query, `{"title":"new","rating":5}`, map[string]string{
+		"Prefer": "resolution=ignore-duplicates,return=representation",
+	})
+	assert.Equal(t, fasthttp.StatusCreated, res.StatusCode())
+	assert.Empty(t, mockResponseRows(t, res))
+	assert.Equal(t, float64(2), pg.Rows("books")[3]["rating"])
+
+	res = doMockRequest(pg, fasthttp.MethodPost, "books", url.Values{"on_conflict": {"isbn"}}, `{"title":"other"}`, nil)
+	assert.Equal(t, fasthttp.StatusBadRequest, res.StatusCode())
+	assert.Len(t, pg.Rows("books"), 4)
+}
@@ -89,6 +89,18 @@ func (m *MockSupabase) MockGetRoleMembershipsWithExpectedResponse(httpCode int,
 	return registerMock(m.Cfg, actionType, method, url, httpCode, memberships)
 }
 
+func (m *MockSupabase) MockGetColumnPrivilegesWithExpectedResponse(httpCode int, privileges []objects.ColumnPrivilege) error {
+	actionType, method, url := getMethodAndUrl(m.Cfg, "getColumnPrivileges")
+
+	return registerMock(m.Cfg, actionType, method, url, httpCode, privileges)
+}
+
+func (m *MockSupabase) MockGetTablePrivilegesWithExpectedResponse(httpCode int, privileges []objects.TablePrivilege) error {
+	actionType, method, url := getMethodAndUrl(m.Cfg, "getTablePrivileges")
+
+	return registerMock(m.Cfg, actionType, method, url, httpCode, privileges)
+}
+
 func (m *MockSupabase) MockCreateRoleWithExpectedResponse(httpCode int, role objects.Role) error {
 	actionType, method, url := getMethodAndUrl(m.Cfg, "common")
 
@@ -249,6 +261,14 @@ func registerMock(cfg *raiden.Config, actionType string, method string, url stri
 			httpmock.RegisterMatcherResponder(method, url,
 				httpmock.BodyContainsString("inherit.rolname    AS inherit_role"),
 				httpmock.NewStringResponder(httpCode, string(jsonData)))
+		case "getColumnPrivileges":
+			httpmock.RegisterMatcherResponder(method, url,
+				httpmock.BodyContainsString("column_grants AS"),
+				httpmock.NewStringResponder(httpCode, string(jsonData)))
+		case "getTablePrivileges":
+			httpmock.RegisterMatcherResponder(method, url,
+				httpmock.BodyContainsString("AS privileges"),
+				httpmock.NewStringResponder(httpCode, string(jsonData)))
 		case "getPolicies":
 			httpmock.RegisterMatcherResponder(method, url,
 				httpmock.BodyContainsString("pol.polname AS name"),
@@ -11,3 +11,10 @@ type Driver interface {
 	CursorPaginateNext(ctx context.Context, statement string, cursorRefColumn string, cursor any, limit int, withCount bool) (data []Item, count int, prevCursor any, nextCursor any, err error)
 	CursorPaginatePrev(ctx context.Context, statement string, cursorRefColumn string, cursor any, limit int, withCount bool) (data []Item, count int, prevCursor any, nextCursor any, err error)
 }
+
+// KeysetDriver is implemented by driver that support cursor pagination over composite sort keys,
+// rows after cursor values are returned in order of sort keys or in reversed order when backward is true.
+// Cursor values is nil for the first page.
+type KeysetDriver interface {
+	KeysetPaginate(ctx context.Context, statement string, keys []SortKey, cursor []any, backward bool, limit int, withCount bool) (data []Item, count int, err error)
+}
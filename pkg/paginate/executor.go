@@ -26,6 +26,15 @@ const (
 
 const DefaultOffsetColumn = "id"
 
+// CountMethod is strategy used by postgrest for counting total data
+type CountMethod string
+
+const (
+	CountExact     CountMethod = "exact"
+	CountPlanned   CountMethod = "planned"
+	CountEstimated CountMethod = "estimated"
+)
+
 type Executor interface {
 	Execute(ctx context.Context, statement string) (ExecuteResult[Item], error)
 	SetDriver(driver Driver) Executor
@@ -40,8 +49,13 @@ type ExecuteOptions struct {
 	CursorDirection CursorPaginateDirection
 	CursorRefColumn string
 
-	WithCount bool
-	IsBypass  bool
+	// SortKeys enable keyset pagination over composite keys like (created_at, id),
+	// Cursor is opaque token returned as NextCursor or PrevCursor of previous page
+	SortKeys []SortKey
+
+	WithCount   bool
+	CountMethod CountMethod
+	IsBypass    bool
 }
 
 type ExecuteResult[T any] struct {
@@ -57,7 +71,7 @@ type executor struct {
 }
 
 func New(config *raiden.Config, opts ExecuteOptions) Executor {
-	driver := &SupabaseDriver{}
+	driver := &SupabaseDriver{countMethod: opts.CountMethod}
 
 	baseUrl := config.SupabasePublicUrl
 	if config.Mode == raiden.SvcMode {
@@ -84,7 +98,14 @@ func New(config *raiden.Config, opts ExecuteOptions) Executor {
 }
 
 func NewFromContext(ctx raiden.Context, opts ExecuteOptions) Executor {
-	driver := &SupabaseDriver{}
+	return &executor{
+		options: opts,
+		driver:  newDriverFromContext(ctx, opts),
+	}
+}
+
+func newDriverFromContext(ctx raiden.Context, opts ExecuteOptions) *SupabaseDriver {
+	driver := &SupabaseDriver{countMethod: opts.CountMethod}
 
 	baseUrl := ctx.Config().SupabasePublicUrl
 	if ctx.Config().Mode == raiden.SvcMode {
@@ -115,10 +136,7 @@ func NewFromContext(ctx raiden.Context, opts ExecuteOptions) Executor {
 		}
 	}
 
-	return &executor{
-		options: opts,
-		driver:  driver,
-	}
+	return driver
 }
 
 func (e *executor) Execute(ctx context.Context, statement string) (ExecuteResult[Item], error) {
@@ -134,6 +152,10 @@ func (e *executor) Execute(ctx context.Context, statement string) (ExecuteResult
 		result.Count = count
 	}
 
+	if e.options.Type == CursorPagination && len(e.options.SortKeys) > 0 {
+		return e.executeKeyset(ctx, statement)
+	}
+
 	if e.options.Type == CursorPagination {
 		cursorRefColumn := e.options.CursorRefColumn
 		if cursorRefColumn == "" {
@@ -171,11 +193,107 @@ func (e *executor) Execute(ctx context.Context, statement string) (ExecuteResult
 	return result, nil
 }
 
+func (e *executor) executeKeyset(ctx context.Context, statement string) (ExecuteResult[Item], error) {
+	var result ExecuteResult[Item]
+
+	driver, ok := e.driver.(KeysetDriver)
+	if !ok {
+		return result, fmt.Errorf("paginate driver %T does not support sort keys", e.driver)
+	}
+
+	cursor, err := decodeOptionCursor(e.options.SortKeys, e.options.Cursor)
+	if err != nil {
+		return result, err
+	}
+
+	backward := e.options.CursorDirection == CursorPaginateDirectionPrev && cursor != nil
+	data, count, err := driver.KeysetPaginate(ctx, statement, e.options.SortKeys, cursor, backward, e.options.Limit+1, e.options.WithCount)
+	if err != nil {
+		return result, err
+	}
+
+	direction := CursorPaginateDirectionNext
+	if backward {
+		direction = CursorPaginateDirectionPrev
+	}
+
+	items, nextCursor, prevCursor, err := KeysetPage(e.options.SortKeys, data, e.options.Limit, direction, cursor != nil)
+	if err != nil {
+		return result, err
+	}
+
+	result.Data, result.Count = items, count
+	result.NextCursor, result.PrevCursor = nextCursor, prevCursor
+	if result.Data == nil {
+		result.Data = make([]Item, 0)
+	}
+	return result, nil
+}
+
+// decodeOptionCursor decode cursor token of execute options, empty cursor mean the first page
+func decodeOptionCursor(keys []SortKey, cursor any) ([]any, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	token, ok := cursor.(string)
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	if token == "" {
+		return nil, nil
+	}
+	return DecodeCursor(keys, token)
+}
+
 func (e *executor) SetDriver(driver Driver) Executor {
 	e.driver = driver
 	return e
 }
 
+// TypedExecutor execute pagination and bind the page data into model T
+type TypedExecutor[T any] struct {
+	executor Executor
+}
+
+// NewTyped wrap executor so the result is returned as []T
+//
+// example :
+//
+//	paginator := paginate.NewTyped[models.Article](paginate.NewFromContext(ctx, paginate.ExecuteOptions{
+//		Type:     paginate.CursorPagination,
+//		Limit:    20,
+//		Cursor:   string(ctx.RequestContext().QueryArgs().Peek("cursor")),
+//		SortKeys: []paginate.SortKey{paginate.Desc("created_at"), paginate.Desc("id")},
+//	}))
+//	result, err := paginator.Execute(context.Background(), "/articles?select=*")
+func NewTyped[T any](executor Executor) *TypedExecutor[T] {
+	return &TypedExecutor[T]{executor: executor}
+}
+
+func (e *TypedExecutor[T]) Execute(ctx context.Context, statement string) (ExecuteResult[T], error) {
+	data, err := e.executor.Execute(ctx, statement)
+	if err != nil {
+		return ExecuteResult[T]{}, err
+	}
+
+	result, err := MarshallResult[T](data)
+	if err != nil {
+		return result, err
+	}
+
+	if result.Data == nil {
+		result.Data = make([]T, 0)
+	}
+	return result, nil
+}
+
+func (e *TypedExecutor[T]) SetDriver(driver Driver) *TypedExecutor[T] {
+	e.executor.SetDriver(driver)
+	return e
+}
+
 func MarshallResult[T any](data ExecuteResult[Item]) (result ExecuteResult[T], err error) {
 	byteData, err := json.Marshal(data)
 	if err != nil {
@@ -0,0 +1,197 @@
+package paginate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SortKey is column of keyset pagination, the last key must be unique
+// so row with the same value of previous key still have stable order.
+// Value of sort key column must not be null.
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+// Asc create ascending sort key
+func Asc(column string) SortKey {
+	return SortKey{Column: column}
+}
+
+// Desc create descending sort key
+func Desc(column string) SortKey {
+	return SortKey{Column: column, Desc: true}
+}
+
+// ErrInvalidCursor is returned when cursor token is malformed or created for different sort keys
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+type cursorToken struct {
+	Columns []string `json:"c"`
+	Values  []any    `json:"v"`
+}
+
+// EncodeCursor create opaque cursor token from value of sort key columns
+func EncodeCursor(keys []SortKey, values []any) (string, error) {
+	if len(keys) == 0 || len(keys) != len(values) {
+		return "", ErrInvalidCursor
+	}
+
+	token := cursorToken{Columns: sortKeyColumns(keys), Values: values}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor return value of sort key columns from cursor token,
+// ErrInvalidCursor is returned when token is not created by EncodeCursor with the same sort keys
+func DecodeCursor(keys []SortKey, cursor string) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+
+	var token cursorToken
+	if err := decoder.Decode(&token); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	columns := sortKeyColumns(keys)
+	if len(token.Columns) != len(columns) || len(token.Values) != len(columns) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i := range columns {
+		if token.Columns[i] != columns[i] || token.Values[i] == nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return token.Values, nil
+}
+
+// KeysetOrder return postgrest order value of sort keys,
+// order is reversed when page is fetched backward
+func KeysetOrder(keys []SortKey, backward bool) string {
+	orders := make([]string, 0, len(keys))
+	for _, k := range keys {
+		direction := "asc"
+		if k.Desc != backward {
+			direction = "desc"
+		}
+		orders = append(orders, fmt.Sprintf("%s.%s", k.Column, direction))
+	}
+	return strings.Join(orders, ",")
+}
+
+// KeysetFilter return postgrest filter that select row after cursor values,
+// composite keys (a, b) is expanded to `or=(a.gt.x,and(a.eq.x,b.gt.y))`
+func KeysetFilter(keys []SortKey, values []any, backward bool) (string, error) {
+	if len(keys) == 0 || len(keys) != len(values) {
+		return "", ErrInvalidCursor
+	}
+
+	operator := func(k SortKey) string {
+		if k.Desc != backward {
+			return "lt"
+		}
+		return "gt"
+	}
+
+	if len(keys) == 1 {
+		return fmt.Sprintf("%s=%s.%s", keys[0].Column, operator(keys[0]), url.QueryEscape(formatKeysetValue(values[0], false))), nil
+	}
+
+	terms := make([]string, 0, len(keys))
+	for i, k := range keys {
+		conditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("%s.eq.%s", keys[j].Column, formatKeysetValue(values[j], true)))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s.%s.%s", k.Column, operator(k), formatKeysetValue(values[i], true)))
+
+		if len(conditions) == 1 {
+			terms = append(terms, conditions[0])
+			continue
+		}
+		terms = append(terms, fmt.Sprintf("and(%s)", strings.Join(conditions, ",")))
+	}
+	return "or=" + url.QueryEscape(fmt.Sprintf("(%s)", strings.Join(terms, ","))), nil
+}
+
+// KeysetPage trim row fetched with limit + 1 to page and create cursor of the page,
+// row of backward page is fetched in reversed order and restored here
+func KeysetPage(keys []SortKey, rows []Item, limit int, direction CursorPaginateDirection, hasCursor bool) (items []Item, nextCursor any, prevCursor any, err error) {
+	hasMore := len(rows) > limit
+	items = rows
+	if hasMore {
+		items = rows[:limit]
+	}
+
+	hasNext, hasPrev := hasMore, hasCursor
+	if direction == CursorPaginateDirectionPrev {
+		items = reverseSlice(items)
+		hasNext, hasPrev = hasCursor, hasMore
+	}
+
+	if len(items) == 0 {
+		return items, nil, nil, nil
+	}
+
+	if hasNext {
+		if nextCursor, err = encodeItemCursor(keys, items[len(items)-1]); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if hasPrev {
+		if prevCursor, err = encodeItemCursor(keys, items[0]); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return items, nextCursor, prevCursor, nil
+}
+
+func encodeItemCursor(keys []SortKey, item Item) (string, error) {
+	values := make([]any, 0, len(keys))
+	for _, k := range keys {
+		v, ok := item[k.Column]
+		if !ok || v == nil {
+			return "", fmt.Errorf("sort key column %s is not selected or null", k.Column)
+		}
+		values = append(values, v)
+	}
+	return EncodeCursor(keys, values)
+}
+
+func sortKeyColumns(keys []SortKey) []string {
+	columns := make([]string, 0, len(keys))
+	for _, k := range keys {
+		columns = append(columns, k.Column)
+	}
+	return columns
+}
+
+// formatKeysetValue format cursor value for filter,
+// string inside logical group is quoted because it can contain reserved character like `,` or `:`
+func formatKeysetValue(value any, quoted bool) string {
+	s, isString := value.(string)
+	if !isString {
+		return fmt.Sprintf("%v", value)
+	}
+
+	if !quoted {
+		return s
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
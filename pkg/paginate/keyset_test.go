@@ -0,0 +1,135 @@
+package paginate_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/paginate"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type keysetPayload struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	keys := []paginate.SortKey{paginate.Desc("created_at"), paginate.Desc("id")}
+
+	token, err := paginate.EncodeCursor(keys, []any{"2024-01-01T10:00:00Z", 10})
+	assert.NoError(t, err)
+
+	values, err := paginate.DecodeCursor(keys, token)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"2024-01-01T10:00:00Z", json.Number("10")}, values)
+
+	_, err = paginate.DecodeCursor([]paginate.SortKey{paginate.Asc("id")}, token)
+	assert.ErrorIs(t, err, paginate.ErrInvalidCursor)
+
+	_, err = paginate.DecodeCursor(keys, "not a cursor")
+	assert.ErrorIs(t, err, paginate.ErrInvalidCursor)
+
+	_, err = paginate.EncodeCursor(keys, []any{10})
+	assert.ErrorIs(t, err, paginate.ErrInvalidCursor)
+}
+
+func TestKeysetFilter(t *testing.T) {
+	filter, err := paginate.KeysetFilter([]paginate.SortKey{paginate.Asc("id")}, []any{5}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "id=gt.5", filter)
+
+	filter, err = paginate.KeysetFilter([]paginate.SortKey{paginate.Asc("id")}, []any{5}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "id=lt.5", filter)
+
+	keys := []paginate.SortKey{paginate.Desc("created_at"), paginate.Asc("name"), paginate.Desc("id")}
+	filter, err = paginate.KeysetFilter(keys, []any{"2024-01-01T10:00:00+07:00", `a,"b"`, 3}, false)
+	assert.NoError(t, err)
+
+	values, err := url.ParseQuery(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, `(created_at.lt."2024-01-01T10:00:00+07:00",and(created_at.eq."2024-01-01T10:00:00+07:00",name.gt."a,\"b\""),and(created_at.eq."2024-01-01T10:00:00+07:00",name.eq."a,\"b\"",id.lt.3))`, values.Get("or"))
+
+	assert.Equal(t, "created_at.desc,name.asc,id.desc", paginate.KeysetOrder(keys, false))
+	assert.Equal(t, "created_at.asc,name.desc,id.asc", paginate.KeysetOrder(keys, true))
+}
+
+func TestExecutor_Keyset(t *testing.T) {
+	keys := []paginate.SortKey{paginate.Desc("created_at"), paginate.Desc("id")}
+	rows := []map[string]any{
+		{"id": 5, "name": "test_5", "created_at": "2024-01-02T00:00:00Z"},
+		{"id": 4, "name": "test_4", "created_at": "2024-01-01T00:00:00Z"},
+		{"id": 3, "name": "test_3", "created_at": "2024-01-01T00:00:00Z"},
+	}
+
+	var requestUri string
+	closeFn := setMockRequest(func(r1 *fasthttp.Request, r2 *fasthttp.Response) error {
+		requestUri = r1.URI().String()
+		dataByte, err := json.Marshal(rows)
+		if err != nil {
+			return err
+		}
+		r2.SetBodyRaw(dataByte)
+		r2.Header.Set("content-range", "0-2/10")
+		return nil
+	})
+	defer closeFn()
+
+	// - Test first page
+	paginator := paginate.NewTyped[keysetPayload](paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Limit:           2,
+		Type:            paginate.CursorPagination,
+		CursorDirection: paginate.CursorPaginateDirectionNext,
+		SortKeys:        keys,
+		IsBypass:        true,
+		WithCount:       true,
+	}))
+
+	result, err := paginator.Execute(context.Background(), "/data?select=*")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8002/rest/v1/data?select=*&order=created_at.desc,id.desc&limit=3", requestUri)
+	assert.Equal(t, []keysetPayload{{Id: 5, Name: "test_5"}, {Id: 4, Name: "test_4"}}, result.Data)
+	assert.Equal(t, 10, result.Count)
+	assert.Nil(t, result.PrevCursor)
+
+	values, err := paginate.DecodeCursor(keys, result.NextCursor.(string))
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"2024-01-01T00:00:00Z", json.Number("4")}, values)
+
+	// - Test previous page
+	cursor := result.NextCursor
+	paginator = paginate.NewTyped[keysetPayload](paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Limit:           2,
+		Type:            paginate.CursorPagination,
+		Cursor:          cursor,
+		CursorDirection: paginate.CursorPaginateDirectionPrev,
+		SortKeys:        keys,
+		IsBypass:        true,
+	}))
+
+	result, err = paginator.Execute(context.Background(), "/data?select=*")
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(requestUri)
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at.asc,id.asc", parsed.Query().Get("order"))
+	assert.Equal(t, `(created_at.gt."2024-01-01T00:00:00Z",and(created_at.eq."2024-01-01T00:00:00Z",id.gt.4))`, parsed.Query().Get("or"))
+	assert.Equal(t, []keysetPayload{{Id: 4, Name: "test_4"}, {Id: 5, Name: "test_5"}}, result.Data)
+	assert.NotNil(t, result.PrevCursor)
+	assert.NotNil(t, result.NextCursor)
+
+	// - Test invalid cursor
+	paginator = paginate.NewTyped[keysetPayload](paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Limit:    2,
+		Type:     paginate.CursorPagination,
+		Cursor:   "invalid",
+		SortKeys: keys,
+		IsBypass: true,
+	}))
+
+	_, err = paginator.Execute(context.Background(), "/data")
+	assert.ErrorIs(t, err, paginate.ErrInvalidCursor)
+}
@@ -0,0 +1,63 @@
+package paginate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/valyala/fasthttp"
+)
+
+// ExecuteRpcPaginated execute SETOF or TABLE rpc page by page, params is sent as request body
+// and pagination is applied to the function result by postgrest.
+// Total data is counted with `Prefer: count=exact` unless CountMethod is set.
+// The page data is also bind to Return field of rpc.
+func ExecuteRpcPaginated(ctx raiden.Context, rpc raiden.Rpc, opts ExecuteOptions) (ExecuteResult[Item], error) {
+	var result ExecuteResult[Item]
+
+	if rpc.GetReturnType() != raiden.RpcReturnDataTypeSetOf && rpc.GetReturnType() != raiden.RpcReturnDataTypeTable {
+		return result, &raiden.ErrorResponse{
+			StatusCode: fasthttp.StatusInternalServerError,
+			Details:    fmt.Sprintf("rpc %s return %s, pagination only available for SETOF or TABLE return type", rpc.GetName(), rpc.GetReturnType()),
+			Message:    "Unsupported rpc return type",
+			Hint:       "Invalid Rpc",
+			Code:       fasthttp.StatusMessage(fasthttp.StatusInternalServerError),
+		}
+	}
+
+	if opts.CountMethod == "" {
+		opts.CountMethod = CountExact
+	}
+
+	req, err := raiden.NewRpcRequest(ctx, rpc)
+	if err != nil {
+		return result, err
+	}
+
+	driver := newDriverFromContext(ctx, opts)
+	driver.baseUrl = req.BaseUrl
+	driver.method = fasthttp.MethodPost
+	driver.body = req.Body
+
+	statement := req.Path
+	if req.Query != "" {
+		statement = fmt.Sprintf("%s?%s", statement, req.Query)
+	}
+
+	paginator := &executor{options: opts, driver: driver}
+	result, err = paginator.Execute(context.Background(), statement)
+	if err != nil {
+		return result, err
+	}
+
+	byteData, err := json.Marshal(result.Data)
+	if err != nil {
+		return result, err
+	}
+
+	if _, err := raiden.BindRpcReturn(rpc, byteData); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,140 @@
+package paginate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/sev-2/raiden/pkg/paginate"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type ListScoutersParams struct {
+	Name string `json:"name" column:"name:name;type:varchar"`
+}
+
+type ListScoutersItem struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type ListScouters struct {
+	raiden.RpcBase
+	Params *ListScoutersParams `json:"-"`
+	Return []ListScoutersItem  `json:"-"`
+}
+
+func (r *ListScouters) GetName() string {
+	return "list_scouters"
+}
+
+func (r *ListScouters) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeSetOf
+}
+
+func (r *ListScouters) GetRawDefinition() string {
+	return `BEGIN RETURN QUERY SELECT id, name FROM scouter WHERE name = :name; END;`
+}
+
+type CountScouters struct {
+	raiden.RpcBase
+	Params *ListScoutersParams `json:"-"`
+	Return int64               `json:"-"`
+}
+
+func (r *CountScouters) GetName() string {
+	return "count_scouters"
+}
+
+func (r *CountScouters) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeBigInt
+}
+
+func (r *CountScouters) GetRawDefinition() string {
+	return `BEGIN RETURN (SELECT count(*) FROM scouter WHERE name = :name); END;`
+}
+
+func getRpcMockCtx(query string) *mock.MockContext {
+	ctx := getMockCtx()
+	ctx.RequestContextFn = func() *fasthttp.RequestCtx {
+		rCtx := &fasthttp.RequestCtx{}
+		rCtx.Request.SetRequestURI("/api/v1/scouters?" + query)
+		rCtx.Request.Header.Set("Authorization", "Bearer user-token")
+		rCtx.Request.Header.Set("apikey", "user-api-key")
+		return rCtx
+	}
+	return ctx
+}
+
+func TestExecuteRpcPaginated_Offset(t *testing.T) {
+	closeFn := setMockRequest(func(r1 *fasthttp.Request, r2 *fasthttp.Response) error {
+		assert.Equal(t, fasthttp.MethodPost, string(r1.Header.Method()))
+		assert.Equal(t, "http://localhost:8002/rest/v1/rpc/list_scouters?order=id.asc&limit=2&offset=2", r1.URI().String())
+		assert.JSONEq(t, `{"in_name":"test"}`, string(r1.Body()))
+		assert.Equal(t, "count=exact", string(r1.Header.Peek("Prefer")))
+		assert.Equal(t, "Bearer user-token", string(r1.Header.Peek("Authorization")))
+
+		dataByte, err := json.Marshal(mockData[:2])
+		if err != nil {
+			return err
+		}
+		r2.SetBodyRaw(dataByte)
+		r2.Header.Set("content-range", "2-3/3")
+		return nil
+	})
+	defer closeFn()
+
+	rpc := &ListScouters{Params: &ListScoutersParams{Name: "test"}}
+	result, err := paginate.ExecuteRpcPaginated(getRpcMockCtx("order=id.asc"), rpc, paginate.ExecuteOptions{
+		Page:      2,
+		Limit:     2,
+		Type:      paginate.OffsetPagination,
+		WithCount: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 3, result.Count)
+	assert.Equal(t, []ListScoutersItem{{Id: 1, Name: "test_1"}, {Id: 2, Name: "test_2"}}, rpc.Return)
+}
+
+func TestExecuteRpcPaginated_Cursor(t *testing.T) {
+	closeFn := setMockRequest(func(r1 *fasthttp.Request, r2 *fasthttp.Response) error {
+		assert.Equal(t, "http://localhost:8002/rest/v1/rpc/list_scouters?limit=3", r1.URI().String())
+		assert.Equal(t, "count=planned", string(r1.Header.Peek("Prefer")))
+
+		dataByte, err := json.Marshal(mockData)
+		if err != nil {
+			return err
+		}
+		r2.SetBodyRaw(dataByte)
+		r2.Header.Set("content-range", "0-2/10")
+		return nil
+	})
+	defer closeFn()
+
+	rpc := &ListScouters{Params: &ListScoutersParams{Name: "test"}}
+	result, err := paginate.ExecuteRpcPaginated(getRpcMockCtx(""), rpc, paginate.ExecuteOptions{
+		Limit:           2,
+		Type:            paginate.CursorPagination,
+		CursorDirection: paginate.CursorPaginateDirectionNext,
+		WithCount:       true,
+		CountMethod:     paginate.CountPlanned,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 10, result.Count)
+	assert.Equal(t, float64(2), result.NextCursor)
+	assert.Len(t, rpc.Return, 2)
+}
+
+func TestExecuteRpcPaginated_UnsupportedReturnType(t *testing.T) {
+	_, err := paginate.ExecuteRpcPaginated(getRpcMockCtx(""), &CountScouters{}, paginate.ExecuteOptions{
+		Page:  1,
+		Limit: 10,
+		Type:  paginate.OffsetPagination,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.(*raiden.ErrorResponse).Details, "pagination only available for SETOF or TABLE return type")
+}
@@ -0,0 +1,404 @@
+package paginate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/jwt"
+	"github.com/valyala/fasthttp"
+)
+
+// sqlTotalColumn is column of total row that added by COUNT(*) OVER() and removed from item
+const sqlTotalColumn = "__paginate_total"
+
+// SqlDriver paginate arbitrary select statement directly against postgres.
+// Statement is wrapped as sub query so it can contain join, group by and parameter
+// like `$1` that bound to args. Cursor and keyset value are appended as next parameter.
+//
+// example :
+//
+//	driver := paginate.NewSqlDriver(db, "published")
+//	paginator := paginate.NewFromContext(ctx, opts).SetDriver(driver)
+//	result, err := paginator.Execute(context.Background(), "select a.id, a.title, u.name from articles a join users u on u.id = a.user_id where a.status = $1")
+type SqlDriver struct {
+	db     *sql.DB
+	args   []any
+	role   string
+	claims map[string]any
+}
+
+// NewSqlDriver create sql driver, args is bound to parameter of the statement
+func NewSqlDriver(db *sql.DB, args ...any) *SqlDriver {
+	return &SqlDriver{db: db, args: args}
+}
+
+// WithRole run statement as postgres role with jwt claims, so row level security
+// is applied like request that sent to postgrest
+func (s *SqlDriver) WithRole(role string, claims map[string]any) *SqlDriver {
+	s.role = role
+	s.claims = claims
+	return s
+}
+
+// WithRequestRole run statement as role in bearer token of request,
+// token must be verified with JWT_SECRET because the role is applied directly to sql connection
+func (s *SqlDriver) WithRequestRole(ctx raiden.Context) (*SqlDriver, error) {
+	token := strings.TrimSpace(string(ctx.RequestContext().Request.Header.Peek(fasthttp.HeaderAuthorization)))
+	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer "))
+	if token == "" {
+		return s, errors.New("paginate sql driver : bearer token is required to impersonate request role")
+	}
+
+	if ctx.Config() == nil || ctx.Config().JwtSecret == "" {
+		return s, errors.New("paginate sql driver : JWT_SECRET is required to verify bearer token")
+	}
+
+	validated, err := jwt.Validate[map[string]any](token, ctx.Config().JwtSecret)
+	if err != nil {
+		return s, err
+	}
+	claims := *validated
+
+	role, _ := claims["role"].(string)
+	if role == "" {
+		return s, errors.New("paginate sql driver : role claim is not found in bearer token")
+	}
+	return s.WithRole(role, claims), nil
+}
+
+func (s *SqlDriver) Paginate(ctx context.Context, statement string, page, limit int, withCount bool) ([]Item, int, error) {
+	offset := (page - 1) * limit
+	query := fmt.Sprintf("%s LIMIT %d OFFSET %d", s.wrapStatement(statement, "", withCount), limit, offset)
+	data, count, err := s.query(ctx, query, s.args, withCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// total is not returned when page is out of range
+	if withCount && len(data) == 0 && offset > 0 {
+		if count, err = s.count(ctx, statement); err != nil {
+			return nil, 0, err
+		}
+	}
+	return data, count, nil
+}
+
+// CursorPaginateNext paginate by single cursor column, cursor is value of the column
+// and direction follow order of the statement like SupabaseDriver
+func (s *SqlDriver) CursorPaginateNext(ctx context.Context, statement string, cursorRefColumn string, cursor any, limit int, withCount bool) ([]Item, int, any, any, error) {
+	keys := []SortKey{{Column: cursorRefColumn, Desc: isDescStatement(statement)}}
+
+	var values []any
+	if hasCursorValue(cursor) {
+		values = []any{cursor}
+	}
+
+	data, count, err := s.KeysetPaginate(ctx, statement, keys, values, false, limit+1, withCount)
+	if err != nil {
+		return data, 0, nil, nil, err
+	}
+
+	items := data
+	if len(data) > limit {
+		items = data[:limit]
+	}
+
+	var nextCursor, prevCursor any
+	if len(items) > 0 && values != nil {
+		prevCursor, err = s.existBefore(ctx, statement, keys, items[0])
+		if err != nil {
+			return items, count, nil, nil, err
+		}
+	}
+
+	if len(data) > limit {
+		nextCursor = items[len(items)-1][cursorRefColumn]
+	}
+	return items, count, nextCursor, prevCursor, nil
+}
+
+// CursorPaginatePrev paginate backward by single cursor column, see CursorPaginateNext
+func (s *SqlDriver) CursorPaginatePrev(ctx context.Context, statement string, cursorRefColumn string, cursor any, limit int, withCount bool) ([]Item, int, any, any, error) {
+	keys := []SortKey{{Column: cursorRefColumn, Desc: isDescStatement(statement)}}
+
+	var values []any
+	if hasCursorValue(cursor) {
+		values = []any{cursor}
+	}
+
+	data, count, err := s.KeysetPaginate(ctx, statement, keys, values, values != nil, limit+1, withCount)
+	if err != nil {
+		return data, 0, nil, nil, err
+	}
+
+	items := data
+	if len(data) > limit {
+		items = data[:limit]
+	}
+	if values != nil {
+		items = reverseSlice(items)
+	}
+
+	var nextCursor, prevCursor any
+	if len(items) == 0 {
+		return items, count, nil, nil, nil
+	}
+
+	if values != nil && len(data) > limit {
+		prevCursor = items[0][cursorRefColumn]
+	}
+	nextCursor = items[len(items)-1][cursorRefColumn]
+	return items, count, nextCursor, prevCursor, nil
+}
+
+// KeysetPaginate select row after cursor values ordered by sort keys
+func (s *SqlDriver) KeysetPaginate(ctx context.Context, statement string, keys []SortKey, cursor []any, backward bool, limit int, withCount bool) ([]Item, int, error) {
+	args := append([]any{}, s.args...)
+
+	where := ""
+	if cursor != nil {
+		if len(cursor) != len(keys) {
+			return nil, 0, ErrInvalidCursor
+		}
+
+		var condition string
+		condition, args = sqlKeysetCondition(keys, cursor, backward, args)
+		where = "WHERE " + condition
+	}
+
+	query := fmt.Sprintf("%s ORDER BY %s LIMIT %d", s.wrapStatement(statement, where, withCount), sqlKeysetOrder(keys, backward), limit)
+	return s.query(ctx, query, args, withCount)
+}
+
+// wrapStatement select every column of statement and total row when count is requested
+func (s *SqlDriver) wrapStatement(statement string, where string, withCount bool) string {
+	columns := "paginate_source.*"
+	if withCount {
+		columns += fmt.Sprintf(", COUNT(*) OVER() AS %s", sqlTotalColumn)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM (%s) AS paginate_source", columns, trimStatement(statement))
+	if where != "" {
+		query += " " + where
+	}
+	return query
+}
+
+func (s *SqlDriver) count(ctx context.Context, statement string) (int, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) AS %s FROM (%s) AS paginate_source", sqlTotalColumn, trimStatement(statement))
+	_, count, err := s.query(ctx, query, s.args, true)
+	return count, err
+}
+
+// existBefore return cursor value of item when there is row before the item
+func (s *SqlDriver) existBefore(ctx context.Context, statement string, keys []SortKey, item Item) (any, error) {
+	values := make([]any, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, item[k.Column])
+	}
+
+	data, _, err := s.KeysetPaginate(ctx, statement, keys, values, true, 1, false)
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// query run the query as configured role and return row as json compatible item,
+// so item has the same value type as item returned by SupabaseDriver
+func (s *SqlDriver) query(ctx context.Context, query string, args []any, withCount bool) (data []Item, count int, err error) {
+	if s.db == nil {
+		return nil, 0, errors.New("paginate sql driver : database is not set")
+	}
+
+	var rows *sql.Rows
+	if s.role == "" {
+		rows, err = s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer rows.Close()
+		return scanSqlItems(rows, withCount)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	claims, err := json.Marshal(s.claims)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('role', $1, true), set_config('request.jwt.claims', $2, true)", s.role, string(claims)); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err = tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, count, err = scanSqlItems(rows, withCount)
+	rows.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, count, tx.Commit()
+}
+
+func scanSqlItems(rows *sql.Rows, withCount bool) ([]Item, int, error) {
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count := 0
+	records := make([]map[string]any, 0)
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, 0, err
+		}
+
+		record := make(map[string]any, len(columns))
+		for i, c := range columns {
+			if c.Name() == sqlTotalColumn {
+				if total, ok := values[i].(int64); ok {
+					count = int(total)
+				}
+				continue
+			}
+			record[c.Name()] = normalizeSqlValue(c.DatabaseTypeName(), values[i])
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// use json round trip so number, time and json column is decoded like postgrest response
+	byteData, err := json.Marshal(records)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data := make([]Item, 0, len(records))
+	if err := json.Unmarshal(byteData, &data); err != nil {
+		return nil, 0, err
+	}
+
+	if !withCount {
+		count = 0
+	}
+	return data, count, nil
+}
+
+func normalizeSqlValue(typeName string, value any) any {
+	b, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	switch strings.ToUpper(typeName) {
+	case "JSON", "JSONB", "NUMERIC":
+		if json.Valid(b) {
+			return json.RawMessage(b)
+		}
+	}
+	return string(b)
+}
+
+// sqlKeysetCondition expand keyset (a, b) to `(a > $1 OR (a = $1 AND b > $2))`,
+// so each key can have different direction
+func sqlKeysetCondition(keys []SortKey, values []any, backward bool, args []any) (string, []any) {
+	placeholders := make([]string, len(keys))
+	for i := range keys {
+		args = append(args, values[i])
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	terms := make([]string, 0, len(keys))
+	for i, k := range keys {
+		operator := ">"
+		if k.Desc != backward {
+			operator = "<"
+		}
+
+		conditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("paginate_source.%s = %s", pq.QuoteIdentifier(keys[j].Column), placeholders[j]))
+		}
+		conditions = append(conditions, fmt.Sprintf("paginate_source.%s %s %s", pq.QuoteIdentifier(k.Column), operator, placeholders[i]))
+
+		terms = append(terms, strings.Join(conditions, " AND "))
+	}
+
+	if len(terms) == 1 {
+		return terms[0], args
+	}
+	return "(" + strings.Join(wrapSqlTerms(terms), " OR ") + ")", args
+}
+
+func wrapSqlTerms(terms []string) []string {
+	wrapped := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if strings.Contains(t, " AND ") {
+			t = "(" + t + ")"
+		}
+		wrapped = append(wrapped, t)
+	}
+	return wrapped
+}
+
+func sqlKeysetOrder(keys []SortKey, backward bool) string {
+	orders := make([]string, 0, len(keys))
+	for _, k := range keys {
+		direction := "ASC"
+		if k.Desc != backward {
+			direction = "DESC"
+		}
+		orders = append(orders, fmt.Sprintf("paginate_source.%s %s", pq.QuoteIdentifier(k.Column), direction))
+	}
+	return strings.Join(orders, ", ")
+}
+
+// isDescStatement check order of the last ORDER BY of statement
+func isDescStatement(statement string) bool {
+	lower := strings.ToLower(statement)
+	index := strings.LastIndex(lower, "order by")
+	if index < 0 {
+		return false
+	}
+	return strings.Contains(lower[index:], " desc")
+}
+
+func hasCursorValue(cursor any) bool {
+	switch c := cursor.(type) {
+	case nil:
+		return false
+	case string:
+		return c != ""
+	case int:
+		return c > 0
+	}
+	return true
+}
+
+func trimStatement(statement string) string {
+	return strings.TrimRight(strings.TrimSpace(statement), ";")
+}
@@ -0,0 +1,319 @@
+package paginate_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/paginate"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type fakeSqlRows struct {
+	columns []string
+	types   []string
+	values  [][]driver.Value
+}
+
+type fakeSqlCall struct {
+	query string
+	args  []any
+}
+
+// fakeSqlConnector is minimal database/sql driver that record executed query
+// and return rows from handler
+type fakeSqlConnector struct {
+	calls   []fakeSqlCall
+	handler func(query string, args []any) fakeSqlRows
+}
+
+func (c *fakeSqlConnector) Connect(context.Context) (driver.Conn, error) { return &fakeSqlConn{c}, nil }
+func (c *fakeSqlConnector) Driver() driver.Driver                        { return nil }
+
+type fakeSqlConn struct{ connector *fakeSqlConnector }
+
+func (c *fakeSqlConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeSqlConn) Close() error                        { return nil }
+func (c *fakeSqlConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *fakeSqlConn) Commit() error                       { return nil }
+func (c *fakeSqlConn) Rollback() error                     { return nil }
+
+func (c *fakeSqlConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.connector.calls = append(c.connector.calls, fakeSqlCall{query: "BEGIN"})
+	return c, nil
+}
+
+func (c *fakeSqlConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.connector.calls = append(c.connector.calls, fakeSqlCall{query: query, args: fakeSqlArgs(args)})
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeSqlConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	call := fakeSqlCall{query: query, args: fakeSqlArgs(args)}
+	c.connector.calls = append(c.connector.calls, call)
+	rows := c.connector.handler(call.query, call.args)
+	return &rows, nil
+}
+
+func fakeSqlArgs(values []driver.NamedValue) []any {
+	args := make([]any, 0, len(values))
+	for _, v := range values {
+		args = append(args, v.Value)
+	}
+	return args
+}
+
+func (r *fakeSqlRows) Columns() []string { return r.columns }
+func (r *fakeSqlRows) Close() error      { return nil }
+
+func (r *fakeSqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.types) {
+		return r.types[index]
+	}
+	return ""
+}
+
+func (r *fakeSqlRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// fakeSqlData return rows of id and name with total of COUNT(*) OVER()
+func fakeSqlData(total int64, ids ...int64) fakeSqlRows {
+	rows := fakeSqlRows{
+		columns: []string{"id", "name", "meta", "__paginate_total"},
+		types:   []string{"INT8", "TEXT", "JSONB", "INT8"},
+	}
+	for _, id := range ids {
+		name := "test_" + string(rune('0'+id))
+		rows.values = append(rows.values, []driver.Value{id, []byte(name), []byte(`{"tag":"` + name + `"}`), total})
+	}
+	return rows
+}
+
+func newFakeSqlDB(handler func(query string, args []any) fakeSqlRows) (*sql.DB, *fakeSqlConnector) {
+	connector := &fakeSqlConnector{handler: handler}
+	return sql.OpenDB(connector), connector
+}
+
+func TestSqlDriver_Offset(t *testing.T) {
+	db, connector := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		return fakeSqlData(3, 1, 2, 3)
+	})
+	defer db.Close()
+
+	paginator := paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Page:      3,
+		Limit:     10,
+		Type:      paginate.OffsetPagination,
+		IsBypass:  true,
+		WithCount: true,
+	}).SetDriver(paginate.NewSqlDriver(db, "active"))
+
+	result, err := paginator.Execute(context.Background(), "select * from data where status = $1;")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+	assert.Equal(t, 3, result.Count)
+	assert.Equal(t, paginate.Item{"id": float64(1), "name": "test_1", "meta": map[string]any{"tag": "test_1"}}, result.Data[0])
+
+	assert.Len(t, connector.calls, 1)
+	assert.Equal(t, "SELECT paginate_source.*, COUNT(*) OVER() AS __paginate_total FROM (select * from data where status = $1) AS paginate_source LIMIT 10 OFFSET 20", connector.calls[0].query)
+	assert.Equal(t, []any{"active"}, connector.calls[0].args)
+
+	// - Test out of range page still return total
+	db2, connector2 := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		if strings.HasPrefix(query, "SELECT COUNT(*)") {
+			return fakeSqlRows{columns: []string{"__paginate_total"}, types: []string{"INT8"}, values: [][]driver.Value{{int64(3)}}}
+		}
+		return fakeSqlData(0)
+	})
+	defer db2.Close()
+
+	paginator = paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Page:      5,
+		Limit:     10,
+		Type:      paginate.OffsetPagination,
+		IsBypass:  true,
+		WithCount: true,
+	}).SetDriver(paginate.NewSqlDriver(db2))
+
+	result, err = paginator.Execute(context.Background(), "select * from data")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 0)
+	assert.Equal(t, 3, result.Count)
+	assert.Len(t, connector2.calls, 2)
+}
+
+func TestSqlDriver_CursorNext(t *testing.T) {
+	// - Test cursor first page
+	db, connector := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		return fakeSqlData(4, 1, 2, 3, 4)
+	})
+	defer db.Close()
+
+	paginator := paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Limit:           3,
+		Type:            paginate.CursorPagination,
+		IsBypass:        true,
+		WithCount:       true,
+		CursorDirection: paginate.CursorPaginateDirectionNext,
+	}).SetDriver(paginate.NewSqlDriver(db))
+
+	result, err := paginator.Execute(context.Background(), "select * from data")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+	assert.Equal(t, 4, result.Count)
+	assert.Equal(t, nil, result.PrevCursor)
+	assert.Equal(t, float64(3), result.NextCursor)
+	assert.Equal(t, `SELECT paginate_source.*, COUNT(*) OVER() AS __paginate_total FROM (select * from data) AS paginate_source ORDER BY paginate_source."id" ASC LIMIT 4`, connector.calls[0].query)
+
+	// - Test cursor last page of descending statement
+	db2, connector2 := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		if strings.HasSuffix(query, "LIMIT 1") {
+			return fakeSqlData(0, 3)
+		}
+		return fakeSqlData(2, 2, 1)
+	})
+	defer db2.Close()
+
+	paginator = paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Cursor:          3,
+		Limit:           3,
+		Type:            paginate.CursorPagination,
+		IsBypass:        true,
+		WithCount:       true,
+		CursorDirection: paginate.CursorPaginateDirectionNext,
+	}).SetDriver(paginate.NewSqlDriver(db2))
+
+	result, err = paginator.Execute(context.Background(), "select * from data order by id desc")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 2, result.Count)
+	assert.Equal(t, float64(2), result.PrevCursor)
+	assert.Nil(t, result.NextCursor)
+
+	assert.Len(t, connector2.calls, 2)
+	assert.Equal(t, `SELECT paginate_source.*, COUNT(*) OVER() AS __paginate_total FROM (select * from data order by id desc) AS paginate_source WHERE paginate_source."id" < $1 ORDER BY paginate_source."id" DESC LIMIT 4`, connector2.calls[0].query)
+	assert.Equal(t, []any{int64(3)}, connector2.calls[0].args)
+	assert.Equal(t, `SELECT paginate_source.* FROM (select * from data order by id desc) AS paginate_source WHERE paginate_source."id" > $1 ORDER BY paginate_source."id" ASC LIMIT 1`, connector2.calls[1].query)
+	assert.Equal(t, []any{float64(2)}, connector2.calls[1].args)
+}
+
+func TestSqlDriver_CursorPrev(t *testing.T) {
+	db, connector := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		return fakeSqlData(4, 4, 3, 2, 1)
+	})
+	defer db.Close()
+
+	paginator := paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Cursor:          5,
+		Limit:           3,
+		Type:            paginate.CursorPagination,
+		IsBypass:        true,
+		WithCount:       true,
+		CursorDirection: paginate.CursorPaginateDirectionPrev,
+	}).SetDriver(paginate.NewSqlDriver(db))
+
+	result, err := paginator.Execute(context.Background(), "select * from data")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 3)
+	assert.Equal(t, float64(2), result.Data[0]["id"])
+	assert.Equal(t, float64(2), result.PrevCursor)
+	assert.Equal(t, float64(4), result.NextCursor)
+	assert.Equal(t, `SELECT paginate_source.*, COUNT(*) OVER() AS __paginate_total FROM (select * from data) AS paginate_source WHERE paginate_source."id" < $1 ORDER BY paginate_source."id" DESC LIMIT 4`, connector.calls[0].query)
+}
+
+func TestSqlDriver_Keyset(t *testing.T) {
+	db, connector := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		return fakeSqlData(3, 3, 2)
+	})
+	defer db.Close()
+
+	keys := []paginate.SortKey{paginate.Desc("name"), paginate.Asc("id")}
+	cursor, err := paginate.EncodeCursor(keys, []any{"test_4", 4})
+	assert.NoError(t, err)
+
+	paginator := paginate.NewTyped[keysetPayload](paginate.NewFromContext(getMockCtx(), paginate.ExecuteOptions{
+		Cursor:          cursor,
+		Limit:           2,
+		Type:            paginate.CursorPagination,
+		IsBypass:        true,
+		CursorDirection: paginate.CursorPaginateDirectionNext,
+		SortKeys:        keys,
+	})).SetDriver(paginate.NewSqlDriver(db, "active"))
+
+	result, err := paginator.Execute(context.Background(), "select * from data where status = $1")
+	assert.NoError(t, err)
+	assert.Equal(t, []keysetPayload{{Id: 3, Name: "test_3"}, {Id: 2, Name: "test_2"}}, result.Data)
+	assert.NotNil(t, result.PrevCursor)
+	assert.Nil(t, result.NextCursor)
+
+	assert.Equal(t, `SELECT paginate_source.* FROM (select * from data where status = $1) AS paginate_source WHERE (paginate_source."name" < $2 OR (paginate_source."name" = $2 AND paginate_source."id" > $3)) ORDER BY paginate_source."name" DESC, paginate_source."id" ASC LIMIT 3`, connector.calls[0].query)
+	assert.Equal(t, []any{"active", "test_4", "4"}, connector.calls[0].args)
+}
+
+func TestSqlDriver_Role(t *testing.T) {
+	db, connector := newFakeSqlDB(func(query string, args []any) fakeSqlRows {
+		return fakeSqlData(1, 1)
+	})
+	defer db.Close()
+
+	claims := map[string]any{"role": "authenticated", "sub": "user-1"}
+	payload, _ := json.Marshal(claims)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims)).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	ctx := getMockCtx()
+	ctx.ConfigFn = func() *raiden.Config { return &raiden.Config{JwtSecret: "secret"} }
+	requestCtx := &fasthttp.RequestCtx{}
+	requestCtx.Request.Header.Set("Authorization", "Bearer "+token)
+	ctx.RequestContextFn = func() *fasthttp.RequestCtx { return requestCtx }
+
+	driver, err := paginate.NewSqlDriver(db).WithRequestRole(ctx)
+	assert.NoError(t, err)
+
+	paginator := paginate.NewFromContext(ctx, paginate.ExecuteOptions{
+		Page:  1,
+		Limit: 10,
+		Type:  paginate.OffsetPagination,
+	}).SetDriver(driver)
+
+	result, err := paginator.Execute(context.Background(), "select * from data")
+	assert.NoError(t, err)
+	assert.Len(t, result.Data, 1)
+
+	assert.Len(t, connector.calls, 3)
+	assert.Equal(t, "BEGIN", connector.calls[0].query)
+	assert.Equal(t, "SELECT set_config('role', $1, true), set_config('request.jwt.claims', $2, true)", connector.calls[1].query)
+	assert.Equal(t, "authenticated", connector.calls[1].args[0])
+	assert.JSONEq(t, string(payload), connector.calls[1].args[1].(string))
+
+	// - Test forged token is rejected
+	forged := "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(`{"role":"service_role"}`)) + "."
+	requestCtx.Request.Header.Set("Authorization", "Bearer "+forged)
+	_, err = paginate.NewSqlDriver(db).WithRequestRole(ctx)
+	assert.Error(t, err)
+
+	// - Test token is never trusted when secret is not configured
+	requestCtx.Request.Header.Set("Authorization", "Bearer "+token)
+	ctx.ConfigFn = func() *raiden.Config { return &raiden.Config{} }
+	_, err = paginate.NewSqlDriver(db).WithRequestRole(ctx)
+	assert.Error(t, err)
+
+	requestCtx.Request.Header.Del("Authorization")
+	_, err = paginate.NewSqlDriver(db).WithRequestRole(ctx)
+	assert.Error(t, err)
+}
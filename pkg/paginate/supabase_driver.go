@@ -16,9 +16,12 @@ import (
 )
 
 type SupabaseDriver struct {
-	baseUrl string
-	apiKey  string
-	token   string
+	baseUrl     string
+	apiKey      string
+	token       string
+	method      string
+	body        []byte
+	countMethod CountMethod
 }
 
 func (s *SupabaseDriver) Paginate(ctx context.Context, statement string, page, limit int, withCount bool) ([]Item, int, error) {
@@ -152,6 +155,28 @@ func (s *SupabaseDriver) CursorPaginatePrev(ctx context.Context, statement strin
 	return items, count, nextCursor, prevCursor, nil
 }
 
+// KeysetPaginate append keyset filter, order of sort keys and limit to statement,
+// so statement must not contain order param
+func (s *SupabaseDriver) KeysetPaginate(ctx context.Context, statement string, keys []SortKey, cursor []any, backward bool, limit int, withCount bool) ([]Item, int, error) {
+	params := make([]string, 0, 3)
+	if cursor != nil {
+		filter, err := KeysetFilter(keys, cursor, backward)
+		if err != nil {
+			return nil, 0, err
+		}
+		params = append(params, filter)
+	}
+	params = append(params, fmt.Sprintf("order=%s", KeysetOrder(keys, backward)), fmt.Sprintf("limit=%d", limit))
+
+	paginateStatement := strings.Join(params, "&")
+	if len(strings.Split(statement, "?")) == 2 {
+		statement = fmt.Sprintf("%s&%s", statement, paginateStatement)
+	} else {
+		statement = fmt.Sprintf("%s?%s", statement, paginateStatement)
+	}
+	return s.request(statement, withCount)
+}
+
 func (s *SupabaseDriver) request(statement string, withCount bool) ([]Item, int, error) {
 	var count int
 	var data []Item
@@ -174,7 +199,11 @@ func (s *SupabaseDriver) request(statement string, withCount bool) ([]Item, int,
 		}
 
 		if withCount {
-			req.Header.Set("Prefer", "count=estimated")
+			countMethod := s.countMethod
+			if countMethod == "" {
+				countMethod = CountEstimated
+			}
+			req.Header.Set("Prefer", fmt.Sprintf("count=%s", countMethod))
 		}
 
 		return nil
@@ -199,7 +228,12 @@ func (s *SupabaseDriver) request(statement string, withCount bool) ([]Item, int,
 		return nil
 	}
 
-	body, err := client.SendRequest(fasthttp.MethodGet, url, nil, time.Second*5, reqInterceptor, resInterceptor)
+	method := s.method
+	if method == "" {
+		method = fasthttp.MethodGet
+	}
+
+	body, err := client.SendRequest(method, url, s.body, time.Second*5, reqInterceptor, resInterceptor)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -11,8 +11,10 @@ import (
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/resource/grants"
 	"github.com/sev-2/raiden/pkg/resource/migrator"
 	"github.com/sev-2/raiden/pkg/resource/policies"
+	"github.com/sev-2/raiden/pkg/resource/privileges"
 	"github.com/sev-2/raiden/pkg/resource/roles"
 	"github.com/sev-2/raiden/pkg/resource/rpc"
 	"github.com/sev-2/raiden/pkg/resource/storages"
@@ -33,36 +35,43 @@ type MigrateData struct {
 	Policies []policies.MigrateItem
 	Storages []storages.MigrateItem
 	Types    []types.MigrateItem
+
+	ColumnPrivileges []privileges.MigrateItem
+	TablePrivileges  []grants.MigrateItem
 }
 
 type applyDeps struct {
-	loadNativeRoles     func() (map[string]raiden.Role, error)
-	loadState           func() (*state.State, error)
-	extractApp          func(*Flags, *state.State) (state.ExtractTableResult, state.ExtractRoleResult, state.ExtractRpcResult, state.ExtractStorageResult, state.ExtractTypeResult, error)
-	loadRemote          func(*Flags, *raiden.Config) (*Resource, error)
-	migrate             func(*raiden.Config, *state.LocalState, string, *MigrateData) []error
-	buildRoleMigrate    func(state.ExtractRoleResult, []objects.Role) ([]roles.MigrateItem, error)
-	buildTableMigrate   func(state.ExtractTableResult, []objects.Table, []string) ([]tables.MigrateItem, error)
-	buildRpcMigrate     func(state.ExtractRpcResult, []objects.Function) ([]rpc.MigrateItem, error)
-	buildStorageMigrate func(state.ExtractStorageResult, []objects.Bucket) ([]storages.MigrateItem, error)
-	buildPolicyMigrate  func(state.ExtractPolicyResult, []objects.Policy) ([]policies.MigrateItem, error)
-	buildTypeMigrate    func(state.ExtractTypeResult, []objects.Type) ([]types.MigrateItem, error)
-	printReport         func(MigrateData)
+	loadNativeRoles       func() (map[string]raiden.Role, error)
+	loadState             func() (*state.State, error)
+	extractApp            func(*Flags, *state.State) (state.ExtractTableResult, state.ExtractRoleResult, state.ExtractRpcResult, state.ExtractStorageResult, state.ExtractTypeResult, error)
+	loadRemote            func(*Flags, *raiden.Config) (*Resource, error)
+	migrate               func(*raiden.Config, *state.LocalState, string, *MigrateData) []error
+	buildRoleMigrate      func(state.ExtractRoleResult, []objects.Role) ([]roles.MigrateItem, error)
+	buildTableMigrate     func(state.ExtractTableResult, []objects.Table, []string) ([]tables.MigrateItem, error)
+	buildRpcMigrate       func(state.ExtractRpcResult, []objects.Function) ([]rpc.MigrateItem, error)
+	buildStorageMigrate   func(state.ExtractStorageResult, []objects.Bucket) ([]storages.MigrateItem, error)
+	buildPolicyMigrate    func(state.ExtractPolicyResult, []objects.Policy) ([]policies.MigrateItem, error)
+	buildTypeMigrate      func(state.ExtractTypeResult, []objects.Type) ([]types.MigrateItem, error)
+	buildPrivilegeMigrate func(state.ExtractColumnPrivilegeResult, []objects.ColumnPrivilege) ([]privileges.MigrateItem, error)
+	buildGrantMigrate     func(state.ExtractTablePrivilegeResult, []objects.TablePrivilege) ([]grants.MigrateItem, error)
+	printReport           func(MigrateData)
 }
 
 var defaultApplyDeps = applyDeps{
-	loadNativeRoles:     loadMapNativeRole,
-	loadState:           state.Load,
-	extractApp:          extractAppResource,
-	loadRemote:          Load,
-	migrate:             Migrate,
-	buildRoleMigrate:    roles.BuildMigrateData,
-	buildTableMigrate:   tables.BuildMigrateData,
-	buildRpcMigrate:     rpc.BuildMigrateData,
-	buildStorageMigrate: storages.BuildMigrateData,
-	buildPolicyMigrate:  policies.BuildMigrateData,
-	buildTypeMigrate:    types.BuildMigrateData,
-	printReport:         PrintApplyChangeReport,
+	loadNativeRoles:       loadMapNativeRole,
+	loadState:             state.Load,
+	extractApp:            extractAppResource,
+	loadRemote:            Load,
+	migrate:               Migrate,
+	buildRoleMigrate:      roles.BuildMigrateData,
+	buildTableMigrate:     tables.BuildMigrateData,
+	buildRpcMigrate:       rpc.BuildMigrateData,
+	buildStorageMigrate:   storages.BuildMigrateData,
+	buildPolicyMigrate:    policies.BuildMigrateData,
+	buildTypeMigrate:      types.BuildMigrateData,
+	buildPrivilegeMigrate: privileges.BuildMigrateData,
+	buildGrantMigrate:     grants.BuildMigrateData,
+	printReport:           PrintApplyChangeReport,
 }
 
 // applyJob encapsulates the sequential steps required to build and run a migration plan.
@@ -81,6 +90,8 @@ type applyJob struct {
 	appStorage       state.ExtractStorageResult
 	appTypes         state.ExtractTypeResult
 	appPolicies      state.ExtractPolicyResult
+	appPrivileges    state.ExtractColumnPrivilegeResult
+	appGrants        state.ExtractTablePrivilegeResult
 	resource         *Resource
 	migrateData      MigrateData
 	reportPrinted    bool
@@ -247,6 +258,8 @@ func (j *applyJob) extractAppResources() error {
 // buildPoliciesSnapshot merges table and storage policies to feed downstream diffing.
 func (j *applyJob) buildPoliciesSnapshot() {
 	j.appPolicies = mergeAllPolicy(j.appTables, j.appStorage)
+	j.appPrivileges = mergeAllColumnPrivilege(j.appTables)
+	j.appGrants = mergeAllTablePrivilege(j.appTables)
 }
 
 // validateLocalData checks local relations and policy role references before contacting Supabase.
@@ -264,6 +277,14 @@ func (j *applyJob) validateLocalData() error {
 		return err
 	}
 
+	if err := validateColumnPrivilegeRole(j.appPrivileges, j.appRoles, j.mapNativeRole); err != nil {
+		return err
+	}
+
+	if err := validateTablePrivilegeRole(j.appGrants, j.appRoles, j.mapNativeRole); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -353,6 +374,22 @@ func (j *applyJob) buildMigrateData() error {
 		j.migrateData.Types = data
 	}
 
+	if len(j.appPrivileges.New) > 0 || len(j.appPrivileges.Existing) > 0 || len(j.appPrivileges.Delete) > 0 {
+		data, err := j.deps.buildPrivilegeMigrate(j.appPrivileges, j.resource.ColumnPrivileges)
+		if err != nil {
+			return err
+		}
+		j.migrateData.ColumnPrivileges = data
+	}
+
+	if len(j.appGrants.New) > 0 || len(j.appGrants.Existing) > 0 || len(j.appGrants.Delete) > 0 {
+		data, err := j.deps.buildGrantMigrate(j.appGrants, j.resource.TablePrivileges)
+		if err != nil {
+			return err
+		}
+		j.migrateData.TablePrivileges = data
+	}
+
 	ApplyLogger.Info("finish build migrate data")
 	return nil
 }
@@ -470,6 +507,31 @@ func Migrate(config *raiden.Config, importState *state.LocalState, projectPath s
 		}(&wg, errChan)
 	}
 
+	if len(resource.TablePrivileges) > 0 || len(resource.ColumnPrivileges) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan []error) {
+			defer w.Done()
+
+			// revoke table privilege also revoke column privilege,
+			// so table privilege must be migrated before column privilege
+			if len(resource.TablePrivileges) > 0 {
+				errors := grants.Migrate(config, resource.TablePrivileges, stateChan, grants.ActionFunc)
+				if len(errors) > 0 {
+					eChan <- errors
+					return
+				}
+			}
+
+			if len(resource.ColumnPrivileges) > 0 {
+				errors := privileges.Migrate(config, resource.ColumnPrivileges, stateChan, privileges.ActionFunc)
+				if len(errors) > 0 {
+					eChan <- errors
+					return
+				}
+			}
+		}(&wg, errChan)
+	}
+
 	if len(resource.Storages) > 0 {
 		wg.Add(1)
 		go func(w *sync.WaitGroup, eChan chan []error) {
@@ -619,6 +681,92 @@ func mergeAllPolicy(et state.ExtractTableResult, es state.ExtractStorageResult)
 	return
 }
 
+func mergeAllColumnPrivilege(et state.ExtractTableResult) (rs state.ExtractColumnPrivilegeResult) {
+	for i := range et.New {
+		t := et.New[i]
+		rs.New = append(rs.New, t.ExtractedColumnPrivileges.New...)
+	}
+
+	for i := range et.Existing {
+		t := et.Existing[i]
+		rs.New = append(rs.New, t.ExtractedColumnPrivileges.New...)
+		rs.Existing = append(rs.Existing, t.ExtractedColumnPrivileges.Existing...)
+		rs.Delete = append(rs.Delete, t.ExtractedColumnPrivileges.Delete...)
+	}
+
+	for i := range et.Delete {
+		t := et.Delete[i]
+		rs.Delete = append(rs.Delete, t.ExtractedColumnPrivileges.Delete...)
+	}
+
+	return
+}
+
+func mergeAllTablePrivilege(et state.ExtractTableResult) (rs state.ExtractTablePrivilegeResult) {
+	for i := range et.New {
+		t := et.New[i]
+		rs.New = append(rs.New, t.ExtractedTablePrivileges.New...)
+	}
+
+	for i := range et.Existing {
+		t := et.Existing[i]
+		rs.New = append(rs.New, t.ExtractedTablePrivileges.New...)
+		rs.Existing = append(rs.Existing, t.ExtractedTablePrivileges.Existing...)
+		rs.Delete = append(rs.Delete, t.ExtractedTablePrivileges.Delete...)
+	}
+
+	for i := range et.Delete {
+		t := et.Delete[i]
+		rs.Delete = append(rs.Delete, t.ExtractedTablePrivileges.Delete...)
+	}
+
+	return
+}
+
+func mapAvailableRole(appRoles state.ExtractRoleResult, nativeRole map[string]raiden.Role) map[string]bool {
+	mapRole := make(map[string]bool)
+	for _, r := range appRoles.New {
+		mapRole[r.Name] = true
+	}
+	for _, r := range appRoles.Existing {
+		mapRole[r.Name] = true
+	}
+	for _, r := range nativeRole {
+		mapRole[r.Name()] = true
+	}
+	return mapRole
+}
+
+func validateTablePrivilegeRole(appGrants state.ExtractTablePrivilegeResult, appRoles state.ExtractRoleResult, nativeRole map[string]raiden.Role) error {
+	mapRole := mapAvailableRole(appRoles, nativeRole)
+
+	var allPrivileges []objects.TablePrivilege
+	allPrivileges = append(allPrivileges, appGrants.New...)
+	allPrivileges = append(allPrivileges, appGrants.Existing...)
+	for _, p := range allPrivileges {
+		if !mapRole[p.Role] {
+			return fmt.Errorf("table %s privilege : role %s is not exist", p.Table, p.Role)
+		}
+	}
+
+	return nil
+}
+
+func validateColumnPrivilegeRole(appPrivileges state.ExtractColumnPrivilegeResult, appRoles state.ExtractRoleResult, nativeRole map[string]raiden.Role) error {
+	mapRole := mapAvailableRole(appRoles, nativeRole)
+
+	var allPrivileges []objects.ColumnPrivilege
+	allPrivileges = append(allPrivileges, appPrivileges.New...)
+	allPrivileges = append(allPrivileges, appPrivileges.Existing...)
+	for _, p := range allPrivileges {
+		if !mapRole[p.Role] {
+			return fmt.Errorf("table %s column privilege : role %s is not exist", p.Table, p.Role)
+		}
+	}
+
+	return nil
+}
+
 func validateRoleIsExist(appPolicies state.ExtractPolicyResult, appRoles state.ExtractRoleResult, nativeRole map[string]raiden.Role) error {
 	// prepare role data
 	var allRoles []objects.Role
@@ -865,6 +1013,78 @@ func UpdateLocalStateFromApply(projectPath string, localState *state.LocalState,
 					}
 
 				}
+			case *privileges.MigrateItem:
+				var privilege objects.ColumnPrivilege
+				switch m.Type {
+				case migrator.MigrateTypeCreate, migrator.MigrateTypeUpdate:
+					privilege = m.NewData
+				case migrator.MigrateTypeDelete:
+					privilege = m.OldData
+				default:
+					continue
+				}
+
+				fIndex, tState, found := localState.FindTableByName(privilege.Schema, privilege.Table)
+				if !found {
+					continue
+				}
+
+				pi := -1
+				for i := range tState.ColumnPrivileges {
+					if tState.ColumnPrivileges[i].Key() == privilege.Key() {
+						pi = i
+						break
+					}
+				}
+
+				switch {
+				case m.Type == migrator.MigrateTypeDelete && pi > -1:
+					tState.ColumnPrivileges = append(tState.ColumnPrivileges[:pi], tState.ColumnPrivileges[pi+1:]...)
+				case m.Type == migrator.MigrateTypeDelete:
+					continue
+				case pi > -1:
+					tState.ColumnPrivileges[pi] = privilege
+				default:
+					tState.ColumnPrivileges = append(tState.ColumnPrivileges, privilege)
+				}
+				tState.LastUpdate = time.Now()
+				localState.UpdateTable(fIndex, tState)
+			case *grants.MigrateItem:
+				var privilege objects.TablePrivilege
+				switch m.Type {
+				case migrator.MigrateTypeCreate, migrator.MigrateTypeUpdate:
+					privilege = m.NewData
+				case migrator.MigrateTypeDelete:
+					privilege = m.OldData
+				default:
+					continue
+				}
+
+				fIndex, tState, found := localState.FindTableByName(privilege.Schema, privilege.Table)
+				if !found {
+					continue
+				}
+
+				pi := -1
+				for i := range tState.TablePrivileges {
+					if tState.TablePrivileges[i].Key() == privilege.Key() {
+						pi = i
+						break
+					}
+				}
+
+				switch {
+				case m.Type == migrator.MigrateTypeDelete && pi > -1:
+					tState.TablePrivileges = append(tState.TablePrivileges[:pi], tState.TablePrivileges[pi+1:]...)
+				case m.Type == migrator.MigrateTypeDelete:
+					continue
+				case pi > -1:
+					tState.TablePrivileges[pi] = privilege
+				default:
+					tState.TablePrivileges = append(tState.TablePrivileges, privilege)
+				}
+				tState.LastUpdate = time.Now()
+				localState.UpdateTable(fIndex, tState)
 			case *rpc.MigrateItem:
 				switch m.Type {
 				case migrator.MigrateTypeCreate:
@@ -977,6 +1197,16 @@ func PrintApplyChangeReport(migrateData MigrateData) {
 		diffMessage = append(diffMessage, diffPolicy)
 	}
 
+	diffGrant := grants.GetDiffChangeMessage(migrateData.TablePrivileges)
+	if len(diffGrant) > 0 {
+		diffMessage = append(diffMessage, diffGrant)
+	}
+
+	diffPrivilege := privileges.GetDiffChangeMessage(migrateData.ColumnPrivileges)
+	if len(diffPrivilege) > 0 {
+		diffMessage = append(diffMessage, diffPrivilege)
+	}
+
 	diffRole := roles.GetDiffChangeMessage(migrateData.Roles)
 	if len(diffRole) > 0 {
 		diffMessage = append(diffMessage, diffRole)
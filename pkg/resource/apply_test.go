@@ -191,6 +191,12 @@ func TestApply(t *testing.T) {
 	errMembership := mock.MockGetRoleMembershipsWithExpectedResponse(200, []objects.RoleMembership{})
 	assert.NoError(t, errMembership)
 
+	errPrivilege := mock.MockGetColumnPrivilegesWithExpectedResponse(200, []objects.ColumnPrivilege{})
+	assert.NoError(t, errPrivilege)
+
+	errGrant := mock.MockGetTablePrivilegesWithExpectedResponse(200, []objects.TablePrivilege{})
+	assert.NoError(t, errGrant)
+
 	err1 := mock.MockGetBucketsWithExpectedResponse(200, []objects.Bucket{})
 	assert.NoError(t, err1)
 
@@ -316,6 +322,12 @@ func TestApply_AllowedTable(t *testing.T) {
 	errMembership := mock.MockGetRoleMembershipsWithExpectedResponse(200, []objects.RoleMembership{})
 	assert.NoError(t, errMembership)
 
+	errPrivilege := mock.MockGetColumnPrivilegesWithExpectedResponse(200, []objects.ColumnPrivilege{})
+	assert.NoError(t, errPrivilege)
+
+	errGrant := mock.MockGetTablePrivilegesWithExpectedResponse(200, []objects.TablePrivilege{})
+	assert.NoError(t, errGrant)
+
 	err1 := mock.MockGetBucketsWithExpectedResponse(200, []objects.Bucket{})
 	assert.NoError(t, err1)
 
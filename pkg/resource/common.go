@@ -9,6 +9,7 @@ import (
 	"github.com/sev-2/raiden/pkg/cli/configure"
 	"github.com/sev-2/raiden/pkg/cli/generate"
 	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres/roles"
 	"github.com/sev-2/raiden/pkg/state"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 	"github.com/spf13/cobra"
@@ -78,6 +79,7 @@ var registeredTypes []raiden.Type
 
 func RegisterTypes(list ...raiden.Type) {
 	registeredTypes = append(registeredTypes, list...)
+	raiden.RegisterType(list...)
 }
 
 // ----- Handle register models -----
@@ -199,6 +201,26 @@ func filterUserRole(roles []objects.Role, mapNativeRole map[string]raiden.Role)
 	return
 }
 
+// filterManagedTablePrivilege keep privilege granted to api role (anon and authenticated)
+// and user defined role, privilege of other supabase internal role is not managed by raiden
+func filterManagedTablePrivilege(privileges objects.TablePrivileges, userRoles []objects.Role) (managed objects.TablePrivileges) {
+	mapRole := map[string]bool{
+		(&roles.Anon{}).Name():          true,
+		(&roles.Authenticated{}).Name(): true,
+	}
+	for i := range userRoles {
+		mapRole[userRoles[i].Name] = true
+	}
+
+	for i := range privileges {
+		p := privileges[i]
+		if mapRole[p.Role] && len(p.Privileges) > 0 {
+			managed = append(managed, p)
+		}
+	}
+	return
+}
+
 func filterIsNativeRole(mapNativeRole map[string]raiden.Role, supabaseRole []objects.Role) (nativeRoles []state.RoleState) {
 	for i := range supabaseRole {
 		r := supabaseRole[i]
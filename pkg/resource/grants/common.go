@@ -0,0 +1,46 @@
+package grants
+
+import (
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// privilegeOrder is order of privilege in report and generated code
+var privilegeOrder = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+func grantName(p objects.TablePrivilege) string {
+	return p.Schema + "." + p.Table + " for " + p.Role
+}
+
+// normalizePrivileges return uppercase privilege in privilegeOrder
+func normalizePrivileges(privileges []string) []string {
+	exist := make(map[string]bool, len(privileges))
+	for _, p := range privileges {
+		exist[strings.ToUpper(strings.TrimSpace(p))] = true
+	}
+
+	normalized := make([]string, 0, len(privileges))
+	for _, p := range privilegeOrder {
+		if exist[p] {
+			normalized = append(normalized, p)
+		}
+	}
+	return normalized
+}
+
+// difference return privilege in a that is not exist in b
+func difference(a, b []string) []string {
+	exist := make(map[string]bool, len(b))
+	for _, p := range normalizePrivileges(b) {
+		exist[p] = true
+	}
+
+	var result []string
+	for _, p := range normalizePrivileges(a) {
+		if !exist[p] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
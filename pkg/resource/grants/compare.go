@@ -0,0 +1,56 @@
+package grants
+
+import (
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func Compare(sourcePrivileges, targetPrivileges []objects.TablePrivilege) error {
+	diffResult := CompareList(sourcePrivileges, targetPrivileges)
+	if len(diffResult) > 0 {
+		return PrintDiffResult(diffResult)
+	}
+	return nil
+}
+
+type CompareDiffResult struct {
+	Name           string
+	SourceResource objects.TablePrivilege
+	TargetResource objects.TablePrivilege
+	DiffItems      objects.UpdateTablePrivilegeParam
+	IsConflict     bool
+}
+
+func CompareList(sourcePrivileges, targetPrivileges []objects.TablePrivilege) (diffResult []CompareDiffResult) {
+	mapTargetPrivileges := make(map[string]objects.TablePrivilege)
+	for i := range targetPrivileges {
+		r := targetPrivileges[i]
+		mapTargetPrivileges[r.Key()] = r
+	}
+
+	for i := range sourcePrivileges {
+		p := sourcePrivileges[i]
+
+		tp, isExist := mapTargetPrivileges[p.Key()]
+		if !isExist {
+			continue
+		}
+		diffResult = append(diffResult, CompareItem(p, tp))
+	}
+
+	return
+}
+
+func CompareItem(source, target objects.TablePrivilege) (diffResult CompareDiffResult) {
+	diffResult.Name = grantName(source)
+	diffResult.SourceResource = source
+	diffResult.TargetResource = target
+
+	updateItem := objects.UpdateTablePrivilegeParam{
+		GrantPrivileges:  difference(source.Privileges, target.Privileges),
+		RevokePrivileges: difference(target.Privileges, source.Privileges),
+	}
+
+	diffResult.IsConflict = len(updateItem.GrantPrivileges) > 0 || len(updateItem.RevokePrivileges) > 0
+	diffResult.DiffItems = updateItem
+	return
+}
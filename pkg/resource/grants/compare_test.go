@@ -0,0 +1,38 @@
+package grants_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/grants"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareItem(t *testing.T) {
+	local := objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT"}}
+
+	rs := grants.CompareItem(local, objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"insert", "SELECT"}})
+	assert.False(t, rs.IsConflict)
+
+	rs = grants.CompareItem(local, objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "UPDATE", "DELETE"}})
+	assert.True(t, rs.IsConflict)
+	assert.Equal(t, []string{"INSERT"}, rs.DiffItems.GrantPrivileges)
+	assert.Equal(t, []string{"UPDATE", "DELETE"}, rs.DiffItems.RevokePrivileges)
+}
+
+func TestCompare(t *testing.T) {
+	remote := []objects.TablePrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+		{Schema: "public", Table: "articles", Role: "authenticated", Privileges: []string{"SELECT"}},
+	}
+
+	err := grants.Compare(remote, []objects.TablePrivilege{
+		{Schema: "public", Table: "articles", Role: "authenticated", Privileges: []string{"SELECT"}},
+	})
+	assert.NoError(t, err)
+
+	err = grants.Compare(remote, []objects.TablePrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package grants
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type MigrateItem = migrator.MigrateItem[objects.TablePrivilege, objects.UpdateTablePrivilegeParam]
+type MigrateActionFunc = migrator.MigrateActionFunc[objects.TablePrivilege, objects.UpdateTablePrivilegeParam]
+
+var ActionFunc = MigrateActionFunc{
+	CreateFunc: supabase.CreateTablePrivilege,
+	UpdateFunc: supabase.UpdateTablePrivilege,
+	DeleteFunc: supabase.DeleteTablePrivilege,
+}
+
+func BuildMigrateData(extractedLocalData state.ExtractTablePrivilegeResult, supabaseData []objects.TablePrivilege) (migrateData []MigrateItem, err error) {
+	Logger.Info("start build table privilege migrate data")
+	mapSpPrivileges := make(map[string]objects.TablePrivilege)
+	for i := range supabaseData {
+		p := supabaseData[i]
+		mapSpPrivileges[p.Key()] = p
+	}
+
+	Logger.Debug("filter extracted data for update and create table privilege")
+	var comparePrivileges []objects.TablePrivilege
+	localPrivileges := append([]objects.TablePrivilege{}, extractedLocalData.Existing...)
+	localPrivileges = append(localPrivileges, extractedLocalData.New...)
+	for i := range localPrivileges {
+		p := localPrivileges[i]
+		if _, isExist := mapSpPrivileges[p.Key()]; isExist {
+			comparePrivileges = append(comparePrivileges, p)
+			continue
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: p,
+		})
+	}
+
+	migrateData = append(migrateData, BuildMigrateItem(supabaseData, comparePrivileges)...)
+
+	Logger.Debug("filter delete table privilege data")
+	for i := range extractedLocalData.Delete {
+		p := extractedLocalData.Delete[i]
+
+		// revoke privilege currently granted in database
+		sp, isExist := mapSpPrivileges[p.Key()]
+		if !isExist || len(sp.Privileges) == 0 {
+			continue
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeDelete,
+			OldData: sp,
+		})
+	}
+	Logger.Info("finish build table privilege migrate data")
+	return
+}
+
+func BuildMigrateItem(supabaseData, localData []objects.TablePrivilege) (migrateData []MigrateItem) {
+	Logger.Info("compare supabase and local resource for existing table privilege data")
+	result := CompareList(localData, supabaseData)
+	for i := range result {
+		r := result[i]
+		migrateType := migrator.MigrateTypeIgnore
+		if r.IsConflict {
+			migrateType = migrator.MigrateTypeUpdate
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:           migrateType,
+			NewData:        r.SourceResource,
+			OldData:        r.TargetResource,
+			MigrationItems: r.DiffItems,
+		})
+	}
+	return
+}
+
+func Migrate(config *raiden.Config, privileges []MigrateItem, stateChan chan any, actions MigrateActionFunc) []error {
+	return migrator.MigrateResource(config, privileges, stateChan, actions, migrator.DefaultMigrator)
+}
@@ -0,0 +1,61 @@
+package grants_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/grants"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMigrateData(t *testing.T) {
+	extracted := state.ExtractTablePrivilegeResult{
+		New: []objects.TablePrivilege{
+			{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT"}},
+			{Schema: "public", Table: "articles", Role: "editor", Privileges: []string{"SELECT", "UPDATE"}},
+		},
+		Existing: []objects.TablePrivilege{
+			{Schema: "public", Table: "articles", Role: "authenticated", Privileges: []string{"SELECT", "INSERT"}},
+		},
+		Delete: []objects.TablePrivilege{
+			{Schema: "public", Table: "articles", Role: "reviewer", Privileges: []string{"SELECT"}},
+			{Schema: "public", Table: "articles", Role: "auditor", Privileges: []string{"SELECT"}},
+		},
+	}
+	remote := []objects.TablePrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+		{Schema: "public", Table: "articles", Role: "authenticated", Privileges: []string{"SELECT", "INSERT"}},
+		{Schema: "public", Table: "articles", Role: "reviewer", Privileges: []string{"SELECT", "UPDATE"}},
+	}
+
+	items, err := grants.BuildMigrateData(extracted, remote)
+	assert.NoError(t, err)
+
+	mapType := make(map[string]migrator.MigrateType)
+	for _, item := range items {
+		p := item.NewData
+		if item.Type == migrator.MigrateTypeDelete {
+			p = item.OldData
+		}
+		mapType[p.Key()] = item.Type
+	}
+
+	assert.Len(t, items, 4)
+	assert.Equal(t, migrator.MigrateTypeUpdate, mapType["public.articles.anon"])
+	assert.Equal(t, migrator.MigrateTypeCreate, mapType["public.articles.editor"])
+	assert.Equal(t, migrator.MigrateTypeIgnore, mapType["public.articles.authenticated"])
+	assert.Equal(t, migrator.MigrateTypeDelete, mapType["public.articles.reviewer"])
+
+	for _, item := range items {
+		switch item.Type {
+		case migrator.MigrateTypeUpdate:
+			assert.Equal(t, []string{"INSERT", "UPDATE", "DELETE"}, item.MigrationItems.RevokePrivileges)
+			assert.Empty(t, item.MigrationItems.GrantPrivileges)
+		case migrator.MigrateTypeDelete:
+			// revoke privilege currently granted in database
+			assert.Equal(t, []string{"SELECT", "UPDATE"}, item.OldData.Privileges)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package grants
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+)
+
+// ----- print diff section -----
+func PrintDiffResult(diffResult []CompareDiffResult) error {
+	if len(diffResult) == 0 {
+		return nil
+	}
+
+	isConflict := false
+	for i := range diffResult {
+		d := diffResult[i]
+		if d.IsConflict {
+			PrintDiff(d)
+			isConflict = true
+		}
+	}
+
+	if isConflict {
+		return errors.New("canceled import process, you have conflict in table privilege. please fix it first")
+	}
+
+	return nil
+}
+
+func PrintDiff(diffData CompareDiffResult) {
+	changes := diffChanges(diffData.TargetResource.Privileges, diffData.SourceResource.Privileges)
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Printf("*** Found diff in table privilege %s ***\n", diffData.Name)
+	fmt.Println(strings.Join(changes, "\n"))
+	fmt.Printf("*** End found diff ***\n")
+}
+
+func GetDiffChangeMessage(items []MigrateItem) string {
+	newData := []string{}
+	deleteData := []string{}
+	updateData := []string{}
+
+	for i := range items {
+		item := items[i]
+
+		switch item.Type {
+		case migrator.MigrateTypeCreate:
+			newData = append(newData, fmt.Sprintf("- %s : %s", grantName(item.NewData), privilegesValue(item.NewData.Privileges)))
+		case migrator.MigrateTypeUpdate:
+			changes := diffChanges(item.OldData.Privileges, item.NewData.Privileges)
+			updateData = append(updateData, fmt.Sprintf("- %s\n      %s", grantName(item.NewData), strings.Join(changes, "\n      ")))
+		case migrator.MigrateTypeDelete:
+			deleteData = append(deleteData, fmt.Sprintf("- %s : %s", grantName(item.OldData), privilegesValue(item.OldData.Privileges)))
+		}
+	}
+
+	changeMsg, err := GenerateDiffChangeMessage(newData, updateData, deleteData)
+	if err != nil {
+		Logger.Error("print change table privilege error", "msg", err.Error())
+		return ""
+	}
+	return changeMsg
+}
+
+func diffChanges(oldPrivileges, newPrivileges []string) []string {
+	oldValue, newValue := privilegesValue(oldPrivileges), privilegesValue(newPrivileges)
+	if oldValue == newValue {
+		return nil
+	}
+	return []string{fmt.Sprintf("- privileges : %s >>> %s", oldValue, newValue)}
+}
+
+func privilegesValue(privileges []string) string {
+	normalized := normalizePrivileges(privileges)
+	if len(normalized) == 0 {
+		return "unset"
+	}
+	return strings.Join(normalized, ", ")
+}
+
+// ----- diff change -----
+const DiffChangeTemplate = `
+  {{- if gt (len .NewData) 0}}
+  New Table Privilege
+  {{- range .NewData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .UpdateData) 0}}
+  Update Table Privilege
+  {{- range .UpdateData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .DeleteData) 0}}
+  Delete Table Privilege
+  {{- range .DeleteData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  `
+
+func GenerateDiffChangeMessage(newData []string, updateData []string, deleteData []string) (string, error) {
+	param := map[string]any{
+		"NewData":    newData,
+		"UpdateData": updateData,
+		"DeleteData": deleteData,
+	}
+
+	tmplInstance := template.New("generate diff change table privilege")
+	tmpl, err := tmplInstance.Parse(DiffChangeTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing : %v", err)
+	}
+
+	var buff bytes.Buffer
+	if err := tmpl.Execute(&buff, param); err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
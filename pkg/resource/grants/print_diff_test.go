@@ -0,0 +1,38 @@
+package grants_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/grants"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDiffChangeMessage(t *testing.T) {
+	items := []grants.MigrateItem{
+		{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: objects.TablePrivilege{Schema: "public", Table: "articles", Role: "editor", Privileges: []string{"UPDATE", "SELECT"}},
+		},
+		{
+			Type:    migrator.MigrateTypeUpdate,
+			NewData: objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT"}},
+			OldData: objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+		},
+		{
+			Type:    migrator.MigrateTypeDelete,
+			OldData: objects.TablePrivilege{Schema: "public", Table: "articles", Role: "reviewer", Privileges: []string{"SELECT"}},
+		},
+	}
+
+	msg := grants.GetDiffChangeMessage(items)
+	assert.Contains(t, msg, "New Table Privilege")
+	assert.Contains(t, msg, "- public.articles for editor : SELECT, UPDATE")
+	assert.Contains(t, msg, "Update Table Privilege")
+	assert.Contains(t, msg, "- privileges : SELECT, INSERT, UPDATE, DELETE >>> SELECT")
+	assert.Contains(t, msg, "Delete Table Privilege")
+	assert.Contains(t, msg, "- public.articles for reviewer : SELECT")
+
+	assert.Empty(t, grants.GetDiffChangeMessage(nil))
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/resource/grants"
+	"github.com/sev-2/raiden/pkg/resource/privileges"
 	"github.com/sev-2/raiden/pkg/resource/roles"
 	"github.com/sev-2/raiden/pkg/resource/rpc"
 	"github.com/sev-2/raiden/pkg/resource/storages"
@@ -24,18 +26,20 @@ import (
 var ImportLogger hclog.Logger = logger.HcLog().Named("import")
 
 type importDeps struct {
-	loadNativeRoles func() (map[string]raiden.Role, error)
-	loadRemote      func(*Flags, *raiden.Config) (*Resource, error)
-	loadState       func() (*state.State, error)
-	extractApp      func(*Flags, *state.State) (state.ExtractTableResult, state.ExtractRoleResult, state.ExtractRpcResult, state.ExtractStorageResult, state.ExtractTypeResult, error)
-	compareTypes    func([]objects.Type, []objects.Type) error
-	compareTables   func([]objects.Table, []objects.Table) error
-	compareRoles    func([]objects.Role, []objects.Role) error
-	compareRpc      func([]objects.Function, []objects.Function) error
-	compareStorages func([]objects.Bucket, []objects.Bucket) error
-	updateStateOnly func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error
-	generate        func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error
-	printReport     func(ImportReport, bool)
+	loadNativeRoles   func() (map[string]raiden.Role, error)
+	loadRemote        func(*Flags, *raiden.Config) (*Resource, error)
+	loadState         func() (*state.State, error)
+	extractApp        func(*Flags, *state.State) (state.ExtractTableResult, state.ExtractRoleResult, state.ExtractRpcResult, state.ExtractStorageResult, state.ExtractTypeResult, error)
+	compareTypes      func([]objects.Type, []objects.Type) error
+	compareTables     func([]objects.Table, []objects.Table) error
+	compareRoles      func([]objects.Role, []objects.Role) error
+	compareRpc        func([]objects.Function, []objects.Function) error
+	compareStorages   func([]objects.Bucket, []objects.Bucket) error
+	comparePrivileges func([]objects.ColumnPrivilege, []objects.ColumnPrivilege) error
+	compareGrants     func([]objects.TablePrivilege, []objects.TablePrivilege) error
+	updateStateOnly   func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error
+	generate          func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error
+	printReport       func(ImportReport, bool)
 }
 
 var defaultImportDeps = importDeps{
@@ -49,12 +53,14 @@ var defaultImportDeps = importDeps{
 	compareTables: func(remote []objects.Table, existing []objects.Table) error {
 		return tables.Compare(tables.CompareModeImport, remote, existing)
 	},
-	compareRoles:    roles.Compare,
-	compareRpc:      rpc.Compare,
-	compareStorages: storages.Compare,
-	updateStateOnly: updateStateOnly,
-	generate:        generateImportResource,
-	printReport:     PrintImportReport,
+	compareRoles:      roles.Compare,
+	compareRpc:        rpc.Compare,
+	compareStorages:   storages.Compare,
+	comparePrivileges: privileges.Compare,
+	compareGrants:     grants.Compare,
+	updateStateOnly:   updateStateOnly,
+	generate:          generateImportResource,
+	printReport:       PrintImportReport,
 }
 
 // importJob keeps the shared state and dependencies needed to process an import.
@@ -76,6 +82,7 @@ type importJob struct {
 	nativeStateRoles       []state.RoleState
 	dryRunErrors           []string
 	mapModelValidationTags map[string]state.ModelValidationTag
+	mapModelColumnOptions  map[string]state.ModelColumnOption
 	report                 ImportReport
 	reportComputed         bool
 	reportPrinted          bool
@@ -100,6 +107,7 @@ func runImport(flags *Flags, config *raiden.Config, deps importDeps) (err error)
 		deps:                   deps,
 		dryRunErrors:           []string{},
 		mapModelValidationTags: make(map[string]state.ModelValidationTag),
+		mapModelColumnOptions:  make(map[string]state.ModelColumnOption),
 	}
 
 	defer func() {
@@ -214,6 +222,9 @@ func (j *importJob) prepareRemoteResource() {
 
 	ImportLogger.Trace("remove native role for supabase list role")
 	j.resource.Roles = filterUserRole(j.resource.Roles, j.mapNativeRole)
+
+	ImportLogger.Trace("filter table privilege by managed role")
+	j.resource.TablePrivileges = filterManagedTablePrivilege(j.resource.TablePrivileges, j.resource.Roles)
 }
 
 func (j *importJob) loadLocalState() error {
@@ -252,7 +263,7 @@ func (j *importJob) extractAppResources() error {
 	return nil
 }
 
-// collectValidationTags records existing model validation tags so regeneration preserves them.
+// collectValidationTags records existing model validation tags and column options so regeneration preserves them.
 func (j *importJob) collectValidationTags() {
 	if !(j.flags.All() || j.flags.ModelsOnly) {
 		return
@@ -263,6 +274,9 @@ func (j *importJob) collectValidationTags() {
 		if nt.ValidationTags != nil {
 			j.mapModelValidationTags[nt.Table.Name] = nt.ValidationTags
 		}
+		if len(nt.ColumnOptions) > 0 {
+			j.mapModelColumnOptions[nt.Table.Name] = nt.ColumnOptions
+		}
 	}
 
 	for i := range j.appTables.Existing {
@@ -270,6 +284,9 @@ func (j *importJob) collectValidationTags() {
 		if et.ValidationTags != nil {
 			j.mapModelValidationTags[et.Table.Name] = et.ValidationTags
 		}
+		if len(et.ColumnOptions) > 0 {
+			j.mapModelColumnOptions[et.Table.Name] = et.ColumnOptions
+		}
 	}
 }
 
@@ -297,6 +314,12 @@ func (j *importJob) performComparisons() error {
 	if err := j.compareStorages(); err != nil {
 		return err
 	}
+	if err := j.compareColumnPrivileges(); err != nil {
+		return err
+	}
+	if err := j.compareTablePrivileges(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -455,6 +478,64 @@ func (j *importJob) compareStorages() error {
 	return nil
 }
 
+func (j *importJob) compareTablePrivileges() error {
+	if !(j.flags.All() || j.flags.ModelsOnly) || j.deps.compareGrants == nil {
+		return nil
+	}
+
+	var compareGrants []objects.TablePrivilege
+	for i := range j.appTables.Existing {
+		compareGrants = append(compareGrants, j.appTables.Existing[i].ExtractedTablePrivileges.Existing...)
+	}
+	if len(compareGrants) == 0 {
+		return nil
+	}
+
+	if !j.flags.DryRun {
+		ImportLogger.Debug("start compare table privilege")
+	}
+	if err := j.deps.compareGrants(j.resource.TablePrivileges, compareGrants); err != nil {
+		if j.flags.DryRun {
+			j.dryRunErrors = append(j.dryRunErrors, err.Error())
+			return nil
+		}
+		return err
+	}
+	if !j.flags.DryRun {
+		ImportLogger.Debug("finish compare table privilege")
+	}
+	return nil
+}
+
+func (j *importJob) compareColumnPrivileges() error {
+	if !(j.flags.All() || j.flags.ModelsOnly) || j.deps.comparePrivileges == nil {
+		return nil
+	}
+
+	var comparePrivileges []objects.ColumnPrivilege
+	for i := range j.appTables.Existing {
+		comparePrivileges = append(comparePrivileges, j.appTables.Existing[i].ExtractedColumnPrivileges.Existing...)
+	}
+	if len(comparePrivileges) == 0 {
+		return nil
+	}
+
+	if !j.flags.DryRun {
+		ImportLogger.Debug("start compare column privilege")
+	}
+	if err := j.deps.comparePrivileges(j.resource.ColumnPrivileges, comparePrivileges); err != nil {
+		if j.flags.DryRun {
+			j.dryRunErrors = append(j.dryRunErrors, err.Error())
+			return nil
+		}
+		return err
+	}
+	if !j.flags.DryRun {
+		ImportLogger.Debug("finish compare column privilege")
+	}
+	return nil
+}
+
 func (j *importJob) computeReport() {
 	j.report = ImportReport{
 		Role:    roles.GetNewCountData(j.resource.Roles, j.appRoles),
@@ -473,7 +554,7 @@ func (j *importJob) handleOutput() error {
 			return j.deps.updateStateOnly(&j.importState, j.resource, j.mapModelValidationTags)
 		}
 
-		if err := j.deps.generate(j.config, &j.importState, j.flags.ProjectPath, j.resource, j.mapModelValidationTags, j.flags.GenerateController); err != nil {
+		if err := j.deps.generate(j.config, &j.importState, j.flags.ProjectPath, j.resource, j.mapModelValidationTags, j.mapModelColumnOptions, j.flags.GenerateController); err != nil {
 			return err
 		}
 		j.printReport(false)
@@ -500,7 +581,7 @@ func (j *importJob) printReport(dryRun bool) {
 }
 
 // ----- Generate import data -----
-func generateImportResource(config *raiden.Config, importState *state.LocalState, projectPath string, resource *Resource, mapModelValidationTags map[string]state.ModelValidationTag, generateController bool) error {
+func generateImportResource(config *raiden.Config, importState *state.LocalState, projectPath string, resource *Resource, mapModelValidationTags map[string]state.ModelValidationTag, mapModelColumnOptions map[string]state.ModelColumnOption, generateController bool) error {
 	if err := generator.CreateInternalFolder(projectPath); err != nil {
 		return err
 	}
@@ -540,8 +621,22 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 			ImportLogger.Info("finish generate types")
 		}
 
+		var mapDataType = make(map[string]objects.Type)
+		for i := range resource.Types {
+			dataType := resource.Types[i]
+			mapDataType[dataType.Name] = dataType
+		}
+
 		if len(resource.Tables) > 0 {
 			tableInputs := tables.BuildGenerateModelInputs(resource.Tables, resource.Policies, mapModelValidationTags)
+			tables.AttachColumnOptions(tableInputs, mapModelColumnOptions)
+			tables.AttachColumnPrivileges(tableInputs, resource.ColumnPrivileges)
+			tables.AttachTablePrivileges(tableInputs, resource.TablePrivileges)
+			if config != nil && config.TenantColumn != "" {
+				for _, t := range tableInputs {
+					t.TenantColumn = config.TenantColumn
+				}
+			}
 			ImportLogger.Info("start generate tables")
 			captureFunc := ImportDecorateFunc(tableInputs, func(item *generator.GenerateModelInput, input generator.GenerateInput) bool {
 				if i, ok := input.BindData.(generator.GenerateModelData); ok {
@@ -552,12 +647,6 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 				return false
 			}, stateChan)
 
-			var mapDataType = make(map[string]objects.Type)
-			for i := range resource.Types {
-				dataType := resource.Types[i]
-				mapDataType[dataType.Name] = dataType
-			}
-
 			if err := generator.GenerateModels(projectPath, config.ProjectName, tableInputs, mapDataType, roleMap, nativeRoleMap, captureFunc); err != nil {
 				errChan <- err
 			}
@@ -599,7 +688,7 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 				}
 				return false
 			}, stateChan)
-			if errGenRpc := generator.GenerateRpc(projectPath, config.ProjectName, resource.Functions, resource.Tables, captureFunc); errGenRpc != nil {
+			if errGenRpc := generator.GenerateRpcWithTypes(projectPath, config.ProjectName, resource.Functions, resource.Tables, mapDataType, captureFunc); errGenRpc != nil {
 				errChan <- errGenRpc
 			}
 			ImportLogger.Info("finish generate functions")
@@ -644,14 +733,18 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 func updateStateOnly(importState *state.LocalState, resource *Resource, mapModelValidationTags map[string]state.ModelValidationTag) error {
 	if len(resource.Tables) > 0 {
 		tableInputs := tables.BuildGenerateModelInputs(resource.Tables, resource.Policies, mapModelValidationTags)
+		tables.AttachColumnPrivileges(tableInputs, resource.ColumnPrivileges)
+		tables.AttachTablePrivileges(tableInputs, resource.TablePrivileges)
 		for i := range tableInputs {
 			t := tableInputs[i]
 			importState.AddTable(state.TableState{
-				Table:       t.Table,
-				Relation:    t.Relations,
-				Policies:    t.Policies,
-				ModelStruct: utils.SnakeCaseToPascalCase(t.Table.Name),
-				LastUpdate:  time.Now(),
+				Table:            t.Table,
+				Relation:         t.Relations,
+				Policies:         t.Policies,
+				ColumnPrivileges: t.ColumnPrivileges,
+				TablePrivileges:  t.TablePrivileges,
+				ModelStruct:      utils.SnakeCaseToPascalCase(t.Table.Name),
+				LastUpdate:       time.Now(),
 			})
 		}
 	}
@@ -749,6 +842,9 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 						LastUpdate:  time.Now(),
 						Relation:    parseItem.Relations,
 						Policies:    parseItem.Policies,
+
+						ColumnPrivileges: parseItem.ColumnPrivileges,
+						TablePrivileges:  parseItem.TablePrivileges,
 					}
 					localState.AddTable(tableState)
 				case objects.Role:
@@ -96,7 +96,7 @@ func TestRunImport_GenerateSuccess(t *testing.T) {
 			t.Fatalf("unexpected updateStateOnly call")
 			return nil
 		},
-		generate: func(cfg *raiden.Config, ls *state.LocalState, projectPath string, res *Resource, tags map[string]state.ModelValidationTag, generateController bool) error {
+		generate: func(cfg *raiden.Config, ls *state.LocalState, projectPath string, res *Resource, tags map[string]state.ModelValidationTag, options map[string]state.ModelColumnOption, generateController bool) error {
 			called.generate = true
 			require.Equal(t, config, cfg)
 			require.Equal(t, "proj", projectPath)
@@ -151,7 +151,7 @@ func TestRunImport_DryRunWithError(t *testing.T) {
 		compareRpc:      func([]objects.Function, []objects.Function) error { return nil },
 		compareStorages: func([]objects.Bucket, []objects.Bucket) error { return nil },
 		updateStateOnly: func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error { return nil },
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			t.Fatalf("unexpected generate call")
 			return nil
 		},
@@ -198,7 +198,7 @@ func TestRunImport_ForceImportSkipsComparisons(t *testing.T) {
 			return nil
 		},
 		updateStateOnly: func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error { return nil },
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			return nil
 		},
 		printReport: func(ImportReport, bool) {},
@@ -230,7 +230,7 @@ func TestRunImport_UpdateStateOnly(t *testing.T) {
 			updateCalled = true
 			return nil
 		},
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			t.Fatalf("generate should not be called when updateStateOnly is set")
 			return nil
 		},
@@ -257,7 +257,7 @@ func TestRunImport_LoadNativeRoleError(t *testing.T) {
 		compareRpc:      func([]objects.Function, []objects.Function) error { return nil },
 		compareStorages: func([]objects.Bucket, []objects.Bucket) error { return nil },
 		updateStateOnly: func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error { return nil },
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			return nil
 		},
 		printReport: func(ImportReport, bool) {},
@@ -293,7 +293,7 @@ func TestRunImport_CompareTablesError(t *testing.T) {
 			t.Fatalf("should not update state")
 			return nil
 		},
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			t.Fatalf("should not generate")
 			return nil
 		},
@@ -342,7 +342,7 @@ func TestRunImport_CrossSchemaRelationsFiltered(t *testing.T) {
 		compareRpc:      func([]objects.Function, []objects.Function) error { return nil },
 		compareStorages: func([]objects.Bucket, []objects.Bucket) error { return nil },
 		updateStateOnly: func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error { return nil },
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			return nil
 		},
 		printReport: func(ImportReport, bool) {},
@@ -391,7 +391,7 @@ func TestRunImport_RpcStateCompleteStatementRestored(t *testing.T) {
 		},
 		compareStorages: func([]objects.Bucket, []objects.Bucket) error { return nil },
 		updateStateOnly: func(*state.LocalState, *Resource, map[string]state.ModelValidationTag) error { return nil },
-		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, bool) error {
+		generate: func(*raiden.Config, *state.LocalState, string, *Resource, map[string]state.ModelValidationTag, map[string]state.ModelColumnOption, bool) error {
 			return nil
 		},
 		printReport: func(ImportReport, bool) {},
@@ -25,6 +25,9 @@ type Resource struct {
 	Indexes         []objects.Index
 	RelationActions []objects.TablesRelationshipAction
 	Types           []objects.Type
+
+	ColumnPrivileges objects.ColumnPrivileges
+	TablePrivileges  objects.TablePrivileges
 }
 
 // The Load function loads resources based on the provided flags and project ID, and returns a resource
@@ -64,6 +67,12 @@ func Load(flags *Flags, cfg *raiden.Config) (*Resource, error) {
 		case []objects.Type:
 			resource.Types = rs
 			LoadLogger.Debug("finish get Type from server")
+		case objects.ColumnPrivileges:
+			resource.ColumnPrivileges = rs
+			LoadLogger.Debug("finish get Column Privilege from server")
+		case objects.TablePrivileges:
+			resource.TablePrivileges = rs
+			LoadLogger.Debug("finish get Table Privilege from server")
 		case error:
 			return nil, rs
 		}
@@ -213,7 +222,7 @@ func loadRolesAndMembership(wg *sync.WaitGroup, cfg *raiden.Config, outChan chan
 }
 
 func loadTableResources(wg *sync.WaitGroup, cfg *raiden.Config, outChan chan any, schemas []string) {
-	wg.Add(3)
+	wg.Add(5)
 
 	// Load tables
 	go loadDatabaseResource(wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Table, error) {
@@ -229,6 +238,16 @@ func loadTableResources(wg *sync.WaitGroup, cfg *raiden.Config, outChan chan any
 	go loadDatabaseResource(wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.TablesRelationshipAction, error) {
 		return supabase.GetTableRelationshipActions(cfg, schemas[0])
 	})
+
+	// Load column privileges
+	go loadDatabaseResource(wg, cfg, outChan, func(cfg *raiden.Config) (objects.ColumnPrivileges, error) {
+		return supabase.GetColumnPrivileges(cfg, schemas)
+	})
+
+	// Load table privileges
+	go loadDatabaseResource(wg, cfg, outChan, func(cfg *raiden.Config) (objects.TablePrivileges, error) {
+		return supabase.GetTablePrivileges(cfg, schemas)
+	})
 }
 
 func loadTypes(wg *sync.WaitGroup, cfg *raiden.Config, outChan chan any, schemas []string) {
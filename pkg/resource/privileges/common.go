@@ -0,0 +1,24 @@
+package privileges
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// IsManageable return true when privilege is granted to subset of column,
+// privilege granted to the whole table is not managed as column privilege
+func IsManageable(p objects.ColumnPrivilege) bool {
+	return !p.AllColumns && len(p.Columns) > 0
+}
+
+func privilegeName(p objects.ColumnPrivilege) string {
+	return strings.ToLower(p.Privilege) + " " + p.Schema + "." + p.Table + " for " + p.Role
+}
+
+func sortedColumns(columns []string) []string {
+	sorted := slices.Clone(columns)
+	slices.Sort(sorted)
+	return sorted
+}
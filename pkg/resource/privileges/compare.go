@@ -0,0 +1,73 @@
+package privileges
+
+import (
+	"slices"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func Compare(sourcePrivileges, targetPrivileges []objects.ColumnPrivilege) error {
+	diffResult := CompareList(sourcePrivileges, targetPrivileges)
+	if len(diffResult) > 0 {
+		return PrintDiffResult(diffResult)
+	}
+	return nil
+}
+
+type CompareDiffResult struct {
+	Name           string
+	SourceResource objects.ColumnPrivilege
+	TargetResource objects.ColumnPrivilege
+	DiffItems      objects.UpdateColumnPrivilegeParam
+	IsConflict     bool
+}
+
+func CompareList(sourcePrivileges, targetPrivileges []objects.ColumnPrivilege) (diffResult []CompareDiffResult) {
+	mapTargetPrivileges := make(map[string]objects.ColumnPrivilege)
+	for i := range targetPrivileges {
+		r := targetPrivileges[i]
+		mapTargetPrivileges[r.Key()] = r
+	}
+
+	for i := range sourcePrivileges {
+		p := sourcePrivileges[i]
+
+		tp, isExist := mapTargetPrivileges[p.Key()]
+		if !isExist {
+			continue
+		}
+		diffResult = append(diffResult, CompareItem(p, tp))
+	}
+
+	return
+}
+
+// CompareItem compare column of source and target privilege,
+// target privilege granted to the whole table must be revoked before column is granted
+func CompareItem(source, target objects.ColumnPrivilege) (diffResult CompareDiffResult) {
+	diffResult.Name = privilegeName(source)
+	diffResult.SourceResource = source
+	diffResult.TargetResource = target
+
+	updateItem := objects.UpdateColumnPrivilegeParam{}
+	if target.AllColumns {
+		updateItem.RevokeTablePrivilege = true
+		updateItem.GrantColumns = sortedColumns(source.Columns)
+	} else {
+		for _, c := range sortedColumns(source.Columns) {
+			if !slices.Contains(target.Columns, c) {
+				updateItem.GrantColumns = append(updateItem.GrantColumns, c)
+			}
+		}
+
+		for _, c := range sortedColumns(target.Columns) {
+			if !slices.Contains(source.Columns, c) {
+				updateItem.RevokeColumns = append(updateItem.RevokeColumns, c)
+			}
+		}
+	}
+
+	diffResult.IsConflict = source.AllColumns != target.AllColumns || len(updateItem.GrantColumns) > 0 || len(updateItem.RevokeColumns) > 0
+	diffResult.DiffItems = updateItem
+	return
+}
@@ -0,0 +1,44 @@
+package privileges_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/privileges"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareItem(t *testing.T) {
+	local := objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"title", "id"}}
+
+	rs := privileges.CompareItem(local, objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id", "title"}})
+	assert.False(t, rs.IsConflict)
+
+	rs = privileges.CompareItem(local, objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id", "body"}})
+	assert.True(t, rs.IsConflict)
+	assert.Equal(t, []string{"title"}, rs.DiffItems.GrantColumns)
+	assert.Equal(t, []string{"body"}, rs.DiffItems.RevokeColumns)
+	assert.False(t, rs.DiffItems.RevokeTablePrivilege)
+
+	rs = privileges.CompareItem(local, objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", AllColumns: true})
+	assert.True(t, rs.IsConflict)
+	assert.True(t, rs.DiffItems.RevokeTablePrivilege)
+	assert.Equal(t, []string{"id", "title"}, rs.DiffItems.GrantColumns)
+}
+
+func TestCompare(t *testing.T) {
+	remote := []objects.ColumnPrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", AllColumns: true},
+		{Schema: "public", Table: "articles", Role: "editor", Privilege: "UPDATE", Columns: []string{"title"}},
+	}
+
+	err := privileges.Compare(remote, []objects.ColumnPrivilege{
+		{Schema: "public", Table: "articles", Role: "editor", Privilege: "UPDATE", Columns: []string{"title"}},
+	})
+	assert.NoError(t, err)
+
+	err = privileges.Compare(remote, []objects.ColumnPrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id"}},
+	})
+	assert.Error(t, err)
+}
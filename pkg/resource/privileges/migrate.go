@@ -0,0 +1,88 @@
+package privileges
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type MigrateItem = migrator.MigrateItem[objects.ColumnPrivilege, objects.UpdateColumnPrivilegeParam]
+type MigrateActionFunc = migrator.MigrateActionFunc[objects.ColumnPrivilege, objects.UpdateColumnPrivilegeParam]
+
+var ActionFunc = MigrateActionFunc{
+	CreateFunc: supabase.CreateColumnPrivilege,
+	UpdateFunc: supabase.UpdateColumnPrivilege,
+	DeleteFunc: supabase.DeleteColumnPrivilege,
+}
+
+func BuildMigrateData(extractedLocalData state.ExtractColumnPrivilegeResult, supabaseData []objects.ColumnPrivilege) (migrateData []MigrateItem, err error) {
+	Logger.Info("start build column privilege migrate data")
+	mapSpPrivileges := make(map[string]objects.ColumnPrivilege)
+	for i := range supabaseData {
+		p := supabaseData[i]
+		mapSpPrivileges[p.Key()] = p
+	}
+
+	Logger.Debug("filter extracted data for update and create column privilege")
+	var comparePrivileges []objects.ColumnPrivilege
+	localPrivileges := append([]objects.ColumnPrivilege{}, extractedLocalData.Existing...)
+	localPrivileges = append(localPrivileges, extractedLocalData.New...)
+	for i := range localPrivileges {
+		p := localPrivileges[i]
+		if _, isExist := mapSpPrivileges[p.Key()]; isExist {
+			comparePrivileges = append(comparePrivileges, p)
+			continue
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: p,
+		})
+	}
+
+	migrateData = append(migrateData, BuildMigrateItem(supabaseData, comparePrivileges)...)
+
+	Logger.Debug("filter delete column privilege data")
+	for i := range extractedLocalData.Delete {
+		p := extractedLocalData.Delete[i]
+
+		// revoke column currently granted in database
+		sp, isExist := mapSpPrivileges[p.Key()]
+		if !isExist || !IsManageable(sp) {
+			continue
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeDelete,
+			OldData: sp,
+		})
+	}
+	Logger.Info("finish build column privilege migrate data")
+	return
+}
+
+func BuildMigrateItem(supabaseData, localData []objects.ColumnPrivilege) (migrateData []MigrateItem) {
+	Logger.Info("compare supabase and local resource for existing column privilege data")
+	result := CompareList(localData, supabaseData)
+	for i := range result {
+		r := result[i]
+		migrateType := migrator.MigrateTypeIgnore
+		if r.IsConflict {
+			migrateType = migrator.MigrateTypeUpdate
+		}
+
+		migrateData = append(migrateData, MigrateItem{
+			Type:           migrateType,
+			NewData:        r.SourceResource,
+			OldData:        r.TargetResource,
+			MigrationItems: r.DiffItems,
+		})
+	}
+	return
+}
+
+func Migrate(config *raiden.Config, privileges []MigrateItem, stateChan chan any, actions MigrateActionFunc) []error {
+	return migrator.MigrateResource(config, privileges, stateChan, actions, migrator.DefaultMigrator)
+}
@@ -0,0 +1,58 @@
+package privileges_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/resource/privileges"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMigrateData(t *testing.T) {
+	extracted := state.ExtractColumnPrivilegeResult{
+		New: []objects.ColumnPrivilege{
+			{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id", "title"}},
+			{Schema: "public", Table: "articles", Role: "editor", Privilege: "UPDATE", Columns: []string{"title"}},
+		},
+		Existing: []objects.ColumnPrivilege{
+			{Schema: "public", Table: "articles", Role: "editor", Privilege: "SELECT", Columns: []string{"id"}},
+		},
+		Delete: []objects.ColumnPrivilege{
+			{Schema: "public", Table: "articles", Role: "editor", Privilege: "INSERT", Columns: []string{"title"}},
+			{Schema: "public", Table: "articles", Role: "authenticated", Privilege: "SELECT", Columns: []string{"title"}},
+		},
+	}
+	remote := []objects.ColumnPrivilege{
+		{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", AllColumns: true},
+		{Schema: "public", Table: "articles", Role: "editor", Privilege: "SELECT", Columns: []string{"id"}},
+		{Schema: "public", Table: "articles", Role: "editor", Privilege: "INSERT", Columns: []string{"body", "title"}},
+		{Schema: "public", Table: "articles", Role: "authenticated", Privilege: "SELECT", AllColumns: true},
+	}
+
+	items, err := privileges.BuildMigrateData(extracted, remote)
+	assert.NoError(t, err)
+
+	mapType := make(map[string]migrator.MigrateType)
+	for _, item := range items {
+		p := item.NewData
+		if item.Type == migrator.MigrateTypeDelete {
+			p = item.OldData
+		}
+		mapType[p.Key()] = item.Type
+	}
+
+	assert.Len(t, items, 4)
+	assert.Equal(t, migrator.MigrateTypeUpdate, mapType["public.articles.anon.select"])
+	assert.Equal(t, migrator.MigrateTypeCreate, mapType["public.articles.editor.update"])
+	assert.Equal(t, migrator.MigrateTypeIgnore, mapType["public.articles.editor.select"])
+	assert.Equal(t, migrator.MigrateTypeDelete, mapType["public.articles.editor.insert"])
+
+	for _, item := range items {
+		if item.Type == migrator.MigrateTypeDelete {
+			// revoke column currently granted in database
+			assert.Equal(t, []string{"body", "title"}, item.OldData.Columns)
+		}
+	}
+}
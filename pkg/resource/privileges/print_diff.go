@@ -0,0 +1,134 @@
+package privileges
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ----- print diff section -----
+func PrintDiffResult(diffResult []CompareDiffResult) error {
+	if len(diffResult) == 0 {
+		return nil
+	}
+
+	isConflict := false
+	for i := range diffResult {
+		d := diffResult[i]
+		if d.IsConflict {
+			PrintDiff(d)
+			isConflict = true
+		}
+	}
+
+	if isConflict {
+		return errors.New("canceled import process, you have conflict in column privilege. please fix it first")
+	}
+
+	return nil
+}
+
+func PrintDiff(diffData CompareDiffResult) {
+	changes := diffChanges(diffData.TargetResource, diffData.SourceResource)
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Printf("*** Found diff in column privilege %s ***\n", diffData.Name)
+	fmt.Println(strings.Join(changes, "\n"))
+	fmt.Printf("*** End found diff ***\n")
+}
+
+func GetDiffChangeMessage(items []MigrateItem) string {
+	newData := []string{}
+	deleteData := []string{}
+	updateData := []string{}
+
+	for i := range items {
+		item := items[i]
+
+		switch item.Type {
+		case migrator.MigrateTypeCreate:
+			newData = append(newData, fmt.Sprintf("- %s : %s", privilegeName(item.NewData), strings.Join(item.NewData.Columns, ", ")))
+		case migrator.MigrateTypeUpdate:
+			changes := diffChanges(item.OldData, item.NewData)
+			updateData = append(updateData, fmt.Sprintf("- %s\n      %s", privilegeName(item.NewData), strings.Join(changes, "\n      ")))
+		case migrator.MigrateTypeDelete:
+			deleteData = append(deleteData, fmt.Sprintf("- %s : %s", privilegeName(item.OldData), strings.Join(item.OldData.Columns, ", ")))
+		}
+	}
+
+	changeMsg, err := GenerateDiffChangeMessage(newData, updateData, deleteData)
+	if err != nil {
+		Logger.Error("print change column privilege error", "msg", err.Error())
+		return ""
+	}
+	return changeMsg
+}
+
+func diffChanges(oldData, newData objects.ColumnPrivilege) []string {
+	oldValue, newValue := columnsValue(oldData), columnsValue(newData)
+	if oldValue == newValue {
+		return nil
+	}
+	return []string{fmt.Sprintf("- columns : %s >>> %s", oldValue, newValue)}
+}
+
+func columnsValue(p objects.ColumnPrivilege) string {
+	if p.AllColumns {
+		return "all column"
+	}
+
+	if len(p.Columns) == 0 {
+		return "unset"
+	}
+	return strings.Join(sortedColumns(p.Columns), ", ")
+}
+
+// ----- diff change -----
+const DiffChangeTemplate = `
+  {{- if gt (len .NewData) 0}}
+  New Column Privilege
+  {{- range .NewData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .UpdateData) 0}}
+  Update Column Privilege
+  {{- range .UpdateData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .DeleteData) 0}}
+  Delete Column Privilege
+  {{- range .DeleteData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  `
+
+func GenerateDiffChangeMessage(newData []string, updateData []string, deleteData []string) (string, error) {
+	param := map[string]any{
+		"NewData":    newData,
+		"UpdateData": updateData,
+		"DeleteData": deleteData,
+	}
+
+	tmplInstance := template.New("generate diff change column privilege")
+	tmpl, err := tmplInstance.Parse(DiffChangeTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing : %v", err)
+	}
+
+	var buff bytes.Buffer
+	if err := tmpl.Execute(&buff, param); err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
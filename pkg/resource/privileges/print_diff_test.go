@@ -0,0 +1,38 @@
+package privileges_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/resource/privileges"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDiffChangeMessage(t *testing.T) {
+	items := []privileges.MigrateItem{
+		{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "editor", Privilege: "UPDATE", Columns: []string{"title"}},
+		},
+		{
+			Type:    migrator.MigrateTypeUpdate,
+			NewData: objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id", "title"}},
+			OldData: objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", AllColumns: true},
+		},
+		{
+			Type:    migrator.MigrateTypeDelete,
+			OldData: objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "editor", Privilege: "INSERT", Columns: []string{"body"}},
+		},
+	}
+
+	msg := privileges.GetDiffChangeMessage(items)
+	assert.Contains(t, msg, "New Column Privilege")
+	assert.Contains(t, msg, "- update public.articles for editor : title")
+	assert.Contains(t, msg, "Update Column Privilege")
+	assert.Contains(t, msg, "- columns : all column >>> id, title")
+	assert.Contains(t, msg, "Delete Column Privilege")
+	assert.Contains(t, msg, "- insert public.articles for editor : body")
+
+	assert.Empty(t, privileges.GetDiffChangeMessage(nil))
+}
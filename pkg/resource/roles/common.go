@@ -42,6 +42,7 @@ func AttachInherithRole(mapNativeRole map[string]raiden.Role, supabaseData objec
 					continue
 				}
 
+				rc.AdminOption = ih.AdminOption
 				inheritCandidate = append(inheritCandidate, &rc)
 			}
 		}
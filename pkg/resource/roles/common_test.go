@@ -136,3 +136,21 @@ func TestAttachInherithRole_MixedMemberships(t *testing.T) {
 		assert.Equal(t, "parent_role", target.InheritRoles[0].Name)
 	}
 }
+
+func TestAttachInherithRole_AdminOption(t *testing.T) {
+	supabaseRoles := objects.Roles{
+		{ID: 1, Name: "editor"},
+		{ID: 2, Name: "author"},
+	}
+
+	roleMemberships := objects.RoleMemberships{
+		{ParentID: 2, ParentRole: "author", InheritID: 1, InheritRole: "editor", AdminOption: true},
+	}
+
+	result := roles.AttachInherithRole(map[string]raiden.Role{}, supabaseRoles, roleMemberships)
+	if assert.Len(t, result[0].InheritRoles, 1) {
+		assert.Equal(t, "author", result[0].InheritRoles[0].Name)
+		assert.True(t, result[0].InheritRoles[0].AdminOption)
+	}
+	assert.Empty(t, result[1].InheritRoles)
+}
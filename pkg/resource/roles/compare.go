@@ -118,51 +118,17 @@ func CompareItem(source, target objects.Role) (diffResult CompareDiffResult) {
 		updateItem.ChangeItems = append(updateItem.ChangeItems, objects.UpdateRoleInheritRole)
 	}
 
-	newInheritMap := make(map[string]objects.Role)
-	for i := range source.InheritRoles {
-		rolePtr := source.InheritRoles[i]
-		if rolePtr == nil {
-			continue
-		}
-
-		name := strings.TrimSpace(rolePtr.Name)
-		if name == "" {
-			continue
-		}
-
-		key := strings.ToLower(name)
-		if _, exist := newInheritMap[key]; exist {
-			continue
-		}
-
-		newInheritMap[key] = objects.Role{Name: name}
-	}
-
-	oldInheritMap := make(map[string]objects.Role)
-	for i := range target.InheritRoles {
-		rolePtr := target.InheritRoles[i]
-		if rolePtr == nil {
-			continue
-		}
-
-		name := strings.TrimSpace(rolePtr.Name)
-		if name == "" {
-			continue
-		}
-
-		key := strings.ToLower(name)
-		if _, exist := oldInheritMap[key]; exist {
-			continue
-		}
-
-		oldInheritMap[key] = objects.Role{Name: name}
-	}
+	newInheritMap := mapInheritRoles(source.InheritRoles)
+	oldInheritMap := mapInheritRoles(target.InheritRoles)
 
 	for name, role := range newInheritMap {
-		if _, exist := oldInheritMap[name]; exist {
-			continue
+		oldRole, exist := oldInheritMap[name]
+		switch {
+		case !exist, role.AdminOption && !oldRole.AdminOption:
+			updateItem.ChangeInheritItems = append(updateItem.ChangeInheritItems, objects.UpdateRoleInheritItem{Role: role, Type: objects.UpdateRoleInheritGrant})
+		case !role.AdminOption && oldRole.AdminOption:
+			updateItem.ChangeInheritItems = append(updateItem.ChangeInheritItems, objects.UpdateRoleInheritItem{Role: role, Type: objects.UpdateRoleInheritRevokeAdmin})
 		}
-		updateItem.ChangeInheritItems = append(updateItem.ChangeInheritItems, objects.UpdateRoleInheritItem{Role: role, Type: objects.UpdateRoleInheritGrant})
 	}
 
 	for name, role := range oldInheritMap {
@@ -197,3 +163,27 @@ func CompareItem(source, target objects.Role) (diffResult CompareDiffResult) {
 
 	return
 }
+
+// mapInheritRoles return unique inherit role keyed by lowercase name
+func mapInheritRoles(inheritRoles []*objects.Role) map[string]objects.Role {
+	inheritMap := make(map[string]objects.Role)
+	for i := range inheritRoles {
+		rolePtr := inheritRoles[i]
+		if rolePtr == nil {
+			continue
+		}
+
+		name := strings.TrimSpace(rolePtr.Name)
+		if name == "" {
+			continue
+		}
+
+		key := strings.ToLower(name)
+		if _, exist := inheritMap[key]; exist {
+			continue
+		}
+
+		inheritMap[key] = objects.Role{Name: name, AdminOption: rolePtr.AdminOption}
+	}
+	return inheritMap
+}
@@ -217,3 +217,38 @@ func TestCompareWithError(t *testing.T) {
 	assert.Error(t, err) // Should return error because of conflicts
 	assert.Contains(t, err.Error(), "canceled import process, you have conflict in role")
 }
+
+func TestCompareItem_InheritRolesAdminOption(t *testing.T) {
+	source := objects.Role{
+		ID:   1,
+		Name: "editor",
+		InheritRoles: []*objects.Role{
+			{Name: "author", AdminOption: true},
+			{Name: "reviewer"},
+			{Name: "viewer", AdminOption: true},
+		},
+	}
+
+	target := objects.Role{
+		ID:   1,
+		Name: "editor",
+		InheritRoles: []*objects.Role{
+			{Name: "author"},
+			{Name: "reviewer", AdminOption: true},
+			{Name: "viewer", AdminOption: true},
+		},
+	}
+
+	diffResult := roles.CompareItem(source, target)
+	assert.True(t, diffResult.IsConflict)
+
+	mapType := make(map[string]objects.UpdateRoleInheritItem)
+	for _, item := range diffResult.DiffItems.ChangeInheritItems {
+		mapType[item.Role.Name] = item
+	}
+
+	assert.Len(t, mapType, 2)
+	assert.Equal(t, objects.UpdateRoleInheritGrant, mapType["author"].Type)
+	assert.True(t, mapType["author"].Role.AdminOption)
+	assert.Equal(t, objects.UpdateRoleInheritRevokeAdmin, mapType["reviewer"].Type)
+}
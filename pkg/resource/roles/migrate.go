@@ -200,7 +200,7 @@ func buildGrantInheritItems(role objects.Role) []objects.UpdateRoleInheritItem {
 		unique[key] = struct{}{}
 
 		items = append(items, objects.UpdateRoleInheritItem{
-			Role: objects.Role{Name: name},
+			Role: objects.Role{Name: name, AdminOption: inherit.AdminOption},
 			Type: objects.UpdateRoleInheritGrant,
 		})
 	}
@@ -233,7 +233,7 @@ func cloneInheritRoles(inherits []*objects.Role) []*objects.Role {
 		}
 		unique[key] = struct{}{}
 
-		cloned = append(cloned, &objects.Role{Name: name})
+		cloned = append(cloned, &objects.Role{Name: name, AdminOption: r.AdminOption})
 	}
 
 	if len(cloned) == 0 {
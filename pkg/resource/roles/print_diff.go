@@ -241,12 +241,15 @@ func PrintDiff(diffData CompareDiffResult) {
 			case objects.UpdateRoleInheritRevoke:
 				diffType = DiffTypeDelete
 				action = "remove inherited role"
+			case objects.UpdateRoleInheritRevokeAdmin:
+				diffType = DiffTypeDelete
+				action = "remove admin option of inherited role"
 			default:
 				continue
 			}
 
 			symbol := getDiffSymbol(diffType)
-			changes = append(changes, fmt.Sprintf("%s %s %s for %s\n", symbol, action, item.Role.Name, roleName))
+			changes = append(changes, fmt.Sprintf("%s %s %s for %s\n", symbol, action, inheritRoleName(item), roleName))
 		}
 	}
 
@@ -273,6 +276,12 @@ func GetDiffChangeMessage(items []MigrateItem) string {
 		switch item.Type {
 		case migrator.MigrateTypeCreate:
 			newData = append(newData, fmt.Sprintf("- %s", name))
+			for _, ih := range item.MigrationItems.ChangeInheritItems {
+				if ih.Role.Name == "" {
+					continue
+				}
+				newData = append(newData, fmt.Sprintf("    - inherit role : %s", inheritRoleName(ih)))
+			}
 		case migrator.MigrateTypeUpdate:
 			diffMessage, err := GenerateDiffChangeUpdateMessage(name, item)
 			if err != nil {
@@ -519,9 +528,11 @@ func GenerateDiffChangeUpdateMessage(name string, item MigrateItem) (string, err
 
 		switch item.Type {
 		case objects.UpdateRoleInheritGrant:
-			changeInheritArr = append(changeInheritArr, fmt.Sprintf("- add inherited role : %s", item.Role.Name))
+			changeInheritArr = append(changeInheritArr, fmt.Sprintf("- add inherited role : %s", inheritRoleName(item)))
 		case objects.UpdateRoleInheritRevoke:
 			changeInheritArr = append(changeInheritArr, fmt.Sprintf("- remove inherited role : %s", item.Role.Name))
+		case objects.UpdateRoleInheritRevokeAdmin:
+			changeInheritArr = append(changeInheritArr, fmt.Sprintf("- remove admin option : %s", item.Role.Name))
 		}
 	}
 
@@ -544,3 +555,10 @@ func GenerateDiffChangeUpdateMessage(name string, item MigrateItem) (string, err
 
 	return buff.String(), nil
 }
+
+func inheritRoleName(item objects.UpdateRoleInheritItem) string {
+	if item.Type == objects.UpdateRoleInheritGrant && item.Role.AdminOption {
+		return item.Role.Name + " (with admin option)"
+	}
+	return item.Role.Name
+}
@@ -281,3 +281,36 @@ func TestGenerateDiffChangeMessage(t *testing.T) {
 	assert.Contains(t, diffMessage, "Update Role")
 	assert.Contains(t, diffMessage, "Delete Role")
 }
+
+func TestGetDiffChangeMessage_Membership(t *testing.T) {
+	items := []roles.MigrateItem{
+		{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: objects.Role{Name: "editor"},
+			MigrationItems: objects.UpdateRoleParam{
+				ChangeInheritItems: []objects.UpdateRoleInheritItem{
+					{Role: objects.Role{Name: "author", AdminOption: true}, Type: objects.UpdateRoleInheritGrant},
+				},
+			},
+		},
+		{
+			Type:    migrator.MigrateTypeUpdate,
+			NewData: objects.Role{Name: "reviewer"},
+			OldData: objects.Role{Name: "reviewer"},
+			MigrationItems: objects.UpdateRoleParam{
+				ChangeInheritItems: []objects.UpdateRoleInheritItem{
+					{Role: objects.Role{Name: "viewer"}, Type: objects.UpdateRoleInheritGrant},
+					{Role: objects.Role{Name: "author"}, Type: objects.UpdateRoleInheritRevokeAdmin},
+					{Role: objects.Role{Name: "guest"}, Type: objects.UpdateRoleInheritRevoke},
+				},
+			},
+		},
+	}
+
+	msg := roles.GetDiffChangeMessage(items)
+	assert.Contains(t, msg, "- editor")
+	assert.Contains(t, msg, "- inherit role : author (with admin option)")
+	assert.Contains(t, msg, "- add inherited role : viewer")
+	assert.Contains(t, msg, "- remove admin option : author")
+	assert.Contains(t, msg, "- remove inherited role : guest")
+}
@@ -250,3 +250,38 @@ func buildGenerateModelInput(mapTable MapTable, mapRelations MapRelations, polic
 	}
 	return generateInputs
 }
+
+// AttachColumnPrivileges bind column privilege of each table to generate input,
+// privilege granted to the whole table is skipped
+func AttachColumnPrivileges(inputs []*generator.GenerateModelInput, privileges objects.ColumnPrivileges) {
+	for _, input := range inputs {
+		for _, p := range privileges.FilterByTable(input.Table.Schema, input.Table.Name) {
+			if p.AllColumns || len(p.Columns) == 0 {
+				continue
+			}
+			input.ColumnPrivileges = append(input.ColumnPrivileges, p)
+		}
+	}
+}
+
+// AttachTablePrivileges bind table privilege per role to generate model input
+func AttachTablePrivileges(inputs []*generator.GenerateModelInput, privileges objects.TablePrivileges) {
+	for _, input := range inputs {
+		for _, p := range privileges.FilterByTable(input.Table.Schema, input.Table.Name) {
+			if len(p.Privileges) == 0 {
+				continue
+			}
+			input.TablePrivileges = append(input.TablePrivileges, p)
+		}
+	}
+}
+
+// AttachColumnOptions bind column option of existing model to generate model input,
+// so option that is not stored in database is kept after import
+func AttachColumnOptions(inputs []*generator.GenerateModelInput, mapModelColumnOptions map[string]state.ModelColumnOption) {
+	for _, input := range inputs {
+		if options, exist := mapModelColumnOptions[input.Table.Name]; exist && options != nil {
+			input.ColumnOptions = options
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package state
+
+import "github.com/sev-2/raiden/pkg/supabase/objects"
+
+type ExtractColumnPrivilegeResult struct {
+	Existing []objects.ColumnPrivilege
+	New      []objects.ColumnPrivilege
+	Delete   []objects.ColumnPrivilege
+}
+
+type ExtractTablePrivilegeResult struct {
+	Existing []objects.TablePrivilege
+	New      []objects.TablePrivilege
+	Delete   []objects.TablePrivilege
+}
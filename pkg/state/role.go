@@ -79,6 +79,7 @@ func BindToSupabaseRole(r *objects.Role, role raiden.Role) {
 				continue
 			}
 
+			inherit, adminOption := raiden.RoleAdminOption(inherit)
 			inheritName := inherit.Name()
 			if inheritName == "" {
 				iv := reflect.TypeOf(inherit)
@@ -99,7 +100,7 @@ func BindToSupabaseRole(r *objects.Role, role raiden.Role) {
 			}
 			inheritMap[inheritName] = struct{}{}
 
-			inheritRole := &objects.Role{Name: inheritName}
+			inheritRole := &objects.Role{Name: inheritName, AdminOption: adminOption}
 			r.InheritRoles = append(r.InheritRoles, inheritRole)
 		}
 	}
@@ -207,3 +207,27 @@ func TestExtractRoleResult_ToDeleteFlatMap(t *testing.T) {
 	assert.Equal(t, "role1", mapData["role1"].Name)
 	assert.Equal(t, "role2", mapData["role2"].Name)
 }
+
+type mockAdminRole struct {
+	raiden.RoleBase
+}
+
+func (r *mockAdminRole) Name() string {
+	return "admin_role"
+}
+
+func (r *mockAdminRole) InheritRoles() []raiden.Role {
+	return []raiden.Role{&MockChildRole{}, raiden.WithAdminOption(&mockUnnamedRole{})}
+}
+
+func TestBindToSupabaseRole_AdminOption(t *testing.T) {
+	var res objects.Role
+	state.BindToSupabaseRole(&res, &mockAdminRole{})
+
+	if assert.Len(t, res.InheritRoles, 2) {
+		assert.Equal(t, "child_role", res.InheritRoles[0].Name)
+		assert.False(t, res.InheritRoles[0].AdminOption)
+		assert.Equal(t, "mock_unnamed_role", res.InheritRoles[1].Name)
+		assert.True(t, res.InheritRoles[1].AdminOption)
+	}
+}
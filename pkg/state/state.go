@@ -33,6 +33,9 @@ type (
 		ModelStruct string
 		LastUpdate  time.Time
 		Policies    []objects.Policy
+
+		ColumnPrivileges []objects.ColumnPrivilege
+		TablePrivileges  []objects.TablePrivilege
 	}
 
 	RoleState struct {
@@ -136,6 +139,25 @@ func (s *LocalState) FindTable(tableId int) (index int, tableState TableState, f
 	return
 }
 
+func (s *LocalState) FindTableByName(schema, name string) (index int, tableState TableState, found bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	found = false
+
+	for i := range s.State.Tables {
+		t := s.State.Tables[i]
+
+		if strings.EqualFold(t.Table.Schema, schema) && strings.EqualFold(t.Table.Name, name) {
+			found = true
+			tableState = t
+			index = i
+			return
+		}
+	}
+	return
+}
+
 func (s *LocalState) UpdateTable(index int, state TableState) {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
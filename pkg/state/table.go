@@ -14,10 +14,18 @@ import (
 
 type ModelValidationTag map[string]string
 
+// ModelColumnOption is column tag option of model that is not stored in database,
+// for example softDelete or version, grouped by column name so import can keep it
+type ModelColumnOption map[string][]string
+
 type ExtractTableItem struct {
 	Table             objects.Table
 	ValidationTags    ModelValidationTag
+	ColumnOptions     ModelColumnOption
 	ExtractedPolicies ExtractPolicyResult
+
+	ExtractedColumnPrivileges ExtractColumnPrivilegeResult
+	ExtractedTablePrivileges  ExtractTablePrivilegeResult
 }
 
 type ExtractTableItems []ExtractTableItem
@@ -40,6 +48,8 @@ type tableBuilder struct {
 	existingRelations  map[string]objects.TablesRelationship
 	existingPrimaryKey map[string]objects.PrimaryKey
 	existingPolicies   map[string]objects.Policy
+	existingPrivileges map[string]objects.ColumnPrivilege
+	existingGrants     map[string]objects.TablePrivilege
 	acl                *raiden.Acl
 }
 
@@ -90,6 +100,12 @@ func ExtractTable(tableStates []TableState, appTable []any, mapDataType map[stri
 			ExtractedPolicies: ExtractPolicyResult{
 				Delete: deletedPolicy,
 			},
+			ExtractedColumnPrivileges: ExtractColumnPrivilegeResult{
+				Delete: v.ColumnPrivileges,
+			},
+			ExtractedTablePrivileges: ExtractTablePrivilegeResult{
+				Delete: v.TablePrivileges,
+			},
 		})
 	}
 
@@ -101,6 +117,8 @@ func buildTableFromModel(model any, mapDataType map[string]objects.Type) (ei Ext
 	b.processFields()
 	b.collectModelRelations()
 	b.applyPolicies()
+	b.applyColumnPrivileges()
+	b.applyTablePrivileges()
 	return b.finish()
 }
 
@@ -109,6 +127,8 @@ func buildTableFromState(model any, mapDataType map[string]objects.Type, state T
 	b.processFields()
 	b.collectStateRelations()
 	b.applyPolicies()
+	b.applyColumnPrivileges()
+	b.applyTablePrivileges()
 	return b.finish()
 }
 
@@ -121,11 +141,13 @@ func newTableBuilder(model any, mapDataType map[string]objects.Type, state *Tabl
 	b := &tableBuilder{
 		modelType:          modelType,
 		mapDataType:        mapDataType,
-		item:               ExtractTableItem{ValidationTags: make(ModelValidationTag)},
+		item:               ExtractTableItem{ValidationTags: make(ModelValidationTag), ColumnOptions: make(ModelColumnOption)},
 		existingColumns:    make(map[string]objects.Column),
 		existingRelations:  make(map[string]objects.TablesRelationship),
 		existingPrimaryKey: make(map[string]objects.PrimaryKey),
 		existingPolicies:   make(map[string]objects.Policy),
+		existingPrivileges: make(map[string]objects.ColumnPrivilege),
+		existingGrants:     make(map[string]objects.TablePrivilege),
 	}
 
 	b.item.Table.Name = raiden.GetTableName(model)
@@ -146,6 +168,12 @@ func newTableBuilder(model any, mapDataType map[string]objects.Type, state *Tabl
 		for _, p := range state.Policies {
 			b.existingPolicies[p.Name] = p
 		}
+		for _, p := range state.ColumnPrivileges {
+			b.existingPrivileges[p.Key()] = p
+		}
+		for _, p := range state.TablePrivileges {
+			b.existingGrants[p.Key()] = p
+		}
 	}
 
 	b.applyMetadata()
@@ -221,9 +249,32 @@ func (b *tableBuilder) collectColumn(field reflect.StructField) {
 		b.item.ValidationTags[column.Name] = vTag
 	}
 
+	if options := getColumnOptions(columnTag); len(options) > 0 {
+		b.item.ColumnOptions[column.Name] = options
+	}
+
 	b.columns = append(b.columns, column)
 }
 
+// columnSchemaTags is column tag key that is generated from database column
+var columnSchemaTags = map[string]bool{
+	"name": true, "type": true, "primaryKey": true, "autoIncrement": true,
+	"nullable": true, "default": true, "unique": true,
+}
+
+// getColumnOptions return column tag option that is not generated from database column
+func getColumnOptions(columnTag string) []string {
+	var options []string
+	for _, item := range strings.Split(columnTag, ";") {
+		key := strings.SplitN(item, ":", 2)[0]
+		if key == "" || columnSchemaTags[key] {
+			continue
+		}
+		options = append(options, item)
+	}
+	return options
+}
+
 func (b *tableBuilder) collectModelRelations() {
 	for i := 0; i < b.modelType.NumField(); i++ {
 		field := b.modelType.Field(i)
@@ -291,6 +342,58 @@ func (b *tableBuilder) applyPolicies() {
 
 }
 
+func (b *tableBuilder) applyColumnPrivileges() {
+	if b.acl == nil {
+		return
+	}
+
+	privileges, err := b.acl.BuildColumnPrivileges(b.item.Table.Schema, b.item.Table.Name)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	for _, p := range privileges {
+		if _, ok := b.existingPrivileges[p.Key()]; ok {
+			b.item.ExtractedColumnPrivileges.Existing = append(b.item.ExtractedColumnPrivileges.Existing, p)
+			delete(b.existingPrivileges, p.Key())
+			continue
+		}
+		b.item.ExtractedColumnPrivileges.New = append(b.item.ExtractedColumnPrivileges.New, p)
+	}
+}
+
+func (b *tableBuilder) applyTablePrivileges() {
+	var privileges objects.TablePrivileges
+	if b.acl != nil {
+		p, err := b.acl.BuildTablePrivileges(b.item.Table.Schema, b.item.Table.Name)
+		if err != nil {
+			panic(err.Error())
+		}
+		privileges = p
+	}
+
+	// grant of role that is not declared in model is not managed,
+	// keep it out of delete list so the role is not revoked from table
+	declaredRoles := make(map[string]bool)
+	for _, p := range privileges {
+		declaredRoles[p.Role] = true
+	}
+	for k, p := range b.existingGrants {
+		if !declaredRoles[p.Role] {
+			delete(b.existingGrants, k)
+		}
+	}
+
+	for _, p := range privileges {
+		if _, ok := b.existingGrants[p.Key()]; ok {
+			b.item.ExtractedTablePrivileges.Existing = append(b.item.ExtractedTablePrivileges.Existing, p)
+			delete(b.existingGrants, p.Key())
+			continue
+		}
+		b.item.ExtractedTablePrivileges.New = append(b.item.ExtractedTablePrivileges.New, p)
+	}
+}
+
 func (b *tableBuilder) finish() ExtractTableItem {
 	b.item.Table.Columns = b.columns
 	b.item.Table.Relationships = b.relations
@@ -302,6 +405,14 @@ func (b *tableBuilder) finish() ExtractTableItem {
 		}
 	}
 
+	for _, p := range b.existingPrivileges {
+		b.item.ExtractedColumnPrivileges.Delete = append(b.item.ExtractedColumnPrivileges.Delete, p)
+	}
+
+	for _, p := range b.existingGrants {
+		b.item.ExtractedTablePrivileges.Delete = append(b.item.ExtractedTablePrivileges.Delete, p)
+	}
+
 	return b.item
 }
 
@@ -558,6 +669,11 @@ func buildTableRelation(tableName, fieldName, schema string, mapRelations map[st
 	return
 }
 
+// GetModelAcl return acl of model after ConfigureAcl is called, nil is returned when model doesn't have Acl field
+func GetModelAcl(model any) *raiden.Acl {
+	return getAcl(model)
+}
+
 // get Acl
 func getAcl(model any) *raiden.Acl {
 	v := reflect.ValueOf(model)
@@ -127,3 +127,82 @@ func TestApplyPoliciesWithNoAcl(t *testing.T) {
 	require.NotPanics(t, func() { tb.applyPolicies() })
 	require.Empty(t, tb.item.ExtractedPolicies.New)
 }
+
+type grantModel struct {
+	Metadata string `schema:"public" tableName:"articles"`
+	ID       int    `column:"name:id;type:integer;primaryKey;nullable:false"`
+	Acl      raiden.Acl
+}
+
+func (m *grantModel) ConfigureAcl() {
+	m.Acl.Enable().GrantColumns(
+		raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon").Columns("id", "title"),
+		raiden.ColumnGrant(raiden.PrivilegeUpdate).For("editor").Columns("title"),
+	)
+}
+
+func TestApplyColumnPrivilegesWithExisting(t *testing.T) {
+	persisted := TableState{
+		Table: objects.Table{Name: "articles", Schema: "public"},
+		ColumnPrivileges: []objects.ColumnPrivilege{
+			{Schema: "public", Table: "articles", Role: "anon", Privilege: "SELECT", Columns: []string{"id"}},
+			{Schema: "public", Table: "articles", Role: "editor", Privilege: "INSERT", Columns: []string{"title"}},
+		},
+	}
+
+	result := buildTableFromState(&grantModel{}, nil, persisted)
+	require.Len(t, result.ExtractedColumnPrivileges.Existing, 1)
+	require.Equal(t, []string{"id", "title"}, result.ExtractedColumnPrivileges.Existing[0].Columns)
+	require.Len(t, result.ExtractedColumnPrivileges.New, 1)
+	require.Equal(t, "UPDATE", result.ExtractedColumnPrivileges.New[0].Privilege)
+	require.Len(t, result.ExtractedColumnPrivileges.Delete, 1)
+	require.Equal(t, "INSERT", result.ExtractedColumnPrivileges.Delete[0].Privilege)
+}
+
+type tableGrantModel struct {
+	Metadata string `schema:"public" tableName:"articles"`
+	ID       int    `column:"name:id;type:integer;primaryKey;nullable:false"`
+	Acl      raiden.Acl
+}
+
+func (m *tableGrantModel) ConfigureAcl() {
+	m.Acl.Enable().GrantTable(
+		raiden.TableGrant(raiden.CommandSelect).For("anon"),
+		raiden.TableGrant(raiden.CommandAll).For("editor"),
+	)
+}
+
+func TestApplyTablePrivilegesWithExisting(t *testing.T) {
+	persisted := TableState{
+		Table: objects.Table{Name: "articles", Schema: "public"},
+		TablePrivileges: []objects.TablePrivilege{
+			{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT"}},
+			{Schema: "public", Table: "articles", Role: "reviewer", Privileges: []string{"SELECT"}},
+		},
+	}
+
+	result := buildTableFromState(&tableGrantModel{}, nil, persisted)
+	require.Len(t, result.ExtractedTablePrivileges.Existing, 1)
+	require.Equal(t, []string{"SELECT"}, result.ExtractedTablePrivileges.Existing[0].Privileges)
+	require.Len(t, result.ExtractedTablePrivileges.New, 1)
+	require.Equal(t, "editor", result.ExtractedTablePrivileges.New[0].Role)
+	require.Equal(t, []string{"SELECT", "INSERT", "UPDATE", "DELETE"}, result.ExtractedTablePrivileges.New[0].Privileges)
+	// reviewer is not declared in model so the grant is not managed
+	require.Empty(t, result.ExtractedTablePrivileges.Delete)
+}
+
+func TestApplyTablePrivilegesWithoutGrant(t *testing.T) {
+	persisted := TableState{
+		Table: objects.Table{Name: "articles", Schema: "custom"},
+		TablePrivileges: []objects.TablePrivilege{
+			{Schema: "custom", Table: "articles", Role: "anon", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+			{Schema: "custom", Table: "articles", Role: "authenticated", Privileges: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}},
+		},
+	}
+
+	result := buildTableFromState(&aclModel{}, nil, persisted)
+	require.NotEmpty(t, result.ExtractedPolicies.New)
+	require.Empty(t, result.ExtractedTablePrivileges.New)
+	require.Empty(t, result.ExtractedTablePrivileges.Existing)
+	require.Empty(t, result.ExtractedTablePrivileges.Delete)
+}
@@ -0,0 +1,114 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetColumnPrivileges(cfg *raiden.Config, includedSchema []string) ([]objects.ColumnPrivilege, error) {
+	MetaLogger.Trace("start fetching column privileges from meta")
+	rs, err := ExecuteQuery[[]objects.ColumnPrivilege](getBaseUrl(cfg), sql.GenerateGetColumnPrivilegesQuery(includedSchema), nil, DefaultInterceptor(cfg), nil)
+	if err != nil {
+		err = fmt.Errorf("get column privileges error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching column privileges from meta")
+	return rs, err
+}
+
+func CreateColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege) (objects.ColumnPrivilege, error) {
+	MetaLogger.Trace("start grant column privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildGrantColumnPrivilegeQuery(privilege)
+	if err != nil {
+		return objects.ColumnPrivilege{}, err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return objects.ColumnPrivilege{}, fmt.Errorf("grant %s privilege on %s to %s error : %s", privilege.Privilege, privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish grant column privilege", "table", privilege.Table, "role", privilege.Role)
+	return privilege, nil
+}
+
+func UpdateColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege, param objects.UpdateColumnPrivilegeParam) error {
+	MetaLogger.Trace("start update column privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildUpdateColumnPrivilegeQuery(privilege, param)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return fmt.Errorf("update %s privilege on %s for %s error : %s", privilege.Privilege, privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish update column privilege", "table", privilege.Table, "role", privilege.Role)
+	return nil
+}
+
+func DeleteColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege) error {
+	MetaLogger.Trace("start revoke column privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildRevokeColumnPrivilegeQuery(privilege)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return fmt.Errorf("revoke %s privilege on %s from %s error : %s", privilege.Privilege, privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish revoke column privilege", "table", privilege.Table, "role", privilege.Role)
+	return nil
+}
+
+func GetTablePrivileges(cfg *raiden.Config, includedSchema []string) ([]objects.TablePrivilege, error) {
+	MetaLogger.Trace("start fetching table privileges from meta")
+	rs, err := ExecuteQuery[[]objects.TablePrivilege](getBaseUrl(cfg), sql.GenerateGetTablePrivilegesQuery(includedSchema), nil, DefaultInterceptor(cfg), nil)
+	if err != nil {
+		err = fmt.Errorf("get table privileges error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching table privileges from meta")
+	return rs, err
+}
+
+func CreateTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege) (objects.TablePrivilege, error) {
+	MetaLogger.Trace("start grant table privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildGrantTablePrivilegeQuery(privilege)
+	if err != nil {
+		return objects.TablePrivilege{}, err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return objects.TablePrivilege{}, fmt.Errorf("grant privilege on %s to %s error : %s", privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish grant table privilege", "table", privilege.Table, "role", privilege.Role)
+	return privilege, nil
+}
+
+func UpdateTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege, param objects.UpdateTablePrivilegeParam) error {
+	MetaLogger.Trace("start update table privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildUpdateTablePrivilegeQuery(privilege, param)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return fmt.Errorf("update privilege on %s for %s error : %s", privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish update table privilege", "table", privilege.Table, "role", privilege.Role)
+	return nil
+}
+
+func DeleteTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege) error {
+	MetaLogger.Trace("start revoke table privilege", "table", privilege.Table, "role", privilege.Role)
+	q, err := query.BuildRevokeTablePrivilegeQuery(privilege)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ExecuteQuery[any](getBaseUrl(cfg), q, nil, DefaultInterceptor(cfg), nil); err != nil {
+		return fmt.Errorf("revoke privilege on %s from %s error : %s", privilege.Table, privilege.Role, err)
+	}
+	MetaLogger.Trace("finish revoke table privilege", "table", privilege.Table, "role", privilege.Role)
+	return nil
+}
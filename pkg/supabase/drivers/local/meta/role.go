@@ -135,7 +135,7 @@ func updateRoleInheritances(cfg *raiden.Config, roleName string, items []objects
 		go func(w *sync.WaitGroup, inheritItem objects.UpdateRoleInheritItem) {
 			defer w.Done()
 
-			sql, err := query.BuildRoleInheritQuery(roleName, inheritItem.Role.Name, inheritItem.Type)
+			sql, err := query.BuildRoleInheritItemQuery(roleName, inheritItem)
 			if err != nil {
 				errChan <- err
 				return
@@ -144,8 +144,11 @@ func updateRoleInheritances(cfg *raiden.Config, roleName string, items []objects
 			_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, DefaultInterceptor(cfg), nil)
 			if err != nil {
 				action := "grant"
-				if inheritItem.Type == objects.UpdateRoleInheritRevoke {
+				switch inheritItem.Type {
+				case objects.UpdateRoleInheritRevoke:
 					action = "revoke"
+				case objects.UpdateRoleInheritRevokeAdmin:
+					action = "revoke admin option of"
 				}
 				errChan <- fmt.Errorf("%s role %s for %s error : %s", action, inheritItem.Role.Name, roleName, err)
 				return
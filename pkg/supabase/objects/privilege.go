@@ -0,0 +1,77 @@
+package objects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnPrivilege is privilege of role to subset of table column,
+// like `GRANT SELECT (id, title) ON public.articles TO anon`
+type ColumnPrivilege struct {
+	Schema    string   `json:"schema"`
+	Table     string   `json:"table"`
+	Role      string   `json:"role"`
+	Privilege string   `json:"privilege"`
+	Columns   []string `json:"columns"`
+
+	// AllColumns is true when privilege is granted to the table,
+	// so role can access every column regardless of column privilege
+	AllColumns bool `json:"all_columns"`
+}
+
+// Key return unique identifier of privilege, privilege of the same role and table is grouped by privilege type
+func (c ColumnPrivilege) Key() string {
+	return strings.ToLower(fmt.Sprintf("%s.%s.%s.%s", c.Schema, c.Table, c.Role, c.Privilege))
+}
+
+type ColumnPrivileges []ColumnPrivilege
+
+func (cp ColumnPrivileges) FilterByTable(schema, table string) ColumnPrivileges {
+	var filteredData ColumnPrivileges
+	for _, v := range cp {
+		if v.Schema == schema && v.Table == table {
+			filteredData = append(filteredData, v)
+		}
+	}
+	return filteredData
+}
+
+type UpdateColumnPrivilegeParam struct {
+	GrantColumns  []string
+	RevokeColumns []string
+
+	// RevokeTablePrivilege revoke privilege granted to the table,
+	// postgres revoke column privilege as well so every column in GrantColumns is granted again
+	RevokeTablePrivilege bool
+}
+
+// TablePrivilege is privilege of role to the whole table,
+// like `GRANT SELECT, INSERT ON public.articles TO authenticated`
+type TablePrivilege struct {
+	Schema     string   `json:"schema"`
+	Table      string   `json:"table"`
+	Role       string   `json:"role"`
+	Privileges []string `json:"privileges"`
+}
+
+// Key return unique identifier of privilege, every privilege of the same role and table is grouped
+func (t TablePrivilege) Key() string {
+	return strings.ToLower(fmt.Sprintf("%s.%s.%s", t.Schema, t.Table, t.Role))
+}
+
+type TablePrivileges []TablePrivilege
+
+func (tp TablePrivileges) FilterByTable(schema, table string) TablePrivileges {
+	var filteredData TablePrivileges
+	for _, v := range tp {
+		if v.Schema == schema && v.Table == table {
+			filteredData = append(filteredData, v)
+		}
+	}
+	return filteredData
+}
+
+type UpdateTablePrivilegeParam struct {
+	GrantPrivileges  []string
+	RevokePrivileges []string
+}
@@ -18,6 +18,10 @@ type Role struct {
 
 	// Preload data when import or apply
 	InheritRoles []*Role `json:"inherit_roles"`
+
+	// AdminOption is used by item of InheritRoles,
+	// mark membership is granted with admin option
+	AdminOption bool `json:"admin_option,omitempty"`
 }
 
 type Roles []Role
@@ -37,6 +41,7 @@ type RoleMembership struct {
 	ParentRole  string `json:"parent_role"`
 	InheritID   int    `json:"inherit_id"`
 	InheritRole string `json:"inherit_role"`
+	AdminOption bool   `json:"admin_option"`
 }
 
 type RoleMemberships []RoleMembership
@@ -82,8 +87,9 @@ type UpdateRoleParam struct {
 type UpdateRoleInheritType string
 
 const (
-	UpdateRoleInheritGrant  UpdateRoleInheritType = "grant"
-	UpdateRoleInheritRevoke UpdateRoleInheritType = "revoke"
+	UpdateRoleInheritGrant       UpdateRoleInheritType = "grant"
+	UpdateRoleInheritRevoke      UpdateRoleInheritType = "revoke"
+	UpdateRoleInheritRevokeAdmin UpdateRoleInheritType = "revoke_admin"
 )
 
 type UpdateRoleInheritItem struct {
@@ -52,10 +52,10 @@ func BuildCreatePolicyQuery(policy objects.Policy) string {
 
 		for _, privilege := range privileges {
 			grantAccessTables = append(grantAccessTables, fmt.Sprintf(`
-			IF NOT HAS_TABLE_PRIVILEGE('%s', '%s', '%s') THEN
+			IF NOT %s('%s', '%s', '%s') THEN
 				GRANT %s ON %s TO %s;
 			END IF;
-		`, role, tableFQNText, privilege, privilege, tableFQN, pq.QuoteIdentifier(role)))
+		`, privilegeCheckFunc(privilege), role, tableFQNText, privilege, privilege, tableFQN, pq.QuoteIdentifier(role)))
 		}
 	}
 
@@ -127,10 +127,10 @@ func BuildUpdatePolicyQuery(policy objects.Policy, updatePolicyParams objects.Up
 			for _, role := range policy.Roles {
 				for _, privilege := range privilegesForCommand(command) {
 					grantAccessTables = append(grantAccessTables, fmt.Sprintf(`
-				IF NOT HAS_TABLE_PRIVILEGE('%s', '%s', '%s') THEN
+				IF NOT %s('%s', '%s', '%s') THEN
 					GRANT %s ON %s TO %s;
 				END IF;
-			`, role, tableFQNText, privilege, privilege, tableFQN, pq.QuoteIdentifier(role)))
+			`, privilegeCheckFunc(privilege), role, tableFQNText, privilege, privilege, tableFQN, pq.QuoteIdentifier(role)))
 				}
 			}
 		}
@@ -177,3 +177,14 @@ func privilegesForCommand(command string) []string {
 		return []string{command}
 	}
 }
+
+// privilegeCheckFunc return function to check existing privilege before grant table privilege to policy role,
+// role with column privilege is treated as granted so the column restriction is kept
+func privilegeCheckFunc(privilege string) string {
+	switch privilege {
+	case string(objects.PolicyCommandSelect), string(objects.PolicyCommandInsert), string(objects.PolicyCommandUpdate):
+		return "HAS_ANY_COLUMN_PRIVILEGE"
+	default:
+		return "HAS_TABLE_PRIVILEGE"
+	}
+}
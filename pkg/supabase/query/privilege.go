@@ -0,0 +1,163 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func BuildGrantColumnPrivilegeQuery(privilege objects.ColumnPrivilege) (string, error) {
+	if err := validateColumnPrivilege(privilege); err != nil {
+		return "", err
+	}
+
+	if len(privilege.Columns) == 0 {
+		return "", fmt.Errorf("grant %s on %s.%s to %s require column", privilege.Privilege, privilege.Schema, privilege.Table, privilege.Role)
+	}
+
+	return columnPrivilegeStatement("GRANT", privilege, privilege.Columns), nil
+}
+
+func BuildUpdateColumnPrivilegeQuery(privilege objects.ColumnPrivilege, param objects.UpdateColumnPrivilegeParam) (string, error) {
+	if err := validateColumnPrivilege(privilege); err != nil {
+		return "", err
+	}
+
+	statements := make([]string, 0, 3)
+	if param.RevokeTablePrivilege {
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s;", privilegeType(privilege), privilegeTable(privilege), pq.QuoteIdentifier(privilege.Role)))
+	}
+
+	if len(param.RevokeColumns) > 0 {
+		statements = append(statements, columnPrivilegeStatement("REVOKE", privilege, param.RevokeColumns))
+	}
+
+	if len(param.GrantColumns) > 0 {
+		statements = append(statements, columnPrivilegeStatement("GRANT", privilege, param.GrantColumns))
+	}
+
+	if len(statements) == 0 {
+		return "", fmt.Errorf("update %s privilege on %s.%s for %s has no changes", privilege.Privilege, privilege.Schema, privilege.Table, privilege.Role)
+	}
+
+	return strings.Join(statements, " "), nil
+}
+
+func BuildRevokeColumnPrivilegeQuery(privilege objects.ColumnPrivilege) (string, error) {
+	if err := validateColumnPrivilege(privilege); err != nil {
+		return "", err
+	}
+
+	if len(privilege.Columns) == 0 {
+		return "", fmt.Errorf("revoke %s on %s.%s from %s require column", privilege.Privilege, privilege.Schema, privilege.Table, privilege.Role)
+	}
+
+	return columnPrivilegeStatement("REVOKE", privilege, privilege.Columns), nil
+}
+
+func columnPrivilegeStatement(action string, privilege objects.ColumnPrivilege, columns []string) string {
+	quotedColumns := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quotedColumns = append(quotedColumns, pq.QuoteIdentifier(c))
+	}
+
+	direction := "TO"
+	if action == "REVOKE" {
+		direction = "FROM"
+	}
+
+	return fmt.Sprintf("%s %s (%s) ON %s %s %s;", action, privilegeType(privilege), strings.Join(quotedColumns, ", "), privilegeTable(privilege), direction, pq.QuoteIdentifier(privilege.Role))
+}
+
+func validateColumnPrivilege(privilege objects.ColumnPrivilege) error {
+	if privilege.Table == "" || privilege.Role == "" {
+		return fmt.Errorf("column privilege require table and role")
+	}
+
+	switch privilegeType(privilege) {
+	case "SELECT", "INSERT", "UPDATE", "REFERENCES":
+		return nil
+	default:
+		return fmt.Errorf("privilege %s can't be granted to column", privilege.Privilege)
+	}
+}
+
+func privilegeType(privilege objects.ColumnPrivilege) string {
+	return strings.ToUpper(strings.TrimSpace(privilege.Privilege))
+}
+
+func privilegeTable(privilege objects.ColumnPrivilege) string {
+	return qualifiedTable(privilege.Schema, privilege.Table)
+}
+
+func qualifiedTable(schema, table string) string {
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(table))
+}
+
+// ----- table privilege -----
+
+func BuildGrantTablePrivilegeQuery(privilege objects.TablePrivilege) (string, error) {
+	return tablePrivilegeStatement("GRANT", privilege, privilege.Privileges)
+}
+
+func BuildUpdateTablePrivilegeQuery(privilege objects.TablePrivilege, param objects.UpdateTablePrivilegeParam) (string, error) {
+	statements := make([]string, 0, 2)
+	if len(param.RevokePrivileges) > 0 {
+		q, err := tablePrivilegeStatement("REVOKE", privilege, param.RevokePrivileges)
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, q)
+	}
+
+	if len(param.GrantPrivileges) > 0 {
+		q, err := tablePrivilegeStatement("GRANT", privilege, param.GrantPrivileges)
+		if err != nil {
+			return "", err
+		}
+		statements = append(statements, q)
+	}
+
+	if len(statements) == 0 {
+		return "", fmt.Errorf("update privilege on %s.%s for %s has no changes", privilege.Schema, privilege.Table, privilege.Role)
+	}
+
+	return strings.Join(statements, " "), nil
+}
+
+func BuildRevokeTablePrivilegeQuery(privilege objects.TablePrivilege) (string, error) {
+	return tablePrivilegeStatement("REVOKE", privilege, privilege.Privileges)
+}
+
+func tablePrivilegeStatement(action string, privilege objects.TablePrivilege, privileges []string) (string, error) {
+	if privilege.Table == "" || privilege.Role == "" {
+		return "", fmt.Errorf("table privilege require table and role")
+	}
+
+	if len(privileges) == 0 {
+		return "", fmt.Errorf("%s on %s.%s for %s require privilege", strings.ToLower(action), privilege.Schema, privilege.Table, privilege.Role)
+	}
+
+	types := make([]string, 0, len(privileges))
+	for _, p := range privileges {
+		pt := strings.ToUpper(strings.TrimSpace(p))
+		switch pt {
+		case "SELECT", "INSERT", "UPDATE", "DELETE":
+			types = append(types, pt)
+		default:
+			return "", fmt.Errorf("privilege %s is not supported for table %s", p, privilege.Table)
+		}
+	}
+
+	direction := "TO"
+	if action == "REVOKE" {
+		direction = "FROM"
+	}
+
+	return fmt.Sprintf("%s %s ON %s %s %s;", action, strings.Join(types, ", "), qualifiedTable(privilege.Schema, privilege.Table), direction, pq.QuoteIdentifier(privilege.Role)), nil
+}
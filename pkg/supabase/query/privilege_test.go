@@ -0,0 +1,75 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildColumnPrivilegeQuery(t *testing.T) {
+	privilege := objects.ColumnPrivilege{Schema: "public", Table: "articles", Role: "anon", Privilege: "select", Columns: []string{"id", "title"}}
+
+	q, err := BuildGrantColumnPrivilegeQuery(privilege)
+	assert.NoError(t, err)
+	assert.Equal(t, `GRANT SELECT ("id", "title") ON "public"."articles" TO "anon";`, q)
+
+	q, err = BuildRevokeColumnPrivilegeQuery(privilege)
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE SELECT ("id", "title") ON "public"."articles" FROM "anon";`, q)
+
+	q, err = BuildUpdateColumnPrivilegeQuery(privilege, objects.UpdateColumnPrivilegeParam{
+		RevokeTablePrivilege: true,
+		GrantColumns:         []string{"id", "title"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE SELECT ON "public"."articles" FROM "anon"; GRANT SELECT ("id", "title") ON "public"."articles" TO "anon";`, q)
+
+	q, err = BuildUpdateColumnPrivilegeQuery(privilege, objects.UpdateColumnPrivilegeParam{
+		GrantColumns:  []string{"slug"},
+		RevokeColumns: []string{"title"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE SELECT ("title") ON "public"."articles" FROM "anon"; GRANT SELECT ("slug") ON "public"."articles" TO "anon";`, q)
+}
+
+func TestBuildColumnPrivilegeQueryInvalid(t *testing.T) {
+	_, err := BuildGrantColumnPrivilegeQuery(objects.ColumnPrivilege{Table: "articles", Role: "anon", Privilege: "DELETE", Columns: []string{"id"}})
+	assert.Error(t, err)
+
+	_, err = BuildGrantColumnPrivilegeQuery(objects.ColumnPrivilege{Table: "articles", Role: "anon", Privilege: "SELECT"})
+	assert.Error(t, err)
+
+	_, err = BuildUpdateColumnPrivilegeQuery(objects.ColumnPrivilege{Table: "articles", Role: "anon", Privilege: "SELECT"}, objects.UpdateColumnPrivilegeParam{})
+	assert.Error(t, err)
+}
+
+func TestBuildTablePrivilegeQuery(t *testing.T) {
+	privilege := objects.TablePrivilege{Schema: "public", Table: "articles", Role: "anon", Privileges: []string{"select", "INSERT"}}
+
+	q, err := BuildGrantTablePrivilegeQuery(privilege)
+	assert.NoError(t, err)
+	assert.Equal(t, `GRANT SELECT, INSERT ON "public"."articles" TO "anon";`, q)
+
+	q, err = BuildRevokeTablePrivilegeQuery(privilege)
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE SELECT, INSERT ON "public"."articles" FROM "anon";`, q)
+
+	q, err = BuildUpdateTablePrivilegeQuery(privilege, objects.UpdateTablePrivilegeParam{
+		GrantPrivileges:  []string{"UPDATE"},
+		RevokePrivileges: []string{"DELETE"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE DELETE ON "public"."articles" FROM "anon"; GRANT UPDATE ON "public"."articles" TO "anon";`, q)
+}
+
+func TestBuildTablePrivilegeQueryInvalid(t *testing.T) {
+	_, err := BuildGrantTablePrivilegeQuery(objects.TablePrivilege{Table: "articles", Role: "anon", Privileges: []string{"TRUNCATE"}})
+	assert.Error(t, err)
+
+	_, err = BuildGrantTablePrivilegeQuery(objects.TablePrivilege{Table: "articles", Role: "anon"})
+	assert.Error(t, err)
+
+	_, err = BuildUpdateTablePrivilegeQuery(objects.TablePrivilege{Table: "articles", Role: "anon"}, objects.UpdateTablePrivilegeParam{})
+	assert.Error(t, err)
+}
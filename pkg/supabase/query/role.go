@@ -255,23 +255,37 @@ END $$;`, roleLiteral)
 }
 
 func BuildRoleInheritQuery(roleName string, inheritRoleName string, action objects.UpdateRoleInheritType) (string, error) {
+	return BuildRoleInheritItemQuery(roleName, objects.UpdateRoleInheritItem{
+		Role: objects.Role{Name: inheritRoleName},
+		Type: action,
+	})
+}
+
+// BuildRoleInheritItemQuery build grant or revoke membership query,
+// grant with admin option when item role is marked with admin option
+func BuildRoleInheritItemQuery(roleName string, item objects.UpdateRoleInheritItem) (string, error) {
 	if roleName == "" {
 		return "", fmt.Errorf("role name is required")
 	}
 
-	if inheritRoleName == "" {
+	if item.Role.Name == "" {
 		return "", fmt.Errorf("inherit role name is required")
 	}
 
 	quotedRole := pq.QuoteIdentifier(roleName)
-	quotedInherit := pq.QuoteIdentifier(inheritRoleName)
+	quotedInherit := pq.QuoteIdentifier(item.Role.Name)
 
-	switch action {
+	switch item.Type {
 	case objects.UpdateRoleInheritGrant:
+		if item.Role.AdminOption {
+			return fmt.Sprintf("GRANT %s TO %s WITH ADMIN OPTION;", quotedInherit, quotedRole), nil
+		}
 		return fmt.Sprintf("GRANT %s TO %s;", quotedInherit, quotedRole), nil
 	case objects.UpdateRoleInheritRevoke:
 		return fmt.Sprintf("REVOKE %s FROM %s;", quotedInherit, quotedRole), nil
+	case objects.UpdateRoleInheritRevokeAdmin:
+		return fmt.Sprintf("REVOKE ADMIN OPTION FOR %s FROM %s;", quotedInherit, quotedRole), nil
 	default:
-		return "", fmt.Errorf("unsupported role inherit action %s", action)
+		return "", fmt.Errorf("unsupported role inherit action %s", item.Type)
 	}
 }
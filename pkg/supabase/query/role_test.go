@@ -172,6 +172,22 @@ func TestBuildRoleInheritQuery(t *testing.T) {
 	assert.Equal(t, `REVOKE "child_role" FROM "parent_role";`, query)
 }
 
+func TestBuildRoleInheritItemQuery(t *testing.T) {
+	query, err := BuildRoleInheritItemQuery("editor", objects.UpdateRoleInheritItem{
+		Role: objects.Role{Name: "author", AdminOption: true},
+		Type: objects.UpdateRoleInheritGrant,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `GRANT "author" TO "editor" WITH ADMIN OPTION;`, query)
+
+	query, err = BuildRoleInheritItemQuery("editor", objects.UpdateRoleInheritItem{
+		Role: objects.Role{Name: "author"},
+		Type: objects.UpdateRoleInheritRevokeAdmin,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `REVOKE ADMIN OPTION FOR "author" FROM "editor";`, query)
+}
+
 func TestBuildRoleInheritQueryErrorCases(t *testing.T) {
 	// Test empty role name
 	_, err := BuildRoleInheritQuery("", "child_role", objects.UpdateRoleInheritGrant)
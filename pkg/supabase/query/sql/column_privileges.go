@@ -1,5 +1,10 @@
 package sql
 
+import (
+	"fmt"
+	"strings"
+)
+
 var GetColumntPrivelegesQuery = `
 -- Lists each column's privileges in the form of:
 --
@@ -147,3 +152,66 @@ group by column_id,
          x.relname,
          x.attname
 `
+
+// getColumnPrivilegesBySchemaQuery list privilege per table, role and privilege type,
+// column privilege is read from attacl only so privilege inherited from table is reported as all_columns
+var getColumnPrivilegesBySchemaQuery = `
+WITH column_grants AS (
+  SELECT
+    nc.nspname AS schema,
+    c.relname AS table_name,
+    grantee.rolname AS role,
+    acl.privilege_type AS privilege,
+    array_agg(a.attname::text ORDER BY a.attnum) AS columns
+  FROM pg_attribute a
+  JOIN pg_class c ON c.oid = a.attrelid
+  JOIN pg_namespace nc ON nc.oid = c.relnamespace
+  CROSS JOIN LATERAL aclexplode(a.attacl) AS acl
+  JOIN pg_roles grantee ON grantee.oid = acl.grantee
+  WHERE a.attnum > 0
+    AND NOT a.attisdropped
+    AND a.attacl IS NOT NULL
+    AND c.relkind IN ('r', 'v', 'm', 'f', 'p')
+    AND nc.nspname IN (%[1]s)
+  GROUP BY nc.nspname, c.relname, grantee.rolname, acl.privilege_type
+),
+table_grants AS (
+  SELECT
+    nc.nspname AS schema,
+    c.relname AS table_name,
+    grantee.rolname AS role,
+    acl.privilege_type AS privilege
+  FROM pg_class c
+  JOIN pg_namespace nc ON nc.oid = c.relnamespace
+  CROSS JOIN LATERAL aclexplode(coalesce(c.relacl, acldefault('r', c.relowner))) AS acl
+  JOIN pg_roles grantee ON grantee.oid = acl.grantee
+  WHERE c.relkind IN ('r', 'v', 'm', 'f', 'p')
+    AND nc.nspname IN (%[1]s)
+    AND acl.privilege_type IN ('SELECT', 'INSERT', 'UPDATE', 'REFERENCES')
+)
+SELECT
+  coalesce(cg.schema, tg.schema) AS schema,
+  coalesce(cg.table_name, tg.table_name) AS "table",
+  coalesce(cg.role, tg.role) AS role,
+  coalesce(cg.privilege, tg.privilege) AS privilege,
+  coalesce(cg.columns, '{}'::text[]) AS columns,
+  tg.role IS NOT NULL AS all_columns
+FROM column_grants cg
+FULL JOIN table_grants tg
+  ON tg.schema = cg.schema
+  AND tg.table_name = cg.table_name
+  AND tg.role = cg.role
+  AND tg.privilege = cg.privilege
+ORDER BY 1, 2, 3, 4
+`
+
+func GenerateGetColumnPrivilegesQuery(includedSchema []string) string {
+	schemas := make([]string, 0, len(includedSchema))
+	for _, s := range includedSchema {
+		schemas = append(schemas, fmt.Sprintf("'%s'", s))
+	}
+	if len(schemas) == 0 {
+		schemas = append(schemas, "'public'")
+	}
+	return fmt.Sprintf(getColumnPrivilegesBySchemaQuery, strings.Join(schemas, ","))
+}
@@ -42,7 +42,8 @@ role_inheritance AS (
     role.oid::int8    AS parent_id,
     role.rolname      AS parent_role,
     inherit.oid::int8  AS inherit_id,
-    inherit.rolname    AS inherit_role_name
+    inherit.rolname    AS inherit_role_name,
+    m.admin_option     AS admin_option
   FROM pg_auth_members m
   JOIN pg_roles inherit ON m.member = inherit.oid
   JOIN pg_roles role   ON m.roleid = role.oid
@@ -51,7 +52,8 @@ SELECT DISTINCT
   i.parent_id,
   i.parent_role,
   i.inherit_id,
-  i.inherit_role_name,
+  i.inherit_role_name AS inherit_role,
+  i.admin_option,
   sr.schema_name
 FROM role_inheritance i
 LEFT JOIN schema_roles sr
@@ -27,6 +27,7 @@ func TestGetRoleMembershipsQuery(t *testing.T) {
 	assert.Contains(t, getRoleMembershipsQuery, "parent_role")
 	assert.Contains(t, getRoleMembershipsQuery, "inherit_id")
 	assert.Contains(t, getRoleMembershipsQuery, "inherit_role_name")
+	assert.Contains(t, getRoleMembershipsQuery, "m.admin_option     AS admin_option")
 	assert.Contains(t, getRoleMembershipsQuery, "schema_name")
 }
 
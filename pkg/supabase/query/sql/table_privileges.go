@@ -1,5 +1,10 @@
 package sql
 
+import (
+	"fmt"
+	"strings"
+)
+
 var GetTablePrivelegesQuery = `
 -- Despite the name 'table_privileges', this includes other kinds of relations:
 -- views, matviews, etc. "Relation privileges" just doesn't roll off the tongue.
@@ -77,3 +82,34 @@ group by
   c.relname,
   c.relkind
 `
+
+// getTablePrivilegesBySchemaQuery list privilege of role per table,
+// privilege of table owner and PUBLIC pseudo role is not included
+var getTablePrivilegesBySchemaQuery = `
+SELECT
+  nc.nspname AS schema,
+  c.relname AS "table",
+  grantee.rolname AS role,
+  array_agg(DISTINCT acl.privilege_type::text ORDER BY acl.privilege_type::text) AS privileges
+FROM pg_class c
+JOIN pg_namespace nc ON nc.oid = c.relnamespace
+CROSS JOIN LATERAL aclexplode(coalesce(c.relacl, acldefault('r', c.relowner))) AS acl
+JOIN pg_roles grantee ON grantee.oid = acl.grantee
+WHERE c.relkind IN ('r', 'v', 'm', 'f', 'p')
+  AND nc.nspname IN (%s)
+  AND acl.grantee <> c.relowner
+  AND acl.privilege_type IN ('SELECT', 'INSERT', 'UPDATE', 'DELETE')
+GROUP BY nc.nspname, c.relname, grantee.rolname
+ORDER BY 1, 2, 3
+`
+
+func GenerateGetTablePrivilegesQuery(includedSchema []string) string {
+	schemas := make([]string, 0, len(includedSchema))
+	for _, s := range includedSchema {
+		schemas = append(schemas, fmt.Sprintf("'%s'", s))
+	}
+	if len(schemas) == 0 {
+		schemas = append(schemas, "'public'")
+	}
+	return fmt.Sprintf(getTablePrivilegesBySchemaQuery, strings.Join(schemas, ","))
+}
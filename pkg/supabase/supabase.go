@@ -294,6 +294,110 @@ func DeletePolicy(cfg *raiden.Config, old objects.Policy) (err error) {
 	})
 }
 
+func GetColumnPrivileges(cfg *raiden.Config, includedSchema []string) (objects.ColumnPrivileges, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all column privilege from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "column privilege", func() ([]objects.ColumnPrivilege, error) {
+			return cloud.GetColumnPrivileges(cfg, includedSchema)
+		})
+	}
+	SupabaseLogger.Debug("Get all column privilege from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "column privilege", func() ([]objects.ColumnPrivilege, error) {
+		return meta.GetColumnPrivileges(cfg, includedSchema)
+	})
+}
+
+func CreateColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege) (objects.ColumnPrivilege, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Grant column privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("create", "column privilege", func() (objects.ColumnPrivilege, error) {
+			return cloud.CreateColumnPrivilege(cfg, privilege)
+		})
+	}
+	SupabaseLogger.Debug("Grant column privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionWithDataErr("create", "column privilege", func() (objects.ColumnPrivilege, error) {
+		return meta.CreateColumnPrivilege(cfg, privilege)
+	})
+}
+
+func UpdateColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege, updateItems objects.UpdateColumnPrivilegeParam) error {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Update column privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionErr("update", "column privilege", func() error {
+			return cloud.UpdateColumnPrivilege(cfg, privilege, updateItems)
+		})
+	}
+	SupabaseLogger.Debug("Update column privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionErr("update", "column privilege", func() error {
+		return meta.UpdateColumnPrivilege(cfg, privilege, updateItems)
+	})
+}
+
+func DeleteColumnPrivilege(cfg *raiden.Config, privilege objects.ColumnPrivilege) error {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Revoke column privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionErr("delete", "column privilege", func() error {
+			return cloud.DeleteColumnPrivilege(cfg, privilege)
+		})
+	}
+	SupabaseLogger.Debug("Revoke column privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionErr("delete", "column privilege", func() error {
+		return meta.DeleteColumnPrivilege(cfg, privilege)
+	})
+}
+
+func GetTablePrivileges(cfg *raiden.Config, includedSchema []string) (objects.TablePrivileges, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all table privilege from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "table privilege", func() ([]objects.TablePrivilege, error) {
+			return cloud.GetTablePrivileges(cfg, includedSchema)
+		})
+	}
+	SupabaseLogger.Debug("Get all table privilege from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "table privilege", func() ([]objects.TablePrivilege, error) {
+		return meta.GetTablePrivileges(cfg, includedSchema)
+	})
+}
+
+func CreateTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege) (objects.TablePrivilege, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Grant table privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("create", "table privilege", func() (objects.TablePrivilege, error) {
+			return cloud.CreateTablePrivilege(cfg, privilege)
+		})
+	}
+	SupabaseLogger.Debug("Grant table privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionWithDataErr("create", "table privilege", func() (objects.TablePrivilege, error) {
+		return meta.CreateTablePrivilege(cfg, privilege)
+	})
+}
+
+func UpdateTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege, updateItems objects.UpdateTablePrivilegeParam) error {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Update table privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionErr("update", "table privilege", func() error {
+			return cloud.UpdateTablePrivilege(cfg, privilege, updateItems)
+		})
+	}
+	SupabaseLogger.Debug("Update table privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionErr("update", "table privilege", func() error {
+		return meta.UpdateTablePrivilege(cfg, privilege, updateItems)
+	})
+}
+
+func DeleteTablePrivilege(cfg *raiden.Config, privilege objects.TablePrivilege) error {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Revoke table privilege in supabase cloud", "table", privilege.Table, "role", privilege.Role, "project-id", cfg.ProjectId)
+		return decorateActionErr("delete", "table privilege", func() error {
+			return cloud.DeleteTablePrivilege(cfg, privilege)
+		})
+	}
+	SupabaseLogger.Debug("Revoke table privilege in supabase pg-meta", "table", privilege.Table, "role", privilege.Role)
+	return decorateActionErr("delete", "table privilege", func() error {
+		return meta.DeleteTablePrivilege(cfg, privilege)
+	})
+}
+
 func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
 		SupabaseLogger.Debug("Get all function from supabase cloud", "project-id", cfg.ProjectId)
@@ -19,6 +19,9 @@ type (
 		isForced bool
 
 		mapRule map[string]*rule
+		grants  []*columnGrant
+
+		tableGrants []*tableGrant
 
 		once sync.Once
 	}
@@ -94,6 +97,30 @@ func (a *Acl) Define(rr ...*rule) *Acl {
 	return a
 }
 
+// AclPreset is reusable group of rule, built-in preset is available in pkg/acl
+//
+// example :
+//
+//	func (p *Projects) ConfigureAcl() {
+//		p.Acl.Enable().Use(
+//			acl.Preset.OwnerCRUD(p, &p.UserId, "authenticated"),
+//			acl.Preset.PublicRead(p),
+//		).Define(
+//			raiden.Rule("projects_admin_delete").For("admin").To(raiden.CommandDelete),
+//		)
+//	}
+type AclPreset func(a *Acl)
+
+// Use apply preset to acl, rule of preset is replaced by rule defined later with the same name
+func (a *Acl) Use(presets ...AclPreset) *Acl {
+	for _, p := range presets {
+		if p != nil {
+			p(a)
+		}
+	}
+	return a
+}
+
 func (a *Acl) InitOnce(fn func()) { a.once.Do(fn) }
 
 func (a *Acl) BuildPolicies(schema, table string) (policies objects.Policies, err error) {
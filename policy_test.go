@@ -116,3 +116,20 @@ func TestAclBuildStoragePolicies(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestAclUse(t *testing.T) {
+	readAll := func(a *raiden.Acl) {
+		a.Define(raiden.Rule("read all").To(raiden.CommandSelect).Using(builder.True))
+	}
+
+	acl := &raiden.Acl{}
+	acl.Use(readAll, nil).Define(
+		raiden.Rule("read all").For("authenticated").To(raiden.CommandSelect).Using(builder.OwnerIsAuth("owner")),
+	)
+
+	policies, err := acl.BuildPolicies("public", "notes")
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, []string{"authenticated"}, policies[0].Roles)
+	assert.Equal(t, `"owner" = auth.uid()`, policies[0].Definition)
+}
@@ -0,0 +1,230 @@
+package raiden
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// Privilege is privilege that can be granted to subset of table column
+type Privilege string
+
+const (
+	PrivilegeSelect     Privilege = "SELECT"
+	PrivilegeInsert     Privilege = "INSERT"
+	PrivilegeUpdate     Privilege = "UPDATE"
+	PrivilegeReferences Privilege = "REFERENCES"
+)
+
+func (p Privilege) isValid() bool {
+	switch p {
+	case PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate, PrivilegeReferences:
+		return true
+	}
+	return false
+}
+
+// ColumnGrant create grant of privilege to subset of table column
+//
+// example :
+//
+//	func (a *Articles) ConfigureAcl() {
+//		a.Acl.Enable().GrantColumns(
+//			raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon").Columns("id", "title"),
+//			raiden.ColumnGrant(raiden.PrivilegeUpdate).For("authenticated").Columns("title", "body"),
+//		)
+//	}
+func ColumnGrant(privilege Privilege) *columnGrant {
+	return &columnGrant{privilege: privilege}
+}
+
+type columnGrant struct {
+	privilege Privilege
+	roles     []string
+	columns   []string
+}
+
+func (g *columnGrant) For(roles ...string) *columnGrant {
+	g.roles = append(g.roles, roles...)
+	return g
+}
+
+func (g *columnGrant) Columns(columns ...string) *columnGrant {
+	g.columns = append(g.columns, columns...)
+	return g
+}
+
+// GrantColumns register column privilege of table, role only can access granted column
+// when privilege is not granted to the whole table
+func (a *Acl) GrantColumns(gg ...*columnGrant) *Acl {
+	for _, g := range gg {
+		if g != nil {
+			a.grants = append(a.grants, g)
+		}
+	}
+	return a
+}
+
+// BuildColumnPrivileges return column privilege grouped by role and privilege
+func (a *Acl) BuildColumnPrivileges(schema, table string) (objects.ColumnPrivileges, error) {
+	if len(a.grants) == 0 {
+		return nil, nil
+	}
+
+	if err := utils.EmptyOrError(table, "table name required to set"); err != nil {
+		return nil, err
+	}
+	schema = utils.EmptyOrDefault(schema, "public")
+
+	mapPrivilege := make(map[string]*objects.ColumnPrivilege)
+	keys := make([]string, 0)
+	for _, g := range a.grants {
+		privilege := Privilege(strings.ToUpper(string(g.privilege)))
+		if !privilege.isValid() {
+			return nil, fmt.Errorf("privilege %s can't be granted to column of table %s", g.privilege, table)
+		}
+
+		if len(g.roles) == 0 {
+			return nil, fmt.Errorf("%s column grant of table %s require role", privilege, table)
+		}
+
+		if len(g.columns) == 0 {
+			return nil, fmt.Errorf("%s column grant of table %s require column", privilege, table)
+		}
+
+		for _, role := range g.roles {
+			p := objects.ColumnPrivilege{Schema: schema, Table: table, Role: role, Privilege: string(privilege)}
+			existing, ok := mapPrivilege[p.Key()]
+			if !ok {
+				existing = &p
+				mapPrivilege[p.Key()] = existing
+				keys = append(keys, p.Key())
+			}
+
+			for _, c := range g.columns {
+				if !slices.Contains(existing.Columns, c) {
+					existing.Columns = append(existing.Columns, c)
+				}
+			}
+		}
+	}
+
+	privileges := make(objects.ColumnPrivileges, 0, len(keys))
+	for _, k := range keys {
+		p := mapPrivilege[k]
+		slices.Sort(p.Columns)
+		privileges = append(privileges, *p)
+	}
+	return privileges, nil
+}
+
+// TableGrant create grant of command to the whole table, CommandAll grant select, insert, update and delete
+//
+// example :
+//
+//	func (a *Articles) ConfigureAcl() {
+//		a.Acl.Enable().GrantTable(
+//			raiden.TableGrant(raiden.CommandSelect).For("anon", "authenticated"),
+//			raiden.TableGrant(raiden.CommandInsert, raiden.CommandUpdate).For("authenticated"),
+//		)
+//	}
+func TableGrant(commands ...Command) *tableGrant {
+	return &tableGrant{commands: commands}
+}
+
+type tableGrant struct {
+	commands []Command
+	roles    []string
+}
+
+func (g *tableGrant) For(roles ...string) *tableGrant {
+	g.roles = append(g.roles, roles...)
+	return g
+}
+
+// GrantTable register table privilege of role, privilege of role that is not registered is not managed
+func (a *Acl) GrantTable(gg ...*tableGrant) *Acl {
+	for _, g := range gg {
+		if g != nil {
+			a.tableGrants = append(a.tableGrants, g)
+		}
+	}
+	return a
+}
+
+var tablePrivilegeCommands = []Command{CommandSelect, CommandInsert, CommandUpdate, CommandDelete}
+
+// BuildTablePrivileges return table privilege grouped by role
+func (a *Acl) BuildTablePrivileges(schema, table string) (objects.TablePrivileges, error) {
+	if len(a.tableGrants) == 0 {
+		return nil, nil
+	}
+
+	if err := utils.EmptyOrError(table, "table name required to set"); err != nil {
+		return nil, err
+	}
+	schema = utils.EmptyOrDefault(schema, "public")
+
+	columnGranted := make(map[string]bool)
+	for _, g := range a.grants {
+		for _, role := range g.roles {
+			columnGranted[role+"."+strings.ToUpper(string(g.privilege))] = true
+		}
+	}
+
+	mapCommand := make(map[string]map[Command]bool)
+	roles := make([]string, 0)
+	for _, g := range a.tableGrants {
+		if len(g.roles) == 0 {
+			return nil, fmt.Errorf("table grant of table %s require role", table)
+		}
+
+		if len(g.commands) == 0 {
+			return nil, fmt.Errorf("table grant of table %s require command", table)
+		}
+
+		for _, role := range g.roles {
+			commands, ok := mapCommand[role]
+			if !ok {
+				commands = make(map[Command]bool)
+				mapCommand[role] = commands
+				roles = append(roles, role)
+			}
+
+			for _, c := range g.commands {
+				c = Command(strings.ToUpper(string(c)))
+				switch c {
+				case CommandAll:
+					for _, tc := range tablePrivilegeCommands {
+						commands[tc] = true
+					}
+				case CommandSelect, CommandInsert, CommandUpdate, CommandDelete:
+					commands[c] = true
+				default:
+					return nil, fmt.Errorf("command %s can't be granted to table %s", c, table)
+				}
+			}
+		}
+	}
+
+	privileges := make(objects.TablePrivileges, 0, len(roles))
+	for _, role := range roles {
+		p := objects.TablePrivilege{Schema: schema, Table: table, Role: role}
+		for _, c := range tablePrivilegeCommands {
+			if !mapCommand[role][c] {
+				continue
+			}
+
+			// column privilege is ignored by postgres when the same privilege is granted to table
+			if columnGranted[role+"."+string(c)] {
+				return nil, fmt.Errorf("%s is granted to both table %s and its column for role %s", c, table, role)
+			}
+			p.Privileges = append(p.Privileges, string(c))
+		}
+		privileges = append(privileges, p)
+	}
+	return privileges, nil
+}
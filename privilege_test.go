@@ -0,0 +1,92 @@
+package raiden_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAclBuildColumnPrivileges(t *testing.T) {
+	acl := &raiden.Acl{}
+	acl.GrantColumns(
+		raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon", "authenticated").Columns("title", "id"),
+		raiden.ColumnGrant("update").For("authenticated").Columns("title"),
+		raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon").Columns("slug", "id"),
+	)
+
+	privileges, err := acl.BuildColumnPrivileges("", "articles")
+	require.NoError(t, err)
+	require.Len(t, privileges, 3)
+
+	require.Equal(t, "public", privileges[0].Schema)
+	require.Equal(t, "anon", privileges[0].Role)
+	require.Equal(t, "SELECT", privileges[0].Privilege)
+	require.Equal(t, []string{"id", "slug", "title"}, privileges[0].Columns)
+
+	require.Equal(t, "authenticated", privileges[1].Role)
+	require.Equal(t, []string{"id", "title"}, privileges[1].Columns)
+
+	require.Equal(t, "UPDATE", privileges[2].Privilege)
+	require.Equal(t, []string{"title"}, privileges[2].Columns)
+}
+
+func TestAclBuildColumnPrivilegesInvalid(t *testing.T) {
+	cases := map[string]*raiden.Acl{
+		"privilege": (&raiden.Acl{}).GrantColumns(raiden.ColumnGrant("DELETE").For("anon").Columns("id")),
+		"role":      (&raiden.Acl{}).GrantColumns(raiden.ColumnGrant(raiden.PrivilegeSelect).Columns("id")),
+		"column":    (&raiden.Acl{}).GrantColumns(raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon")),
+	}
+
+	for name, acl := range cases {
+		_, err := acl.BuildColumnPrivileges("public", "articles")
+		require.Error(t, err, name)
+	}
+
+	privileges, err := (&raiden.Acl{}).BuildColumnPrivileges("public", "articles")
+	require.NoError(t, err)
+	require.Empty(t, privileges)
+}
+
+func TestAclBuildTablePrivileges(t *testing.T) {
+	acl := &raiden.Acl{}
+	acl.GrantTable(
+		raiden.TableGrant(raiden.CommandUpdate, raiden.CommandSelect).For("authenticated", "anon"),
+		raiden.TableGrant("delete").For("authenticated"),
+		raiden.TableGrant(raiden.CommandAll).For("editor"),
+	)
+
+	privileges, err := acl.BuildTablePrivileges("", "articles")
+	require.NoError(t, err)
+	require.Len(t, privileges, 3)
+
+	require.Equal(t, "public", privileges[0].Schema)
+	require.Equal(t, "authenticated", privileges[0].Role)
+	require.Equal(t, []string{"SELECT", "UPDATE", "DELETE"}, privileges[0].Privileges)
+
+	require.Equal(t, "anon", privileges[1].Role)
+	require.Equal(t, []string{"SELECT", "UPDATE"}, privileges[1].Privileges)
+
+	require.Equal(t, "editor", privileges[2].Role)
+	require.Equal(t, []string{"SELECT", "INSERT", "UPDATE", "DELETE"}, privileges[2].Privileges)
+}
+
+func TestAclBuildTablePrivilegesInvalid(t *testing.T) {
+	cases := map[string]*raiden.Acl{
+		"command": (&raiden.Acl{}).GrantTable(raiden.TableGrant("TRUNCATE").For("anon")),
+		"role":    (&raiden.Acl{}).GrantTable(raiden.TableGrant(raiden.CommandSelect)),
+		"empty":   (&raiden.Acl{}).GrantTable(raiden.TableGrant().For("anon")),
+		"column": (&raiden.Acl{}).
+			GrantTable(raiden.TableGrant(raiden.CommandSelect).For("anon")).
+			GrantColumns(raiden.ColumnGrant(raiden.PrivilegeSelect).For("anon").Columns("id")),
+	}
+
+	for name, acl := range cases {
+		_, err := acl.BuildTablePrivileges("public", "articles")
+		require.Error(t, err, name)
+	}
+
+	privileges, err := (&raiden.Acl{}).BuildTablePrivileges("public", "articles")
+	require.NoError(t, err)
+	require.Empty(t, privileges)
+}
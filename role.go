@@ -81,3 +81,35 @@ func (r *RoleBase) CanLogin() bool {
 func (r *RoleBase) ValidUntil() *objects.SupabaseTime {
 	return nil
 }
+
+// ----- Inherit Role With Admin Option -----
+type adminOptionRole struct {
+	Role
+}
+
+// WithAdminOption mark inherit role to be granted with admin option,
+// member of role can grant and revoke the inherit role to other role
+//
+// example :
+//
+//	func (r *Editor) InheritRoles() []raiden.Role {
+//		return []raiden.Role{&Reviewer{}, raiden.WithAdminOption(&Author{})}
+//	}
+func WithAdminOption(role Role) Role {
+	if role == nil {
+		return nil
+	}
+
+	if r, ok := role.(*adminOptionRole); ok {
+		return r
+	}
+	return &adminOptionRole{Role: role}
+}
+
+// RoleAdminOption return original role and true when role is marked by WithAdminOption
+func RoleAdminOption(role Role) (Role, bool) {
+	if r, ok := role.(*adminOptionRole); ok {
+		return r.Role, true
+	}
+	return role, false
+}
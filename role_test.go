@@ -74,3 +74,21 @@ func TestRoleInterfaceDefaults(t *testing.T) {
 	assert.Len(t, r.InheritRoles(), 0)
 	assert.Nil(t, r.ValidUntil())
 }
+
+func TestWithAdminOption(t *testing.T) {
+	author := &sampleRole{name: "author"}
+
+	role, isAdmin := raiden.RoleAdminOption(author)
+	assert.False(t, isAdmin)
+	assert.Equal(t, author, role)
+
+	wrapped := raiden.WithAdminOption(author)
+	assert.Equal(t, "author", wrapped.Name())
+	assert.Equal(t, wrapped, raiden.WithAdminOption(wrapped))
+
+	role, isAdmin = raiden.RoleAdminOption(wrapped)
+	assert.True(t, isAdmin)
+	assert.Equal(t, author, role)
+
+	assert.Nil(t, raiden.WithAdminOption(nil))
+}
@@ -271,6 +271,9 @@ func (r *router) registerHttpHandler(route *Route) {
 }
 
 func (r *router) registerRestHandler(route *Route) {
+	// tenant column of model is resolved once, rest proxy read it on every request
+	RegisterTenantModel(route.Model)
+
 	chain := NewChain()
 	if group := r.findRouteGroup(route.Type); group != nil {
 		chain = r.buildNativeMiddleware(route, chain)
@@ -304,6 +304,35 @@ func TestRouter_RestRouteRegistersHead(t *testing.T) {
 	assert.Contains(t, registeredRoutes[fasthttp.MethodHead], "/rest/v1/rest_head_check")
 }
 
+type routerTenantModel struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	OrgId string `json:"org_id,omitempty" column:"name:org_id;type:text;tenant"`
+
+	Metadata string `json:"-" schema:"public" tableName:"router_tenant_projects"`
+}
+
+func TestRouter_RestRouteRegistersTenantModel(t *testing.T) {
+	conf := loadConfig()
+	router := raiden.NewRouter(conf)
+
+	restRoute := raiden.Route{
+		Type:       raiden.RouteTypeRest,
+		Path:       "/rest/v1/router_tenant_projects",
+		Controller: &RestController{},
+		Model:      &routerTenantModel{},
+	}
+
+	router.Register([]*raiden.Route{&restRoute})
+	router.BuildHandler()
+
+	// tenant model is registered with the route, before any request is handled
+	cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", JwtSecret: tenantJwtSecret}
+	ctx := newTenantCtx(cfg, nil)
+	ctx.Request.SetRequestURI("/rest/v1/router_tenant_projects")
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	assert.Equal(t, raiden.ErrTenantNotResolved, raiden.RestProxy(&ctx, "router_tenant_projects"))
+}
+
 func TestRouter_NewRouteFromCustomController(t *testing.T) {
 	methods := []string{fasthttp.MethodGet}
 	r := raiden.NewRouteFromController(&HelloWorldController{}, methods)
@@ -54,6 +54,21 @@ const (
 	RpcParamDataTypeArrayOfText         RpcParamDataType = "TEXT[]"
 	RpcParamDataTypeArrayOfVarchar      RpcParamDataType = "CHARACTER VARYING[]"
 	RpcParamDataTypeArrayOfVarcharAlias RpcParamDataType = "VARCHAR[]"
+
+	RpcParamDataTypeDecimal                 RpcParamDataType = "DECIMAL"
+	RpcParamDataTypeInterval                RpcParamDataType = "INTERVAL"
+	RpcParamDataTypeInet                    RpcParamDataType = "INET"
+	RpcParamDataTypeTimestampTZShortAlias   RpcParamDataType = "TIMESTAMPTZ"
+	RpcParamDataTypeArrayOfBoolean          RpcParamDataType = "BOOLEAN[]"
+	RpcParamDataTypeArrayOfDate             RpcParamDataType = "DATE[]"
+	RpcParamDataTypeArrayOfTimestamp        RpcParamDataType = "TIMESTAMP WITHOUT TIME ZONE[]"
+	RpcParamDataTypeArrayOfTimestampAlias   RpcParamDataType = "TIMESTAMP[]"
+	RpcParamDataTypeArrayOfTimestampTZ      RpcParamDataType = "TIMESTAMP WITH TIME ZONE[]"
+	RpcParamDataTypeArrayOfTimestampTZAlias RpcParamDataType = "TIMESTAMPTZ[]"
+	RpcParamDataTypeArrayOfJSON             RpcParamDataType = "JSON[]"
+	RpcParamDataTypeArrayOfJSONB            RpcParamDataType = "JSONB[]"
+	RpcParamDataTypeArrayOfInterval         RpcParamDataType = "INTERVAL[]"
+	RpcParamDataTypeArrayOfInet             RpcParamDataType = "INET[]"
 )
 
 // Define constants for rpc return data type
@@ -89,23 +104,119 @@ const (
 	RpcReturnDataTypeArrayOfText         RpcReturnDataType = "TEXT[]"
 	RpcReturnDataTypeArrayOfVarchar      RpcReturnDataType = "CHARACTER VARYING[]"
 	RpcReturnDataTypeArrayOfVarcharAlias RpcReturnDataType = "VARCHAR[]"
+
+	RpcReturnDataTypeNumeric                 RpcReturnDataType = "NUMERIC"
+	RpcReturnDataTypeInterval                RpcReturnDataType = "INTERVAL"
+	RpcReturnDataTypeInet                    RpcReturnDataType = "INET"
+	RpcReturnDataTypeTimestampTZShortAlias   RpcReturnDataType = "TIMESTAMPTZ"
+	RpcReturnDataTypeArrayOfUUID             RpcReturnDataType = "UUID[]"
+	RpcReturnDataTypeArrayOfBoolean          RpcReturnDataType = "BOOLEAN[]"
+	RpcReturnDataTypeArrayOfDate             RpcReturnDataType = "DATE[]"
+	RpcReturnDataTypeArrayOfTimestamp        RpcReturnDataType = "TIMESTAMP WITHOUT TIME ZONE[]"
+	RpcReturnDataTypeArrayOfTimestampAlias   RpcReturnDataType = "TIMESTAMP[]"
+	RpcReturnDataTypeArrayOfTimestampTZ      RpcReturnDataType = "TIMESTAMP WITH TIME ZONE[]"
+	RpcReturnDataTypeArrayOfTimestampTZAlias RpcReturnDataType = "TIMESTAMPTZ[]"
+	RpcReturnDataTypeArrayOfJSON             RpcReturnDataType = "JSON[]"
+	RpcReturnDataTypeArrayOfJSONB            RpcReturnDataType = "JSONB[]"
+	RpcReturnDataTypeArrayOfInterval         RpcReturnDataType = "INTERVAL[]"
+	RpcReturnDataTypeArrayOfInet             RpcReturnDataType = "INET[]"
 )
 
+// rpcTypeModifierRegex match type with precision modifier, ex : numeric(10,2), character varying(255)[]
+var rpcTypeModifierRegex = regexp.MustCompile(`^(.+?)\s*(\(\s*\d+\s*(?:,\s*\d+\s*)?\))\s*(\[\])?$`)
+
+// SplitRpcTypeModifier split type declaration into base type and precision modifier,
+// ex : NUMERIC(10,2)[] will be split into NUMERIC[] and (10,2)
+func SplitRpcTypeModifier(pType string) (baseType string, modifier string) {
+	pType = strings.TrimSpace(pType)
+	match := rpcTypeModifierRegex.FindStringSubmatch(pType)
+	if len(match) != 4 {
+		return pType, ""
+	}
+	return strings.TrimSpace(match[1]) + match[3], strings.ReplaceAll(match[2], " ", "")
+}
+
+// joinRpcTypeModifier put precision modifier back before array suffix
+func joinRpcTypeModifier(baseType string, modifier string) string {
+	if modifier == "" {
+		return baseType
+	}
+
+	if strings.HasSuffix(baseType, "[]") {
+		return strings.TrimSuffix(baseType, "[]") + modifier + "[]"
+	}
+	return baseType + modifier
+}
+
+func isRpcTypeSupportModifier(baseType string) bool {
+	switch strings.TrimSuffix(baseType, "[]") {
+	case string(RpcParamDataTypeNumeric), string(RpcParamDataTypeDecimal),
+		string(RpcParamDataTypeVarchar), string(RpcParamDataTypeVarcharAlias):
+		return true
+	default:
+		return false
+	}
+}
+
+// getRegisteredRpcType find registered user defined type (enum or composite),
+// array of registered type is supported too
+func getRegisteredRpcType(pType string) (t Type, isArray bool, found bool) {
+	name := strings.TrimSpace(pType)
+	if strings.HasSuffix(name, "[]") {
+		isArray = true
+		name = strings.TrimSuffix(name, "[]")
+	}
+
+	t, found = GetRegisteredType(name)
+	return
+}
+
+// getRegisteredRpcTypeName return normalized user defined type name for rpc declaration
+func getRegisteredRpcTypeName(pType string) (string, bool) {
+	t, isArray, found := getRegisteredRpcType(pType)
+	if !found {
+		return "", false
+	}
+
+	name := strings.ToLower(GetTypeQualifiedName(t))
+	if isArray {
+		name += "[]"
+	}
+	return name, true
+}
+
+// getRegisteredRpcGoType return go type declaration of user defined type,
+// type is generated in types package by raiden generator
+func getRegisteredRpcGoType(pType string) (string, bool) {
+	t, isArray, found := getRegisteredRpcType(pType)
+	if !found {
+		return "", false
+	}
+
+	goType := fmt.Sprintf("types.%s", utils.SnakeCaseToPascalCase(t.Name()))
+	if isArray {
+		goType = "[]" + goType
+	}
+	return goType, true
+}
+
 func RpcParamToGoType(dataType RpcParamDataType) string {
-	switch dataType {
+	baseType, _ := SplitRpcTypeModifier(strings.ToUpper(string(dataType)))
+	switch RpcParamDataType(baseType) {
 	case RpcParamDataTypeInteger, RpcParamDataTypeBigInt:
 		return "int64"
 	case RpcParamDataTypeReal:
 		return "float32"
-	case RpcParamDataTypeDoublePreci, RpcParamDataTypeNumeric:
+	case RpcParamDataTypeDoublePreci, RpcParamDataTypeNumeric, RpcParamDataTypeDecimal:
 		return "float64"
-	case RpcParamDataTypeText, RpcParamDataTypeVarchar, RpcParamDataTypeVarcharAlias:
+	case RpcParamDataTypeText, RpcParamDataTypeVarchar, RpcParamDataTypeVarcharAlias,
+		RpcParamDataTypeInterval, RpcParamDataTypeInet:
 		return "string"
 	case RpcParamDataTypeBoolean:
 		return "bool"
 	case RpcParamDataTypeBytea:
 		return "[]byte"
-	case RpcParamDataTypeTimestamp, RpcParamDataTypeTimestampTZ, RpcParamDataTypeTimestampAlias, RpcParamDataTypeTimestampTZAlias:
+	case RpcParamDataTypeTimestamp, RpcParamDataTypeTimestampTZ, RpcParamDataTypeTimestampAlias, RpcParamDataTypeTimestampTZAlias, RpcParamDataTypeTimestampTZShortAlias:
 		return "postgres.DateTime"
 	case RpcParamDataTypeJSON, RpcParamDataTypeJSONB:
 		return "map[string]interface{}"
@@ -123,14 +234,39 @@ func RpcParamToGoType(dataType RpcParamDataType) string {
 		return "[]float32"
 	case RpcParamDataTypeArrayOfDoublePreci, RpcParamDataTypeArrayOfNumeric:
 		return "[]float64"
-	case RpcParamDataTypeArrayOfText, RpcParamDataTypeArrayOfVarchar, RpcParamDataTypeArrayOfVarcharAlias:
+	case RpcParamDataTypeArrayOfText, RpcParamDataTypeArrayOfVarchar, RpcParamDataTypeArrayOfVarcharAlias,
+		RpcParamDataTypeArrayOfInterval, RpcParamDataTypeArrayOfInet:
 		return "[]string"
+	case RpcParamDataTypeArrayOfBoolean:
+		return "[]bool"
+	case RpcParamDataTypeArrayOfDate:
+		return "[]postgres.Date"
+	case RpcParamDataTypeArrayOfTimestamp, RpcParamDataTypeArrayOfTimestampAlias, RpcParamDataTypeArrayOfTimestampTZ, RpcParamDataTypeArrayOfTimestampTZAlias:
+		return "[]postgres.DateTime"
+	case RpcParamDataTypeArrayOfJSON, RpcParamDataTypeArrayOfJSONB:
+		return "[]map[string]interface{}"
 	default:
+		if goType, found := getRegisteredRpcGoType(string(dataType)); found {
+			return goType
+		}
 		return "interface{}" // Return interface{} for unknown types
 	}
 }
 
 func GetValidRpcParamType(pType string, returnAlias bool) (RpcParamDataType, error) {
+	baseType, modifier := SplitRpcTypeModifier(strings.ToUpper(pType))
+	if modifier != "" {
+		if !isRpcTypeSupportModifier(baseType) {
+			return "", fmt.Errorf("unsupported rpc param type  : %s", strings.ToUpper(pType))
+		}
+
+		validType, err := GetValidRpcParamType(baseType, returnAlias)
+		if err != nil {
+			return "", err
+		}
+		return RpcParamDataType(joinRpcTypeModifier(string(validType), modifier)), nil
+	}
+
 	pCheckType := RpcParamDataType(strings.ToUpper(pType))
 	switch pCheckType {
 	case RpcParamDataTypeInteger:
@@ -141,7 +277,7 @@ func GetValidRpcParamType(pType string, returnAlias bool) (RpcParamDataType, err
 		return RpcParamDataTypeReal, nil
 	case RpcParamDataTypeDoublePreci:
 		return RpcParamDataTypeDoublePreci, nil
-	case RpcParamDataTypeNumeric:
+	case RpcParamDataTypeNumeric, RpcParamDataTypeDecimal:
 		return RpcParamDataTypeNumeric, nil
 	case RpcParamDataTypeText:
 		return RpcParamDataTypeText, nil
@@ -159,7 +295,7 @@ func GetValidRpcParamType(pType string, returnAlias bool) (RpcParamDataType, err
 			return RpcParamDataTypeTimestampAlias, nil
 		}
 		return RpcParamDataTypeTimestamp, nil
-	case RpcParamDataTypeTimestampTZ, RpcParamDataTypeTimestampTZAlias:
+	case RpcParamDataTypeTimestampTZ, RpcParamDataTypeTimestampTZAlias, RpcParamDataTypeTimestampTZShortAlias:
 		if returnAlias {
 			return RpcParamDataTypeTimestampTZAlias, nil
 		}
@@ -193,26 +329,57 @@ func GetValidRpcParamType(pType string, returnAlias bool) (RpcParamDataType, err
 		return RpcParamDataTypeArrayOfUuid, nil
 	case RpcParamDataTypePoint:
 		return RpcParamDataTypePoint, nil
+	case RpcParamDataTypeInterval:
+		return RpcParamDataTypeInterval, nil
+	case RpcParamDataTypeInet:
+		return RpcParamDataTypeInet, nil
+	case RpcParamDataTypeArrayOfBoolean:
+		return RpcParamDataTypeArrayOfBoolean, nil
+	case RpcParamDataTypeArrayOfDate:
+		return RpcParamDataTypeArrayOfDate, nil
+	case RpcParamDataTypeArrayOfTimestamp, RpcParamDataTypeArrayOfTimestampAlias:
+		if returnAlias {
+			return RpcParamDataTypeArrayOfTimestampAlias, nil
+		}
+		return RpcParamDataTypeArrayOfTimestamp, nil
+	case RpcParamDataTypeArrayOfTimestampTZ, RpcParamDataTypeArrayOfTimestampTZAlias:
+		if returnAlias {
+			return RpcParamDataTypeArrayOfTimestampTZAlias, nil
+		}
+		return RpcParamDataTypeArrayOfTimestampTZ, nil
+	case RpcParamDataTypeArrayOfJSON:
+		return RpcParamDataTypeArrayOfJSON, nil
+	case RpcParamDataTypeArrayOfJSONB:
+		return RpcParamDataTypeArrayOfJSONB, nil
+	case RpcParamDataTypeArrayOfInterval:
+		return RpcParamDataTypeArrayOfInterval, nil
+	case RpcParamDataTypeArrayOfInet:
+		return RpcParamDataTypeArrayOfInet, nil
 	default:
+		if name, found := getRegisteredRpcTypeName(pType); found {
+			return RpcParamDataType(name), nil
+		}
 		return "", fmt.Errorf("unsupported rpc param type  : %s", pCheckType)
 	}
 }
 
 func RpcReturnToGoType(dataType RpcReturnDataType) string {
-	switch dataType {
+	baseType, _ := SplitRpcTypeModifier(strings.ToUpper(string(dataType)))
+	switch RpcReturnDataType(baseType) {
 	case RpcReturnDataTypeInteger, RpcReturnDataTypeBigInt:
 		return "int64"
 	case RpcReturnDataTypeReal:
 		return "float32"
-	case RpcReturnDataTypeDoublePreci:
+	case RpcReturnDataTypeDoublePreci, RpcReturnDataTypeNumeric:
 		return "float64"
-	case RpcReturnDataTypeText, RpcReturnDataTypeVarchar:
+	case RpcReturnDataTypeText, RpcReturnDataTypeVarchar, RpcReturnDataTypeVarcharAlias,
+		RpcReturnDataTypeInterval, RpcReturnDataTypeInet:
 		return "string"
 	case RpcReturnDataTypeBoolean:
 		return "bool"
 	case RpcReturnDataTypeBytea:
 		return "[]byte"
-	case RpcReturnDataTypeTimestamp, RpcReturnDataTypeTimestampTZ:
+	case RpcReturnDataTypeTimestamp, RpcReturnDataTypeTimestampTZ, RpcReturnDataTypeTimestampAlias, RpcReturnDataTypeTimestampTZAlias, RpcReturnDataTypeTimestampTZShortAlias:
 		return "postgres.DateTime"
 	case RpcReturnDataTypeJSON, RpcReturnDataTypeJSONB:
 		return "map[string]interface{}"
@@ -228,14 +395,41 @@ func RpcReturnToGoType(dataType RpcReturnDataType) string {
 		return "[]float32"
 	case RpcReturnDataTypeArrayOfDoublePreci, RpcReturnDataTypeArrayOfNumeric:
 		return "[]float64"
-	case RpcReturnDataTypeArrayOfText, RpcReturnDataTypeArrayOfVarchar, RpcReturnDataTypeArrayOfVarcharAlias:
+	case RpcReturnDataTypeArrayOfText, RpcReturnDataTypeArrayOfVarchar, RpcReturnDataTypeArrayOfVarcharAlias,
+		RpcReturnDataTypeArrayOfInterval, RpcReturnDataTypeArrayOfInet:
 		return "[]string"
+	case RpcReturnDataTypeArrayOfUUID:
+		return "[]uuid.UUID"
+	case RpcReturnDataTypeArrayOfBoolean:
+		return "[]bool"
+	case RpcReturnDataTypeArrayOfDate:
+		return "[]postgres.Date"
+	case RpcReturnDataTypeArrayOfTimestamp, RpcReturnDataTypeArrayOfTimestampAlias, RpcReturnDataTypeArrayOfTimestampTZ, RpcReturnDataTypeArrayOfTimestampTZAlias:
+		return "[]postgres.DateTime"
+	case RpcReturnDataTypeArrayOfJSON, RpcReturnDataTypeArrayOfJSONB:
+		return "[]map[string]interface{}"
 	default:
+		if goType, found := getRegisteredRpcGoType(string(dataType)); found {
+			return goType
+		}
 		return "interface{}" // Return interface{} for unknown types
 	}
 }
 
 func GetValidRpcReturnType(pType string, returnAlias bool) (RpcReturnDataType, error) {
+	baseType, modifier := SplitRpcTypeModifier(strings.ToUpper(pType))
+	if modifier != "" {
+		if !isRpcTypeSupportModifier(baseType) {
+			return "", fmt.Errorf("unsupported rpc return type  : %s", strings.ToUpper(pType))
+		}
+
+		validType, err := GetValidRpcReturnType(baseType, returnAlias)
+		if err != nil {
+			return "", err
+		}
+		return RpcReturnDataType(joinRpcTypeModifier(string(validType), modifier)), nil
+	}
+
 	pCheckType := RpcReturnDataType(strings.ToUpper(pType))
 	switch pCheckType {
 	case RpcReturnDataTypeInteger:
@@ -262,7 +456,7 @@ func GetValidRpcReturnType(pType string, returnAlias bool) (RpcReturnDataType, e
 			return RpcReturnDataTypeTimestampAlias, nil
 		}
 		return RpcReturnDataTypeTimestamp, nil
-	case RpcReturnDataTypeTimestampTZ, RpcReturnDataTypeTimestampTZAlias:
+	case RpcReturnDataTypeTimestampTZ, RpcReturnDataTypeTimestampTZAlias, RpcReturnDataTypeTimestampTZShortAlias:
 		if returnAlias {
 			return RpcReturnDataTypeTimestampTZAlias, nil
 		}
@@ -302,7 +496,40 @@ func GetValidRpcReturnType(pType string, returnAlias bool) (RpcReturnDataType, e
 		return RpcReturnDataTypePoint, nil
 	case RpcReturnDataTypeUUID:
 		return RpcReturnDataTypeUUID, nil
+	case RpcReturnDataTypeNumeric, RpcReturnDataType(RpcParamDataTypeDecimal):
+		return RpcReturnDataTypeNumeric, nil
+	case RpcReturnDataTypeInterval:
+		return RpcReturnDataTypeInterval, nil
+	case RpcReturnDataTypeInet:
+		return RpcReturnDataTypeInet, nil
+	case RpcReturnDataTypeArrayOfUUID:
+		return RpcReturnDataTypeArrayOfUUID, nil
+	case RpcReturnDataTypeArrayOfBoolean:
+		return RpcReturnDataTypeArrayOfBoolean, nil
+	case RpcReturnDataTypeArrayOfDate:
+		return RpcReturnDataTypeArrayOfDate, nil
+	case RpcReturnDataTypeArrayOfTimestamp, RpcReturnDataTypeArrayOfTimestampAlias:
+		if returnAlias {
+			return RpcReturnDataTypeArrayOfTimestampAlias, nil
+		}
+		return RpcReturnDataTypeArrayOfTimestamp, nil
+	case RpcReturnDataTypeArrayOfTimestampTZ, RpcReturnDataTypeArrayOfTimestampTZAlias:
+		if returnAlias {
+			return RpcReturnDataTypeArrayOfTimestampTZAlias, nil
+		}
+		return RpcReturnDataTypeArrayOfTimestampTZ, nil
+	case RpcReturnDataTypeArrayOfJSON:
+		return RpcReturnDataTypeArrayOfJSON, nil
+	case RpcReturnDataTypeArrayOfJSONB:
+		return RpcReturnDataTypeArrayOfJSONB, nil
+	case RpcReturnDataTypeArrayOfInterval:
+		return RpcReturnDataTypeArrayOfInterval, nil
+	case RpcReturnDataTypeArrayOfInet:
+		return RpcReturnDataTypeArrayOfInet, nil
 	default:
+		if name, found := getRegisteredRpcTypeName(pType); found {
+			return RpcReturnDataType(name), nil
+		}
 		return "", fmt.Errorf("unsupported rpc return type  : %s", pCheckType)
 	}
 }
@@ -371,7 +598,47 @@ func GetValidRpcReturnNameDecl(pType RpcReturnDataType, returnAlias bool) (strin
 		return "RpcReturnDataTypePoint", nil
 	case RpcReturnDataTypeUUID:
 		return "RpcReturnDataTypeUUID", nil
+	case RpcReturnDataTypeNumeric:
+		return "RpcReturnDataTypeNumeric", nil
+	case RpcReturnDataTypeInterval:
+		return "RpcReturnDataTypeInterval", nil
+	case RpcReturnDataTypeInet:
+		return "RpcReturnDataTypeInet", nil
+	case RpcReturnDataTypeArrayOfNumeric:
+		return "RpcReturnDataTypeArrayOfNumeric", nil
+	case RpcReturnDataTypeArrayOfUUID:
+		return "RpcReturnDataTypeArrayOfUUID", nil
+	case RpcReturnDataTypeArrayOfBoolean:
+		return "RpcReturnDataTypeArrayOfBoolean", nil
+	case RpcReturnDataTypeArrayOfDate:
+		return "RpcReturnDataTypeArrayOfDate", nil
+	case RpcReturnDataTypeArrayOfTimestamp, RpcReturnDataTypeArrayOfTimestampAlias:
+		if returnAlias {
+			return "RpcReturnDataTypeArrayOfTimestampAlias", nil
+		}
+		return "RpcReturnDataTypeArrayOfTimestamp", nil
+	case RpcReturnDataTypeArrayOfTimestampTZ, RpcReturnDataTypeArrayOfTimestampTZAlias:
+		if returnAlias {
+			return "RpcReturnDataTypeArrayOfTimestampTZAlias", nil
+		}
+		return "RpcReturnDataTypeArrayOfTimestampTZ", nil
+	case RpcReturnDataTypeArrayOfJSON:
+		return "RpcReturnDataTypeArrayOfJSON", nil
+	case RpcReturnDataTypeArrayOfJSONB:
+		return "RpcReturnDataTypeArrayOfJSONB", nil
+	case RpcReturnDataTypeArrayOfInterval:
+		return "RpcReturnDataTypeArrayOfInterval", nil
+	case RpcReturnDataTypeArrayOfInet:
+		return "RpcReturnDataTypeArrayOfInet", nil
 	default:
+		// precision and user defined type doesn't have constant, declare it as literal
+		if _, modifier := SplitRpcTypeModifier(string(pType)); modifier != "" {
+			return fmt.Sprintf("RpcReturnDataType(%q)", strings.ToLower(string(pType))), nil
+		}
+
+		if name, found := getRegisteredRpcTypeName(string(pType)); found {
+			return fmt.Sprintf("RpcReturnDataType(%q)", name), nil
+		}
 		return "", fmt.Errorf("unsupported rpc return name declaration  : %s", pType)
 	}
 }
@@ -842,6 +1109,11 @@ func extractRpcParam(paramType reflect.Type) (params RpcParams, err error) {
 			continue
 		}
 
+		// make user defined type in param field resolvable by type tag
+		if t, isType := findRpcUserType(field.Type); isType {
+			RegisterType(t)
+		}
+
 		ct, err := UnmarshalRpcParamTag(columnTagStr)
 		if err != nil {
 			return params, err
@@ -878,6 +1150,11 @@ func extractRpcResult(returnReflectType reflect.Type, rpc Rpc) (q string, err er
 }
 
 func buildRpcReturnSetOf(returnReflectType reflect.Type) (q string, err error) {
+	if t, isType := findRpcUserType(returnReflectType); isType {
+		RegisterType(t)
+		return fmt.Sprintf("setof %s", GetTypeQualifiedName(t)), nil
+	}
+
 	st, err := findStruct(returnReflectType)
 	if err != nil {
 		return "", err
@@ -941,6 +1218,24 @@ func buildRpcDefinition(rpc Rpc) string {
 	return definition
 }
 
+// findRpcUserType check if field type (or its element) implement Type,
+// ex : types.Status, *types.Status and []types.Status
+func findRpcUserType(fieldType reflect.Type) (Type, bool) {
+	for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	t, isType := reflect.New(fieldType).Interface().(Type)
+	if !isType || t.Name() == "" {
+		return nil, false
+	}
+	return t, true
+}
+
 func findStruct(returnReflectType reflect.Type) (reflect.Type, error) {
 	switch returnReflectType.Kind() {
 	case reflect.Ptr:
@@ -955,7 +1250,19 @@ func findStruct(returnReflectType reflect.Type) (reflect.Type, error) {
 }
 
 // ----- Execute Rpc -----
-func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
+// RpcRequest is upstream request of rpc,
+// contains encoded params and auth header of caller
+type RpcRequest struct {
+	BaseUrl string
+	Path    string
+	Query   string
+	Body    []byte
+	Header  http.Header
+}
+
+// NewRpcRequest build upstream request of rpc from context,
+// used by ExecuteRpc and can be used for custom execution like pagination
+func NewRpcRequest(ctx Context, rpc Rpc) (*RpcRequest, error) {
 	rpcType := reflect.TypeOf(rpc).Elem()
 	rpcValue := reflect.ValueOf(rpc).Elem()
 	if rpcType.Kind() == reflect.Pointer {
@@ -981,8 +1288,7 @@ func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
 		paramValue = paramValue.Elem()
 	}
 
-	returnField, found := rpcType.FieldByName("Return")
-	if !found {
+	if _, found := rpcType.FieldByName("Return"); !found {
 		return nil, &ErrorResponse{
 			StatusCode: fasthttp.StatusInternalServerError,
 			Details:    fmt.Sprintf("Struct %s doesn`t have Return field, define first because this attribute need for receive data from server", rpcType.Name()),
@@ -1063,12 +1369,15 @@ func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
 		}
 	}
 
-	apiUrl := fmt.Sprintf("%s/%s/%s", ctx.Config().SupabasePublicUrl, "rest/v1/rpc", rpc.GetName())
+	req := &RpcRequest{
+		BaseUrl: fmt.Sprintf("%s/%s", ctx.Config().SupabasePublicUrl, "rest/v1"),
+		Path:    fmt.Sprintf("rpc/%s", rpc.GetName()),
+		Body:    pByte,
+	}
+
 	if ctx.Config().Mode == SvcMode {
-		baseUrl := ctx.Config().PostgRestUrl
 		// Trim trailing slash for consistency
-		baseUrl = strings.TrimSuffix(baseUrl, "/")
-		apiUrl = fmt.Sprintf("%s/rpc/%s", baseUrl, rpc.GetName())
+		req.BaseUrl = strings.TrimSuffix(ctx.Config().PostgRestUrl, "/")
 	}
 
 	if string(ctx.RequestContext().QueryArgs().QueryString()) != "" {
@@ -1086,23 +1395,87 @@ func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
 			}
 		}
 
-		queryParams := strings.Join(queryParamsSlice, "&")
-		apiUrl = fmt.Sprintf("%s?%s", apiUrl, queryParams)
+		req.Query = strings.Join(queryParamsSlice, "&")
 	}
 
 	httpReq, err := ConvertRequestCtxToHTTPRequest(ctx.RequestContext())
 	if err != nil {
 		return nil, err
 	}
+	req.Header = httpReq.Header
 
-	resData, err := rpcSendRequest(apiUrl, pByte, rpcAttachAuthHeader(httpReq))
+	return req, nil
+}
+
+// Url return full upstream url include remaining query params
+func (r *RpcRequest) Url() string {
+	apiUrl := fmt.Sprintf("%s/%s", r.BaseUrl, r.Path)
+	if r.Query != "" {
+		apiUrl = fmt.Sprintf("%s?%s", apiUrl, r.Query)
+	}
+	return apiUrl
+}
+
+func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
+	req, err := NewRpcRequest(ctx, rpc)
 	if err != nil {
 		return nil, err
 	}
+	apiUrl := req.Url()
+
+	// serve from cache when rpc opt-in and result is deterministic
+	var cacheKey string
+	cacheTTL, isCacheable := getRpcCacheTTL(rpc)
+	if isCacheable {
+		var jwtSecret string
+		if config := ctx.Config(); config != nil {
+			jwtSecret = config.JwtSecret
+		}
+		cacheKey, isCacheable = buildRpcCacheKey(rpc, apiUrl, req.Body, req.Header, jwtSecret)
+	}
+
+	var resData []byte
+	if isCacheable && !isRpcCacheBypassed(req.Header) {
+		if cached, found := GetRpcCacheStore().Get(cacheKey); found {
+			resData = cached
+		}
+	}
+
+	if resData == nil {
+		resData, err = rpcSendRequest(apiUrl, req.Body, rpcAttachAuthHeader(req.Header))
+		if err != nil {
+			return nil, err
+		}
+
+		if isCacheable {
+			GetRpcCacheStore().Set(cacheKey, resData, cacheTTL, getRpcCacheTags(rpc)...)
+		}
+	}
+
+	return BindRpcReturn(rpc, resData)
+}
+
+// BindRpcReturn decode response data and set the value to Return field of rpc
+func BindRpcReturn(rpc Rpc, data []byte) (any, error) {
+	rpcValue := reflect.ValueOf(rpc).Elem()
+	if rpcValue.Kind() == reflect.Pointer {
+		rpcValue = rpcValue.Elem()
+	}
+
+	returnField, found := rpcValue.Type().FieldByName("Return")
+	if !found {
+		return nil, &ErrorResponse{
+			StatusCode: fasthttp.StatusInternalServerError,
+			Details:    fmt.Sprintf("Struct %s doesn`t have Return field, define first because this attribute need for receive data from server", rpcValue.Type().Name()),
+			Message:    fmt.Sprintf("Undefined field Return in struct %s", rpcValue.Type().Name()),
+			Hint:       "Invalid Rpc",
+			Code:       fasthttp.StatusMessage(fasthttp.StatusInternalServerError),
+		}
+	}
 
 	// sample data
 	returnObject := reflect.New(returnField.Type).Interface()
-	if err := json.Unmarshal(resData, returnObject); err != nil {
+	if err := json.Unmarshal(data, returnObject); err != nil {
 		return nil, &ErrorResponse{
 			StatusCode: fasthttp.StatusInternalServerError,
 			Details:    err,
@@ -1121,13 +1494,13 @@ func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
 	return returnValue.Interface(), nil
 }
 
-func rpcAttachAuthHeader(inReq *http.Request) net.RequestInterceptor {
+func rpcAttachAuthHeader(inHeader http.Header) net.RequestInterceptor {
 	return func(outReq *http.Request) error {
-		if authHeader := inReq.Header.Get("Authorization"); len(authHeader) > 0 {
+		if authHeader := inHeader.Get("Authorization"); len(authHeader) > 0 {
 			outReq.Header.Set("Authorization", authHeader)
 		}
 
-		if apiKey := inReq.Header.Get("apiKey"); len(apiKey) > 0 {
+		if apiKey := inHeader.Get("apiKey"); len(apiKey) > 0 {
 			outReq.Header.Set("apiKey", apiKey)
 		}
 
@@ -0,0 +1,330 @@
+package raiden
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/jwt"
+)
+
+// ----- Define type, variable and constant -----
+type (
+	// RpcCacheable is optional interface for rpc that want the result to be cached,
+	// cache only applied for rpc with stable or immutable behavior
+	RpcCacheable interface {
+		CacheTTL() time.Duration
+	}
+
+	// RpcCacheStore is storage for cached rpc response,
+	// every entry is tagged by rpc name and bound model table for invalidation
+	RpcCacheStore interface {
+		Get(key string) ([]byte, bool)
+		Set(key string, value []byte, ttl time.Duration, tags ...string)
+		Delete(key string)
+		DeleteByTag(tag string)
+	}
+
+	RpcMemoryCacheStore struct {
+		mu       sync.Mutex
+		capacity int
+		items    map[string]*list.Element
+		order    *list.List
+		tags     map[string]map[string]bool
+	}
+
+	rpcMemoryCacheItem struct {
+		key       string
+		value     []byte
+		expiredAt time.Time
+		tags      []string
+	}
+)
+
+const (
+	DefaultRpcCacheSize = 1000
+
+	rpcCacheTagPrefixRpc   = "rpc:"
+	rpcCacheTagPrefixTable = "table:"
+)
+
+var (
+	rpcCacheStore   RpcCacheStore
+	rpcCacheStoreMu sync.RWMutex
+
+	// claims that always change between token and doesn't affect query result,
+	// exp is kept so cached result never outlive the token
+	rpcCacheIgnoredClaims = []string{"iat", "nbf", "jti"}
+)
+
+// ----- Cache store registry -----
+
+// SetRpcCacheStore replace default in-memory store, ex : with redis implementation
+func SetRpcCacheStore(store RpcCacheStore) {
+	rpcCacheStoreMu.Lock()
+	defer rpcCacheStoreMu.Unlock()
+	rpcCacheStore = store
+}
+
+// GetRpcCacheStore return registered store or create default in-memory lru store
+func GetRpcCacheStore() RpcCacheStore {
+	rpcCacheStoreMu.RLock()
+	store := rpcCacheStore
+	rpcCacheStoreMu.RUnlock()
+	if store != nil {
+		return store
+	}
+
+	rpcCacheStoreMu.Lock()
+	defer rpcCacheStoreMu.Unlock()
+	if rpcCacheStore == nil {
+		rpcCacheStore = NewRpcMemoryCacheStore(DefaultRpcCacheSize)
+	}
+	return rpcCacheStore
+}
+
+// ----- Invalidation hook -----
+
+// InvalidateRpcCache remove all cached result of rpc
+func InvalidateRpcCache(rpc ...Rpc) {
+	for _, r := range rpc {
+		InvalidateRpcCacheByName(getRpcCacheName(r))
+	}
+}
+
+// InvalidateRpcCacheByName remove all cached result by rpc name, ex : get_votes or public.get_votes
+func InvalidateRpcCacheByName(names ...string) {
+	store := GetRpcCacheStore()
+	for _, name := range names {
+		if !strings.Contains(name, ".") {
+			name = fmt.Sprintf("%s.%s", DefaultRpcSchema, name)
+		}
+		store.DeleteByTag(rpcCacheTagPrefixRpc + strings.ToLower(name))
+	}
+}
+
+// InvalidateRpcCacheByTable remove cached result of all rpc that bind the table,
+// call this from job or subscriber when data in table is changed
+func InvalidateRpcCacheByTable(tables ...string) {
+	store := GetRpcCacheStore()
+	for _, table := range tables {
+		store.DeleteByTag(rpcCacheTagPrefixTable + strings.ToLower(table))
+	}
+}
+
+// InvalidateRpcCacheByModel remove cached result of all rpc that bind the model
+func InvalidateRpcCacheByModel(models ...any) {
+	for _, m := range models {
+		InvalidateRpcCacheByTable(GetTableName(m))
+	}
+}
+
+// ----- Cache helper -----
+
+// getRpcCacheTTL return ttl when rpc opt-in to cache and the behavior allow it
+func getRpcCacheTTL(rpc Rpc) (time.Duration, bool) {
+	cacheable, ok := rpc.(RpcCacheable)
+	if !ok {
+		return 0, false
+	}
+
+	if rpc.GetBehavior() != RpcBehaviorStable && rpc.GetBehavior() != RpcBehaviorImmutable {
+		return 0, false
+	}
+
+	ttl := cacheable.CacheTTL()
+	return ttl, ttl > 0
+}
+
+func getRpcCacheName(rpc Rpc) string {
+	schema := rpc.GetSchema()
+	if schema == "" {
+		schema = DefaultRpcSchema
+	}
+	return strings.ToLower(fmt.Sprintf("%s.%s", schema, rpc.GetName()))
+}
+
+func getRpcCacheTags(rpc Rpc) []string {
+	tags := []string{rpcCacheTagPrefixRpc + getRpcCacheName(rpc)}
+	for _, m := range rpc.GetModels() {
+		if m.Model == nil {
+			continue
+		}
+		tags = append(tags, rpcCacheTagPrefixTable+strings.ToLower(GetTableName(m.Model)))
+	}
+	return tags
+}
+
+// buildRpcCacheKey create cache key from function, request url (include query params),
+// params and caller identity so result is never shared between different role or user,
+// return false when caller identity can't be verified
+func buildRpcCacheKey(rpc Rpc, apiUrl string, params []byte, header http.Header, jwtSecret string) (string, bool) {
+	identity, ok := getRpcCacheIdentity(header, jwtSecret)
+	if !ok {
+		return "", false
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(apiUrl))
+	hash.Write([]byte{0})
+	hash.Write(params)
+	hash.Write([]byte{0})
+	hash.Write([]byte(identity))
+
+	return fmt.Sprintf("%s%s:%s", rpcCacheTagPrefixRpc, getRpcCacheName(rpc), hex.EncodeToString(hash.Sum(nil))), true
+}
+
+// getRpcCacheIdentity return claims of verified token as caller identity,
+// token with invalid signature or expired token is never used as identity
+// because cached result is returned before postgrest validate the token
+func getRpcCacheIdentity(header http.Header, jwtSecret string) (string, bool) {
+	token := strings.TrimSpace(header.Get("Authorization"))
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+
+	if token == "" {
+		token = header.Get("apiKey")
+	}
+
+	if token == "" {
+		return "", true
+	}
+
+	if jwtSecret == "" {
+		return "", false
+	}
+
+	validated, err := jwt.Validate[map[string]any](token, jwtSecret)
+	if err != nil || validated == nil {
+		return "", false
+	}
+
+	claims := *validated
+	for _, c := range rpcCacheIgnoredClaims {
+		delete(claims, c)
+	}
+
+	// map key is sorted by encoder, so identity is stable between request
+	identity, err := json.Marshal(claims)
+	if err != nil {
+		return "", false
+	}
+	return string(identity), true
+}
+
+func isRpcCacheBypassed(header http.Header) bool {
+	cacheControl := strings.ToLower(header.Get("Cache-Control"))
+	return strings.Contains(cacheControl, "no-cache") || strings.Contains(cacheControl, "no-store")
+}
+
+// ----- In memory lru store -----
+
+func NewRpcMemoryCacheStore(capacity int) *RpcMemoryCacheStore {
+	if capacity <= 0 {
+		capacity = DefaultRpcCacheSize
+	}
+
+	return &RpcMemoryCacheStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		tags:     make(map[string]map[string]bool),
+	}
+}
+
+func (s *RpcMemoryCacheStore) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, exist := s.items[key]
+	if !exist {
+		return nil, false
+	}
+
+	item := el.Value.(*rpcMemoryCacheItem)
+	if time.Now().After(item.expiredAt) {
+		s.removeElement(el)
+		return nil, false
+	}
+
+	s.order.MoveToFront(el)
+	return item.value, true
+}
+
+func (s *RpcMemoryCacheStore) Set(key string, value []byte, ttl time.Duration, tags ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, exist := s.items[key]; exist {
+		s.removeElement(el)
+	}
+
+	item := &rpcMemoryCacheItem{
+		key:       key,
+		value:     value,
+		expiredAt: time.Now().Add(ttl),
+		tags:      tags,
+	}
+	s.items[key] = s.order.PushFront(item)
+
+	for _, tag := range tags {
+		if _, exist := s.tags[tag]; !exist {
+			s.tags[tag] = make(map[string]bool)
+		}
+		s.tags[tag][key] = true
+	}
+
+	for s.order.Len() > s.capacity {
+		s.removeElement(s.order.Back())
+	}
+}
+
+func (s *RpcMemoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, exist := s.items[key]; exist {
+		s.removeElement(el)
+	}
+}
+
+func (s *RpcMemoryCacheStore) DeleteByTag(tag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.tags[tag] {
+		if el, exist := s.items[key]; exist {
+			s.removeElement(el)
+		}
+	}
+	delete(s.tags, tag)
+}
+
+// Len return total cached entry, include expired entry that not evicted yet
+func (s *RpcMemoryCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+func (s *RpcMemoryCacheStore) removeElement(el *list.Element) {
+	item := el.Value.(*rpcMemoryCacheItem)
+	s.order.Remove(el)
+	delete(s.items, item.key)
+
+	for _, tag := range item.tags {
+		if keys, exist := s.tags[tag]; exist {
+			delete(keys, item.key)
+			if len(keys) == 0 {
+				delete(s.tags, tag)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package raiden_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type GetCachedSubmissions struct {
+	GetSubmissions
+}
+
+func (r *GetCachedSubmissions) GetBehavior() raiden.RpcBehaviorType {
+	return raiden.RpcBehaviorStable
+}
+
+func (r *GetCachedSubmissions) CacheTTL() time.Duration {
+	return time.Minute
+}
+
+type GetVolatileCachedSubmissions struct {
+	GetCachedSubmissions
+}
+
+func (r *GetVolatileCachedSubmissions) GetBehavior() raiden.RpcBehaviorType {
+	return raiden.RpcBehaviorVolatile
+}
+
+const rpcCacheJwtSecret = "rpc-cache-secret"
+
+func signRpcCacheToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return signed
+}
+
+func newRpcCacheMockContext(token string) *mock.MockContext {
+	return newRpcCacheMockContextWithSecret(token, rpcCacheJwtSecret)
+}
+
+func newRpcCacheMockContextWithSecret(token, secret string) *mock.MockContext {
+	return &mock.MockContext{
+		ConfigFn: func() *raiden.Config {
+			return &raiden.Config{
+				JwtSecret:           secret,
+				DeploymentTarget:    raiden.DeploymentTargetCloud,
+				ProjectId:           "test-project-id",
+				ProjectName:         "My Great Project",
+				SupabaseApiBasePath: "/v1",
+				SupabaseApiUrl:      "http://supabase.cloud.com",
+				SupabasePublicUrl:   "http://supabase.cloud.com",
+			}
+		},
+		RequestContextFn: func() *fasthttp.RequestCtx {
+			rCtx := &fasthttp.RequestCtx{
+				Request: fasthttp.Request{
+					Header: fasthttp.RequestHeader{},
+				},
+			}
+			rCtx.Request.Header.Set("Authorization", "Bearer "+token)
+			rCtx.Request.Header.Set("apiKey", "some api key")
+			return rCtx
+		},
+	}
+}
+
+func newCachedSubmissions() *GetCachedSubmissions {
+	return &GetCachedSubmissions{
+		GetSubmissions: GetSubmissions{
+			Params: &GetSubmissionsParams{ScouterName: "test_1", CandidateName: "test_2"},
+		},
+	}
+}
+
+func TestExecuteRpc_Cache(t *testing.T) {
+	raiden.SetRpcCacheStore(raiden.NewRpcMemoryCacheStore(10))
+	defer raiden.SetRpcCacheStore(nil)
+
+	userA := signRpcCacheToken(t, rpcCacheJwtSecret, jwt.MapClaims{"sub": "user-a", "role": "authenticated"})
+	userB := signRpcCacheToken(t, rpcCacheJwtSecret, jwt.MapClaims{"sub": "user-b", "role": "authenticated"})
+
+	mockCtx := newRpcCacheMockContext(userA)
+	mockSupabase := mock.MockSupabase{Cfg: mockCtx.Config()}
+	mockSupabase.Activate()
+	defer mockSupabase.Deactivate()
+
+	err := mockSupabase.MockExecuteRpcWithExpectedResponse(200, "get_submissions", GetSubmissionsResult{})
+	assert.NoError(t, err)
+
+	// second call served from cache
+	_, err = raiden.ExecuteRpc(mockCtx, newCachedSubmissions())
+	assert.NoError(t, err)
+	_, err = raiden.ExecuteRpc(mockCtx, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	// different caller never share cached result
+	_, err = raiden.ExecuteRpc(newRpcCacheMockContext(userB), newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	// different params create new entry
+	rpc := newCachedSubmissions()
+	rpc.Params.CandidateName = "test_3"
+	_, err = raiden.ExecuteRpc(mockCtx, rpc)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+
+	// invalidate by bound table
+	raiden.InvalidateRpcCacheByModel(Submission{})
+	_, err = raiden.ExecuteRpc(mockCtx, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, httpmock.GetTotalCallCount())
+
+	// invalidate by rpc
+	raiden.InvalidateRpcCache(newCachedSubmissions())
+	_, err = raiden.ExecuteRpc(mockCtx, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, httpmock.GetTotalCallCount())
+}
+
+func TestExecuteRpc_CacheSkipVolatile(t *testing.T) {
+	raiden.SetRpcCacheStore(raiden.NewRpcMemoryCacheStore(10))
+	defer raiden.SetRpcCacheStore(nil)
+
+	mockCtx := newRpcCacheMockContext(signRpcCacheToken(t, rpcCacheJwtSecret, jwt.MapClaims{"sub": "user-a"}))
+	mockSupabase := mock.MockSupabase{Cfg: mockCtx.Config()}
+	mockSupabase.Activate()
+	defer mockSupabase.Deactivate()
+
+	err := mockSupabase.MockExecuteRpcWithExpectedResponse(200, "get_submissions", GetSubmissionsResult{})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		rpc := &GetVolatileCachedSubmissions{GetCachedSubmissions: *newCachedSubmissions()}
+		_, err = raiden.ExecuteRpc(mockCtx, rpc)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestExecuteRpc_CacheSkipUnverifiedToken(t *testing.T) {
+	raiden.SetRpcCacheStore(raiden.NewRpcMemoryCacheStore(10))
+	defer raiden.SetRpcCacheStore(nil)
+
+	claims := jwt.MapClaims{"sub": "user-a", "role": "authenticated"}
+	victim := newRpcCacheMockContext(signRpcCacheToken(t, rpcCacheJwtSecret, claims))
+	mockSupabase := mock.MockSupabase{Cfg: victim.Config()}
+	mockSupabase.Activate()
+	defer mockSupabase.Deactivate()
+
+	err := mockSupabase.MockExecuteRpcWithExpectedResponse(200, "get_submissions", GetSubmissionsResult{})
+	assert.NoError(t, err)
+
+	_, err = raiden.ExecuteRpc(victim, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	// forged token with the same claims never hit cached result
+	forged := newRpcCacheMockContext(signRpcCacheToken(t, "other-secret", claims))
+	_, err = raiden.ExecuteRpc(forged, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	// expired token is rejected before cache lookup
+	expired := newRpcCacheMockContext(signRpcCacheToken(t, rpcCacheJwtSecret, jwt.MapClaims{
+		"sub": "user-a", "role": "authenticated", "exp": time.Now().Add(-time.Hour).Unix(),
+	}))
+	_, err = raiden.ExecuteRpc(expired, newCachedSubmissions())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+
+	// cache is skipped when jwt secret is not configured
+	noSecret := newRpcCacheMockContextWithSecret(signRpcCacheToken(t, rpcCacheJwtSecret, claims), "")
+	for i := 0; i < 2; i++ {
+		_, err = raiden.ExecuteRpc(noSecret, newCachedSubmissions())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 5, httpmock.GetTotalCallCount())
+}
+
+func TestRpcMemoryCacheStore(t *testing.T) {
+	store := raiden.NewRpcMemoryCacheStore(2)
+
+	store.Set("a", []byte("1"), time.Minute, "rpc:public.a", "table:x")
+	store.Set("b", []byte("2"), time.Minute, "table:x")
+
+	value, found := store.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	// "b" is least recently used and evicted
+	store.Set("c", []byte("3"), time.Minute)
+	_, found = store.Get("b")
+	assert.False(t, found)
+	assert.Equal(t, 2, store.Len())
+
+	store.DeleteByTag("table:x")
+	_, found = store.Get("a")
+	assert.False(t, found)
+
+	store.Delete("c")
+	assert.Equal(t, 0, store.Len())
+
+	store.Set("d", []byte("4"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, found = store.Get("d")
+	assert.False(t, found)
+}
@@ -0,0 +1,207 @@
+package raiden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/sev-2/raiden/pkg/client/net"
+	"github.com/valyala/fasthttp"
+)
+
+// RpcRowIterator decode response of SETOF or TABLE rpc row by row,
+// so the full result set never loaded into memory at once.
+//
+// example :
+//
+//	it, err := raiden.ExecuteRpcIterator(ctx, &rpc.GetOrders{Params: params})
+//	if err != nil {
+//		return err
+//	}
+//	defer it.Close()
+//
+//	for it.Next() {
+//		var item rpc.GetOrdersItem
+//		if err := it.Scan(&item); err != nil {
+//			return err
+//		}
+//	}
+//	return it.Err()
+type RpcRowIterator struct {
+	body    io.ReadCloser
+	decoder *json.Decoder
+	rowType reflect.Type
+	current json.RawMessage
+	err     error
+	done    bool
+}
+
+// ExecuteRpcIterator execute rpc and return iterator for reading the result,
+// only rpc with SETOF or TABLE return type is supported
+func ExecuteRpcIterator(ctx Context, rpc Rpc) (*RpcRowIterator, error) {
+	rowType, err := getRpcRowType(rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := NewRpcRequest(ctx, rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := rpcStreamRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &RpcRowIterator{
+		body:    body,
+		decoder: json.NewDecoder(body),
+		rowType: rowType,
+	}
+
+	// response must be json array, read the opening delimiter first
+	token, err := it.decoder.Token()
+	if err != nil {
+		it.Close()
+		return nil, rpcInvalidResponseError(err)
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		it.Close()
+		return nil, rpcInvalidResponseError(fmt.Errorf("expected json array, got %v", token))
+	}
+
+	return it, nil
+}
+
+// Next read next row, return false when all row is read or error happened
+func (it *RpcRowIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if !it.decoder.More() {
+		it.done = true
+		if _, err := it.decoder.Token(); err != nil {
+			it.err = rpcInvalidResponseError(err)
+		}
+		return false
+	}
+
+	it.current = nil
+	if err := it.decoder.Decode(&it.current); err != nil {
+		it.err = rpcInvalidResponseError(err)
+		return false
+	}
+	return true
+}
+
+// Scan decode current row into dest
+func (it *RpcRowIterator) Scan(dest any) error {
+	if it.current == nil {
+		return fmt.Errorf("rpc iterator : no row to scan, call Next first")
+	}
+	return json.Unmarshal(it.current, dest)
+}
+
+// Row decode current row into new value of Return element type
+func (it *RpcRowIterator) Row() (any, error) {
+	row := reflect.New(it.rowType)
+	if err := it.Scan(row.Interface()); err != nil {
+		return nil, err
+	}
+	return row.Elem().Interface(), nil
+}
+
+func (it *RpcRowIterator) Err() error {
+	return it.err
+}
+
+func (it *RpcRowIterator) Close() error {
+	it.done = true
+	return it.body.Close()
+}
+
+func getRpcRowType(rpc Rpc) (reflect.Type, error) {
+	if rpc.GetReturnType() != RpcReturnDataTypeSetOf && rpc.GetReturnType() != RpcReturnDataTypeTable {
+		return nil, &ErrorResponse{
+			StatusCode: fasthttp.StatusInternalServerError,
+			Details:    fmt.Sprintf("rpc %s return %s, iteration only available for SETOF or TABLE return type", rpc.GetName(), rpc.GetReturnType()),
+			Message:    "Unsupported rpc return type",
+			Hint:       "Invalid Rpc",
+			Code:       fasthttp.StatusMessage(fasthttp.StatusInternalServerError),
+		}
+	}
+
+	rpcType := reflect.TypeOf(rpc).Elem()
+	if rpcType.Kind() == reflect.Pointer {
+		rpcType = rpcType.Elem()
+	}
+
+	returnField, found := rpcType.FieldByName("Return")
+	if !found || returnField.Type.Kind() != reflect.Slice {
+		return nil, &ErrorResponse{
+			StatusCode: fasthttp.StatusInternalServerError,
+			Details:    fmt.Sprintf("Struct %s must have Return field with slice type for SETOF or TABLE return type", rpcType.Name()),
+			Message:    fmt.Sprintf("Invalid field Return in struct %s", rpcType.Name()),
+			Hint:       "Invalid Rpc",
+			Code:       fasthttp.StatusMessage(fasthttp.StatusInternalServerError),
+		}
+	}
+
+	return returnField.Type.Elem(), nil
+}
+
+// rpcStreamRequest send rpc request and return unread response body,
+// caller is responsible to close the body
+func rpcStreamRequest(req *RpcRequest) (io.ReadCloser, error) {
+	httpReq, err := http.NewRequest(fasthttp.MethodPost, req.Url(), bytes.NewBuffer(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if err := rpcAttachAuthHeader(req.Header)(httpReq); err != nil {
+		return nil, err
+	}
+
+	RpcLogger.Trace("stream rpc request", "url", httpReq.URL.String())
+	resp, err := net.GetClient().Do(httpReq)
+	if err != nil {
+		return nil, &ErrorResponse{
+			StatusCode: fasthttp.StatusInternalServerError,
+			Details:    err.Error(),
+			Message:    fmt.Sprintf("fail request to upstream. Reason: %v", err),
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		var errResponse ErrorResponse
+		if err := json.Unmarshal(body, &errResponse); err == nil && errResponse.Message != "" {
+			errResponse.StatusCode = resp.StatusCode
+			return nil, &errResponse
+		}
+
+		return nil, &ErrorResponse{
+			StatusCode: resp.StatusCode,
+			Details:    string(body),
+			Message:    fmt.Sprintf("fail request to upstream. Reason: invalid HTTP response code: %d", resp.StatusCode),
+		}
+	}
+
+	return resp.Body, nil
+}
+
+func rpcInvalidResponseError(err error) error {
+	return &ErrorResponse{
+		StatusCode: fasthttp.StatusInternalServerError,
+		Details:    err.Error(),
+		Message:    "invalid marshall response data",
+	}
+}
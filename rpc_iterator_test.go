@@ -0,0 +1,85 @@
+package raiden_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+type GetSubmissionsCount struct {
+	GetSubmissions
+}
+
+func (r *GetSubmissionsCount) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeInteger
+}
+
+func TestExecuteRpcIterator(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "http://supabase.cloud.com/rest/v1/rpc/get_submissions",
+		httpmock.NewStringResponder(200, `[{"id":1,"sc_name":"a","c_name":"b"},{"id":2,"sc_name":"c","c_name":"d"}]`))
+
+	it, err := raiden.ExecuteRpcIterator(newRpcCacheMockContext("user-a"), newCachedSubmissions())
+	assert.NoError(t, err)
+	defer it.Close()
+
+	assert.True(t, it.Next())
+	var first GetSubmissionsItem
+	assert.NoError(t, it.Scan(&first))
+	assert.Equal(t, int64(1), first.Id)
+	assert.Equal(t, "a", first.ScName)
+
+	assert.True(t, it.Next())
+	row, err := it.Row()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), row.(GetSubmissionsItem).Id)
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestExecuteRpcIterator_InvalidResponse(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "http://supabase.cloud.com/rest/v1/rpc/get_submissions",
+		httpmock.NewStringResponder(200, `[{"id":1},{"id":`))
+
+	it, err := raiden.ExecuteRpcIterator(newRpcCacheMockContext("user-a"), newCachedSubmissions())
+	assert.NoError(t, err)
+	defer it.Close()
+
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+
+	// not json array
+	httpmock.RegisterResponder(http.MethodPost, "http://supabase.cloud.com/rest/v1/rpc/get_submissions",
+		httpmock.NewStringResponder(200, `{"id":1}`))
+	_, err = raiden.ExecuteRpcIterator(newRpcCacheMockContext("user-a"), newCachedSubmissions())
+	assert.Error(t, err)
+}
+
+func TestExecuteRpcIterator_UpstreamError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "http://supabase.cloud.com/rest/v1/rpc/get_submissions",
+		httpmock.NewStringResponder(400, `{"code":"42883","message":"function does not exist"}`))
+
+	_, err := raiden.ExecuteRpcIterator(newRpcCacheMockContext("user-a"), newCachedSubmissions())
+	assert.Error(t, err)
+	errResponse := err.(*raiden.ErrorResponse)
+	assert.Equal(t, 400, errResponse.StatusCode)
+	assert.Equal(t, "function does not exist", errResponse.Message)
+}
+
+func TestExecuteRpcIterator_UnsupportedReturnType(t *testing.T) {
+	_, err := raiden.ExecuteRpcIterator(newRpcCacheMockContext("user-a"), &GetSubmissionsCount{})
+	assert.Error(t, err)
+}
@@ -67,6 +67,56 @@ func (r *GetSubmissions) GetRawDefinition() string {
 	return `BEGIN RETURN QUERY SELECT s.id, s.created_at, sc.name as sc_name, c.name as c_name FROM :s s INNER JOIN :sc sc ON s.scouter_id = sc.scouter_id INNER JOIN :c c ON s.candidate_id = c.candidate_id WHERE sc.name = :scouter_name AND c.name = :candidate_name; END;`
 }
 
+type RpcOrderStatus struct {
+	raiden.TypeBase
+}
+
+func (*RpcOrderStatus) Name() string {
+	return "order_status"
+}
+
+func (*RpcOrderStatus) Enums() []string {
+	return []string{"pending", "paid"}
+}
+
+type RpcBillingAddress struct {
+	raiden.TypeBase
+}
+
+func (*RpcBillingAddress) Name() string {
+	return "billing_address"
+}
+
+func (*RpcBillingAddress) Schema() string {
+	return "billing"
+}
+
+type GetOrdersByStatusParams struct {
+	Status   RpcOrderStatus   `json:"status" column:"name:status;type:order_status"`
+	Statuses []RpcOrderStatus `json:"statuses" column:"name:statuses;type:order_status[]"`
+	MinTotal float64          `json:"min_total" column:"name:min_total;type:numeric(10,2)"`
+	Flags    []bool           `json:"flags" column:"name:flags;type:boolean[]"`
+	Since    []string         `json:"since" column:"name:since;type:timestamptz[]"`
+}
+
+type GetOrdersByStatus struct {
+	raiden.RpcBase
+	Params *GetOrdersByStatusParams `json:"-"`
+	Return []RpcBillingAddress      `json:"-"`
+}
+
+func (r *GetOrdersByStatus) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeSetOf
+}
+
+func (r *GetOrdersByStatus) UseParamPrefix() bool {
+	return false
+}
+
+func (r *GetOrdersByStatus) GetRawDefinition() string {
+	return `BEGIN RETURN QUERY SELECT * FROM billing.addresses WHERE status = :status; END;`
+}
+
 type RpcWithMissingReturn struct {
 	raiden.RpcBase
 	Params *GetSubmissionsParams `json:"-"`
@@ -84,6 +134,29 @@ func TestCreateQuery(t *testing.T) {
 	assert.Equal(t, expectedCompleteQuery, rpc.GetCompleteStmt())
 }
 
+func TestCreateQueryWithUserDefinedType(t *testing.T) {
+	rpc := &GetOrdersByStatus{}
+	e := raiden.BuildRpc(rpc)
+	assert.NoError(t, e)
+
+	expectedCompleteQuery := "create or replace function public.get_orders_by_status(status order_status, statuses order_status[], min_total numeric(10,2), flags boolean[], since timestamp with time zone[]) returns setof billing.billing_address language plpgsql set search_path = 'public' as $function$ begin return query select * from billing.addresses where status = status ; end; $function$"
+	assert.Equal(t, expectedCompleteQuery, rpc.GetCompleteStmt())
+
+	pType, err := raiden.GetValidRpcParamType("public.order_status", true)
+	assert.NoError(t, err)
+	assert.Equal(t, raiden.RpcParamDataType("order_status"), pType)
+	assert.Equal(t, "types.OrderStatus", raiden.RpcParamToGoType("order_status"))
+	assert.Equal(t, "[]types.OrderStatus", raiden.RpcParamToGoType("order_status[]"))
+
+	rType, err := raiden.GetValidRpcReturnType("billing.billing_address", true)
+	assert.NoError(t, err)
+	assert.Equal(t, raiden.RpcReturnDataType("billing.billing_address"), rType)
+
+	decl, err := raiden.GetValidRpcReturnNameDecl(rType, true)
+	assert.NoError(t, err)
+	assert.Equal(t, `RpcReturnDataType("billing.billing_address")`, decl)
+}
+
 func TestExecuteRpc(t *testing.T) {
 	mockCtx := &mock.MockContext{
 		ConfigFn: func() *raiden.Config {
@@ -288,6 +361,16 @@ func TestRpcParamToGoType(t *testing.T) {
 		{raiden.RpcParamDataTypeArrayOfText, "[]string"},
 		{raiden.RpcParamDataTypeArrayOfVarchar, "[]string"},
 		{raiden.RpcParamDataTypeArrayOfVarcharAlias, "[]string"},
+		{raiden.RpcParamDataTypeInterval, "string"},
+		{raiden.RpcParamDataTypeInet, "string"},
+		{raiden.RpcParamDataTypeArrayOfBoolean, "[]bool"},
+		{raiden.RpcParamDataTypeArrayOfDate, "[]postgres.Date"},
+		{raiden.RpcParamDataTypeArrayOfTimestampTZ, "[]postgres.DateTime"},
+		{raiden.RpcParamDataTypeArrayOfTimestampTZAlias, "[]postgres.DateTime"},
+		{raiden.RpcParamDataTypeArrayOfJSONB, "[]map[string]interface{}"},
+		{raiden.RpcParamDataType("NUMERIC(10,2)"), "float64"},
+		{raiden.RpcParamDataType("VARCHAR(255)[]"), "[]string"},
+		{raiden.RpcParamDataType("unknown_type"), "interface{}"},
 	}
 
 	for _, tt := range tests {
@@ -322,6 +405,21 @@ func TestGetValidRpcParamType(t *testing.T) {
 		{"varchar[]", false, raiden.RpcParamDataTypeArrayOfVarchar, false},
 		{"varchar[]", true, raiden.RpcParamDataTypeArrayOfVarcharAlias, false},
 		{"uuid[]", false, raiden.RpcParamDataTypeArrayOfUuid, false},
+		{"boolean[]", false, raiden.RpcParamDataTypeArrayOfBoolean, false},
+		{"date[]", false, raiden.RpcParamDataTypeArrayOfDate, false},
+		{"timestamptz", false, raiden.RpcParamDataTypeTimestampTZ, false},
+		{"timestamptz[]", false, raiden.RpcParamDataTypeArrayOfTimestampTZ, false},
+		{"timestamp with time zone[]", true, raiden.RpcParamDataTypeArrayOfTimestampTZAlias, false},
+		{"timestamp[]", false, raiden.RpcParamDataTypeArrayOfTimestamp, false},
+		{"json[]", false, raiden.RpcParamDataTypeArrayOfJSON, false},
+		{"jsonb[]", false, raiden.RpcParamDataTypeArrayOfJSONB, false},
+		{"interval", false, raiden.RpcParamDataTypeInterval, false},
+		{"inet", false, raiden.RpcParamDataTypeInet, false},
+		{"decimal", false, raiden.RpcParamDataTypeNumeric, false},
+		{"numeric(10,2)", false, raiden.RpcParamDataType("NUMERIC(10,2)"), false},
+		{"numeric( 10 , 2 )[]", false, raiden.RpcParamDataType("NUMERIC(10,2)[]"), false},
+		{"varchar(255)", true, raiden.RpcParamDataType("VARCHAR(255)"), false},
+		{"integer(10)", false, "", true},
 	}
 
 	for _, tt := range tests {
@@ -362,6 +460,15 @@ func TestRpcReturnToGoType(t *testing.T) {
 		{raiden.RpcReturnDataTypeArrayOfVarchar, "[]string"},
 		{raiden.RpcReturnDataTypeArrayOfVarcharAlias, "[]string"},
 		{raiden.RpcReturnDataTypeUUID, "uuid.UUID"},
+		{raiden.RpcReturnDataTypeNumeric, "float64"},
+		{raiden.RpcReturnDataTypeInterval, "string"},
+		{raiden.RpcReturnDataTypeInet, "string"},
+		{raiden.RpcReturnDataTypeArrayOfUUID, "[]uuid.UUID"},
+		{raiden.RpcReturnDataTypeArrayOfBoolean, "[]bool"},
+		{raiden.RpcReturnDataTypeArrayOfDate, "[]postgres.Date"},
+		{raiden.RpcReturnDataTypeArrayOfTimestampTZ, "[]postgres.DateTime"},
+		{raiden.RpcReturnDataTypeArrayOfJSONB, "[]map[string]interface{}"},
+		{raiden.RpcReturnDataType("NUMERIC(12,4)"), "float64"},
 	}
 
 	for _, tt := range tests {
@@ -397,6 +504,15 @@ func TestGetValidRpcReturnType(t *testing.T) {
 		{"varchar[]", false, raiden.RpcReturnDataTypeArrayOfVarchar, false},
 		{"varchar[]", true, raiden.RpcReturnDataTypeArrayOfVarcharAlias, false},
 		{"uuid", false, raiden.RpcReturnDataTypeUUID, false},
+		{"numeric", false, raiden.RpcReturnDataTypeNumeric, false},
+		{"numeric(12,4)", false, raiden.RpcReturnDataType("NUMERIC(12,4)"), false},
+		{"interval", false, raiden.RpcReturnDataTypeInterval, false},
+		{"inet", false, raiden.RpcReturnDataTypeInet, false},
+		{"uuid[]", false, raiden.RpcReturnDataTypeArrayOfUUID, false},
+		{"boolean[]", false, raiden.RpcReturnDataTypeArrayOfBoolean, false},
+		{"date[]", false, raiden.RpcReturnDataTypeArrayOfDate, false},
+		{"timestamptz[]", true, raiden.RpcReturnDataTypeArrayOfTimestampTZAlias, false},
+		{"jsonb[]", false, raiden.RpcReturnDataTypeArrayOfJSONB, false},
 	}
 
 	for _, tt := range tests {
@@ -434,6 +550,11 @@ func TestGetValidRpcReturnNameDecl(t *testing.T) {
 		{raiden.RpcReturnDataTypeArrayOfVarchar, false, "RpcReturnDataTypeArrayOfVarchar", false},
 		{raiden.RpcReturnDataTypeArrayOfVarcharAlias, true, "RpcReturnDataTypeArrayOfVarcharAlias", false},
 		{raiden.RpcReturnDataTypeUUID, false, "RpcReturnDataTypeUUID", false},
+		{raiden.RpcReturnDataTypeNumeric, false, "RpcReturnDataTypeNumeric", false},
+		{raiden.RpcReturnDataTypeArrayOfBoolean, false, "RpcReturnDataTypeArrayOfBoolean", false},
+		{raiden.RpcReturnDataTypeArrayOfTimestampTZ, true, "RpcReturnDataTypeArrayOfTimestampTZAlias", false},
+		{raiden.RpcReturnDataTypeInterval, false, "RpcReturnDataTypeInterval", false},
+		{raiden.RpcReturnDataType("NUMERIC(12,4)"), false, `RpcReturnDataType("numeric(12,4)")`, false},
 		{raiden.RpcReturnDataType("unsupported"), false, "", true},
 	}
 
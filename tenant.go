@@ -0,0 +1,373 @@
+package raiden
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sev-2/raiden/pkg/jwt"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// TenantOption mark column as tenant key of tenant scoped table,
+	// for example `column:"name:org_id;type:uuid;nullable:false;tenant"`
+	TenantOption = "tenant"
+
+	// DefaultTenantClaim is jwt claim used by default tenant resolver when TENANT_CLAIM is not set
+	DefaultTenantClaim = "org_id"
+)
+
+// TenantScoped is implemented by model that can't use TenantOption in column tag,
+// the returned value is name of tenant column
+type TenantScoped interface {
+	TenantColumn() string
+}
+
+// TenantResolver return tenant of current request, empty tenant mean tenant is not found
+type TenantResolver func(ctx Context) (string, error)
+
+// ErrTenantNotResolved is returned when tenant scoped table is accessed without tenant
+var ErrTenantNotResolved = &ErrorResponse{
+	StatusCode: fasthttp.StatusForbidden,
+	Code:       fasthttp.StatusMessage(fasthttp.StatusForbidden),
+	Message:    "tenant is not resolved from request",
+	Hint:       "Send token that contain tenant claim or set tenant resolver",
+}
+
+// ErrTenantConflictTarget is returned when merge upsert of tenant scoped table
+// can overwrite row of other tenant on conflict
+var ErrTenantConflictTarget = &ErrorResponse{
+	StatusCode: fasthttp.StatusBadRequest,
+	Code:       fasthttp.StatusMessage(fasthttp.StatusBadRequest),
+	Message:    "upsert of tenant scoped table require conflict target that contain tenant column",
+	Hint:       "Set on_conflict to unique columns that contain tenant column, ex : org_id,slug",
+}
+
+// ErrTenantClaimUnverified is returned when tenant claim is read from token without JWT_SECRET
+var ErrTenantClaimUnverified = &ErrorResponse{
+	StatusCode: fasthttp.StatusInternalServerError,
+	Code:       fasthttp.StatusMessage(fasthttp.StatusInternalServerError),
+	Message:    "JWT_SECRET is required to resolve tenant from token claim",
+	Hint:       "Set JWT_SECRET or use TenantFromUnverifiedClaim behind gateway that verify the token",
+}
+
+var (
+	tenantResolver   TenantResolver
+	tenantResolverMu sync.RWMutex
+	tenantTables     sync.Map
+)
+
+// SetTenantResolver override default tenant resolver; returns a restore function.
+//
+// example :
+//
+//	raiden.SetTenantResolver(raiden.TenantFromFirst(
+//		raiden.TenantFromClaim("app_metadata.org_id"),
+//		raiden.TenantFromHeader("X-Org-Id"),
+//	))
+func SetTenantResolver(resolver TenantResolver) func() {
+	tenantResolverMu.Lock()
+	defer tenantResolverMu.Unlock()
+
+	prev := tenantResolver
+	tenantResolver = resolver
+	return func() {
+		tenantResolverMu.Lock()
+		defer tenantResolverMu.Unlock()
+		tenantResolver = prev
+	}
+}
+
+func getTenantResolver() TenantResolver {
+	tenantResolverMu.RLock()
+	defer tenantResolverMu.RUnlock()
+	return tenantResolver
+}
+
+// ResolveTenant return tenant of request with resolver set by SetTenantResolver,
+// default resolver read TENANT_CLAIM (default is org_id) from bearer token
+// and fallback to TENANT_HEADER when it is configured
+func ResolveTenant(ctx Context) (string, error) {
+	if ctx == nil {
+		return "", ErrTenantNotResolved
+	}
+
+	resolver := getTenantResolver()
+	if resolver == nil {
+		resolver = defaultTenantResolver(ctx.Config())
+	}
+
+	tenant, err := resolver(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if tenant == "" {
+		return "", ErrTenantNotResolved
+	}
+	return tenant, nil
+}
+
+func defaultTenantResolver(config *Config) TenantResolver {
+	claim, header := DefaultTenantClaim, ""
+	if config != nil {
+		if config.TenantClaim != "" {
+			claim = config.TenantClaim
+		}
+		header = config.TenantHeader
+	}
+
+	if header == "" {
+		return TenantFromClaim(claim)
+	}
+	return TenantFromFirst(TenantFromClaim(claim), TenantFromHeader(header))
+}
+
+// TenantFromClaim resolve tenant from claim of bearer token, nested claim is separated by dot
+// like `app_metadata.org_id`. Token signature is verified with JWT_SECRET.
+func TenantFromClaim(claim string) TenantResolver {
+	return func(ctx Context) (string, error) {
+		token := getTenantToken(ctx)
+		if token == "" {
+			return "", nil
+		}
+
+		config := ctx.Config()
+		if config == nil || config.JwtSecret == "" {
+			return "", ErrTenantClaimUnverified
+		}
+
+		validated, err := jwt.Validate[map[string]any](token, config.JwtSecret)
+		if err != nil {
+			return "", &ErrorResponse{
+				StatusCode: fasthttp.StatusUnauthorized,
+				Code:       fasthttp.StatusMessage(fasthttp.StatusUnauthorized),
+				Message:    err.Error(),
+			}
+		}
+		return getTenantClaim(*validated, claim), nil
+	}
+}
+
+// TenantFromUnverifiedClaim resolve tenant from claim of bearer token without verifying the signature,
+// client can choose any tenant with forged token so only use it behind gateway that verify the token
+func TenantFromUnverifiedClaim(claim string) TenantResolver {
+	return func(ctx Context) (string, error) {
+		token := getTenantToken(ctx)
+		if token == "" {
+			return "", nil
+		}
+
+		claims, err := jwt.Decode(token)
+		if err != nil {
+			return "", nil
+		}
+		return getTenantClaim(claims, claim), nil
+	}
+}
+
+func getTenantToken(ctx Context) string {
+	token := strings.TrimSpace(string(ctx.RequestContext().Request.Header.Peek(fasthttp.HeaderAuthorization)))
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	return token
+}
+
+func getTenantClaim(claims map[string]any, claim string) string {
+	var value any = claims
+	for _, key := range strings.Split(claim, ".") {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// TenantFromHeader resolve tenant from request header, header is sent by client
+// so only use it behind gateway that set the header
+func TenantFromHeader(header string) TenantResolver {
+	return func(ctx Context) (string, error) {
+		return strings.TrimSpace(string(ctx.RequestContext().Request.Header.Peek(header))), nil
+	}
+}
+
+// TenantFromFirst return tenant of the first resolver that found tenant
+func TenantFromFirst(resolvers ...TenantResolver) TenantResolver {
+	return func(ctx Context) (string, error) {
+		for _, resolver := range resolvers {
+			tenant, err := resolver(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			if tenant != "" {
+				return tenant, nil
+			}
+		}
+		return "", nil
+	}
+}
+
+// GetTenantColumn return tenant column of model from TenantScoped or TenantOption in column tag
+func GetTenantColumn(model any) (string, bool) {
+	if model == nil {
+		return "", false
+	}
+
+	if scoped, ok := model.(TenantScoped); ok && scoped.TenantColumn() != "" {
+		return scoped.TenantColumn(), true
+	}
+
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	if scoped, ok := reflect.New(t).Interface().(TenantScoped); ok && scoped.TenantColumn() != "" {
+		return scoped.TenantColumn(), true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		columnTag := field.Tag.Get("column")
+		for _, item := range strings.Split(columnTag, ";") {
+			if item != TenantOption {
+				continue
+			}
+
+			if name := UnmarshalColumnTag(columnTag).Name; name != "" {
+				return name, true
+			}
+			return strings.Split(field.Tag.Get("json"), ",")[0], true
+		}
+	}
+	return "", false
+}
+
+// RegisterTenantModel register tenant scoped model, so RestProxy scope request of the table.
+// Model of rest route is registered automatically.
+func RegisterTenantModel(models ...any) {
+	for _, m := range models {
+		if column, ok := GetTenantColumn(m); ok {
+			tenantTables.Store(GetTableName(m), column)
+		}
+	}
+}
+
+func getTenantTableColumn(table string) (string, bool) {
+	column, ok := tenantTables.Load(table)
+	if !ok {
+		return "", false
+	}
+	return column.(string), true
+}
+
+// ValidateTenantUpsert check merge upsert of tenant scoped table only update row of the same tenant,
+// conflict target must contain tenant column because update on conflict doesn't apply filter
+func ValidateTenantUpsert(column string, onConflict string, resolution string) error {
+	if column == "" || resolution == "ignore-duplicates" {
+		return nil
+	}
+
+	for _, c := range strings.Split(onConflict, ",") {
+		if strings.TrimSpace(c) == column {
+			return nil
+		}
+	}
+	return ErrTenantConflictTarget
+}
+
+// applyRestProxyTenant scope proxied request of tenant scoped table,
+// tenant filter is added to request that read or change row
+// and tenant column is stamped to payload of insert and update
+func applyRestProxyTenant(appCtx Context, req *fasthttp.Request, table string, proxyUrl string) (string, error) {
+	column, ok := getTenantTableColumn(table)
+	if !ok {
+		return proxyUrl, nil
+	}
+
+	tenant, err := ResolveTenant(appCtx)
+	if err != nil {
+		return proxyUrl, err
+	}
+
+	method := string(req.Header.Method())
+	if method == fasthttp.MethodPost || method == fasthttp.MethodPut || method == fasthttp.MethodPatch {
+		body, err := stampTenant(req.Body(), column, tenant)
+		if err != nil {
+			return proxyUrl, err
+		}
+		req.SetBody(body)
+	}
+
+	if method == fasthttp.MethodPost {
+		prefer := string(req.Header.Peek("Prefer"))
+		if strings.Contains(prefer, "resolution=") {
+			resolution := "merge-duplicates"
+			if strings.Contains(prefer, "resolution=ignore-duplicates") {
+				resolution = "ignore-duplicates"
+			}
+
+			onConflict := string(req.URI().QueryArgs().Peek("on_conflict"))
+			if err := ValidateTenantUpsert(column, onConflict, resolution); err != nil {
+				return proxyUrl, err
+			}
+		}
+		return proxyUrl, nil
+	}
+
+	separator := "?"
+	if strings.Contains(proxyUrl, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%s%s=eq.%s", proxyUrl, separator, column, url.QueryEscape(tenant)), nil
+}
+
+// stampTenant set tenant column of json object or every object in json array
+func stampTenant(body []byte, column string, tenant string) ([]byte, error) {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return body, nil
+	}
+
+	var payload any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body, nil
+	}
+
+	switch p := payload.(type) {
+	case map[string]any:
+		p[column] = tenant
+	case []any:
+		for _, item := range p {
+			if m, ok := item.(map[string]any); ok {
+				m[column] = tenant
+			}
+		}
+	default:
+		return body, nil
+	}
+
+	return json.Marshal(payload)
+}
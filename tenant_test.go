@@ -0,0 +1,194 @@
+package raiden_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+type tenantTaggedModel struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement"`
+	OrgId string `json:"org_id,omitempty" column:"name:org_id;type:text;tenant"`
+	Name  string `json:"name,omitempty" column:"name:name;type:text"`
+
+	Metadata string `json:"-" schema:"public" tableName:"tenant_projects"`
+}
+
+type tenantScopedModel struct {
+	Id          int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	WorkspaceId string `json:"workspace_id,omitempty" column:"name:workspace_id;type:text"`
+
+	Metadata string `json:"-" schema:"public" tableName:"tenant_tasks"`
+}
+
+func (tenantScopedModel) TenantColumn() string {
+	return "workspace_id"
+}
+
+const tenantJwtSecret = "tenant-secret"
+
+// newTenantCtx create context with token signed by JWT_SECRET of config
+func newTenantCtx(cfg *raiden.Config, claims map[string]any) raiden.Ctx {
+	ctx := raiden.NewCtx(cfg, nil, nil)
+	ctx.RequestCtx = &fasthttp.RequestCtx{}
+	if claims != nil {
+		secret := tenantJwtSecret
+		if cfg != nil && cfg.JwtSecret != "" {
+			secret = cfg.JwtSecret
+		}
+		signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims(claims)).SignedString([]byte(secret))
+		ctx.Request.Header.Set("Authorization", "Bearer "+signed)
+	}
+	return ctx
+}
+
+func newUnsignedTenantToken(claims map[string]any) string {
+	payload, _ := json.Marshal(claims)
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(payload) + "."
+}
+
+func TestGetTenantColumn(t *testing.T) {
+	column, ok := raiden.GetTenantColumn(tenantTaggedModel{})
+	assert.True(t, ok)
+	assert.Equal(t, "org_id", column)
+
+	column, ok = raiden.GetTenantColumn(&tenantScopedModel{})
+	assert.True(t, ok)
+	assert.Equal(t, "workspace_id", column)
+
+	_, ok = raiden.GetTenantColumn(Payload{})
+	assert.False(t, ok)
+}
+
+func TestResolveTenant(t *testing.T) {
+	cfg := &raiden.Config{JwtSecret: tenantJwtSecret}
+
+	ctx := newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	tenant, err := raiden.ResolveTenant(&ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+
+	ctx = newTenantCtx(&raiden.Config{JwtSecret: tenantJwtSecret, TenantClaim: "app_metadata.org_id"}, map[string]any{"app_metadata": map[string]any{"org_id": 7}})
+	tenant, err = raiden.ResolveTenant(&ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "7", tenant)
+
+	ctx = newTenantCtx(&raiden.Config{TenantHeader: "X-Org-Id"}, nil)
+	ctx.Request.Header.Set("X-Org-Id", "globex")
+	tenant, err = raiden.ResolveTenant(&ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", tenant)
+
+	ctx = newTenantCtx(cfg, nil)
+	_, err = raiden.ResolveTenant(&ctx)
+	assert.Equal(t, raiden.ErrTenantNotResolved, err)
+
+	// forged token is rejected
+	ctx = newTenantCtx(cfg, nil)
+	ctx.Request.Header.Set("Authorization", "Bearer "+newUnsignedTenantToken(map[string]any{"org_id": "globex"}))
+	_, err = raiden.ResolveTenant(&ctx)
+	assert.Error(t, err)
+
+	// claim is never trusted without secret
+	ctx = newTenantCtx(&raiden.Config{}, nil)
+	ctx.Request.Header.Set("Authorization", "Bearer "+newUnsignedTenantToken(map[string]any{"org_id": "globex"}))
+	_, err = raiden.ResolveTenant(&ctx)
+	assert.Equal(t, raiden.ErrTenantClaimUnverified, err)
+
+	// unverified claim is explicit opt-in
+	tenant, err = raiden.TenantFromUnverifiedClaim("org_id")(&ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "globex", tenant)
+
+	restore := raiden.SetTenantResolver(func(ctx raiden.Context) (string, error) { return "custom", nil })
+	defer restore()
+
+	tenant, err = raiden.ResolveTenant(&ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", tenant)
+}
+
+func TestRestProxy_TenantScope(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed([]tenantTaggedModel{
+		{OrgId: "acme", Name: "rocket"},
+		{OrgId: "globex", Name: "dome"},
+	}))
+
+	restore := raiden.SetRestProxyDoTimeout(pg.DoTimeout)
+	defer restore()
+
+	raiden.RegisterTenantModel(tenantTaggedModel{})
+	cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", JwtSecret: tenantJwtSecret}
+
+	ctx := newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects?select=name")
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	assert.NoError(t, raiden.RestProxy(&ctx, "tenant_projects"))
+	assert.JSONEq(t, `[{"name":"rocket"}]`, string(ctx.Response.Body()))
+
+	ctx = newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects")
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.Header.Set("Prefer", "return=representation")
+	ctx.Request.SetBodyString(`[{"name":"anvil"},{"name":"spring","org_id":"globex"}]`)
+	assert.NoError(t, raiden.RestProxy(&ctx, "tenant_projects"))
+	assert.JSONEq(t, `[{"id":3,"name":"anvil","org_id":"acme"},{"id":4,"name":"spring","org_id":"acme"}]`, string(ctx.Response.Body()))
+
+	ctx = newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects")
+	ctx.Request.Header.SetMethod(fasthttp.MethodDelete)
+	assert.NoError(t, raiden.RestProxy(&ctx, "tenant_projects"))
+	assert.Len(t, pg.Rows("tenant_projects"), 1)
+
+	ctx = newTenantCtx(cfg, nil)
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects")
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	assert.Equal(t, raiden.ErrTenantNotResolved, raiden.RestProxy(&ctx, "tenant_projects"))
+}
+
+func TestRestProxy_TenantMutation(t *testing.T) {
+	pg := mock.NewMockPostgrest()
+	assert.NoError(t, pg.Seed([]tenantTaggedModel{
+		{OrgId: "acme", Name: "rocket"},
+		{OrgId: "globex", Name: "dome"},
+	}))
+
+	restore := raiden.SetRestProxyDoTimeout(pg.DoTimeout)
+	defer restore()
+
+	raiden.RegisterTenantModel(tenantTaggedModel{})
+	cfg := &raiden.Config{SupabasePublicUrl: "http://supabase.local", JwtSecret: tenantJwtSecret}
+
+	// patch can't move row to other tenant
+	ctx := newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects?name=eq.rocket")
+	ctx.Request.Header.SetMethod(fasthttp.MethodPatch)
+	ctx.Request.Header.Set("Prefer", "return=representation")
+	ctx.Request.SetBodyString(`{"name":"rocket-2","org_id":"globex"}`)
+	assert.NoError(t, raiden.RestProxy(&ctx, "tenant_projects"))
+	assert.JSONEq(t, `[{"id":1,"name":"rocket-2","org_id":"acme"}]`, string(ctx.Response.Body()))
+
+	// merge upsert must use conflict target that contain tenant column
+	ctx = newTenantCtx(cfg, map[string]any{"org_id": "acme"})
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects")
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.Header.Set("Prefer", "resolution=merge-duplicates")
+	ctx.Request.SetBodyString(`{"id":2,"name":"stolen"}`)
+	assert.Equal(t, raiden.ErrTenantConflictTarget, raiden.RestProxy(&ctx, "tenant_projects"))
+
+	ctx.Request.SetRequestURI("/rest/v1/tenant_projects?on_conflict=id")
+	assert.Equal(t, raiden.ErrTenantConflictTarget, raiden.RestProxy(&ctx, "tenant_projects"))
+
+	for _, row := range pg.Rows("tenant_projects") {
+		if row["name"] == "dome" {
+			assert.Equal(t, "globex", row["org_id"])
+		}
+	}
+}
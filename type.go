@@ -2,6 +2,9 @@ package raiden
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 )
@@ -21,6 +24,53 @@ type (
 	}
 )
 
+// ----- Handle register types -----
+var (
+	registeredTypes   = make(map[string]Type)
+	registeredTypesMu sync.RWMutex
+)
+
+// RegisterType make user defined type available for rpc param and return declaration,
+// type is registered by name and by schema qualified name
+func RegisterType(list ...Type) {
+	registeredTypesMu.Lock()
+	defer registeredTypesMu.Unlock()
+
+	for _, t := range list {
+		if t == nil || t.Name() == "" {
+			continue
+		}
+		registeredTypes[strings.ToLower(t.Name())] = t
+		registeredTypes[strings.ToLower(GetTypeQualifiedName(t))] = t
+		registeredTypes[strings.ToLower(fmt.Sprintf("%s.%s", getTypeSchema(t), t.Name()))] = t
+	}
+}
+
+// GetRegisteredType find registered type by name or schema qualified name
+func GetRegisteredType(name string) (Type, bool) {
+	registeredTypesMu.RLock()
+	defer registeredTypesMu.RUnlock()
+
+	t, exist := registeredTypes[strings.ToLower(strings.Trim(name, `" `))]
+	return t, exist
+}
+
+// GetTypeQualifiedName return type name prefixed by schema when schema is not public
+func GetTypeQualifiedName(t Type) string {
+	schema := getTypeSchema(t)
+	if schema == DefaultTypeSchema {
+		return t.Name()
+	}
+	return fmt.Sprintf("%s.%s", schema, t.Name())
+}
+
+func getTypeSchema(t Type) string {
+	if t.Schema() == "" {
+		return DefaultTypeSchema
+	}
+	return t.Schema()
+}
+
 // ----- base type default function -----
 type TypeBase struct {
 	Value any
@@ -82,3 +82,30 @@ func TestTypeBase_JsonMarshall(t *testing.T) {
 
 	assert.Equal(t, jsonStr, string(byteData))
 }
+
+type registryTestStatus struct {
+	raiden.TypeBase
+}
+
+func (*registryTestStatus) Name() string {
+	return "registry_test_status"
+}
+
+func (*registryTestStatus) Schema() string {
+	return "billing"
+}
+
+func TestRegisterType(t *testing.T) {
+	raiden.RegisterType(&registryTestStatus{}, nil)
+
+	for _, name := range []string{"registry_test_status", "billing.registry_test_status", "BILLING.REGISTRY_TEST_STATUS"} {
+		rt, found := raiden.GetRegisteredType(name)
+		assert.True(t, found, name)
+		assert.Equal(t, "registry_test_status", rt.Name())
+	}
+
+	_, found := raiden.GetRegisteredType("public.registry_test_status")
+	assert.False(t, found)
+
+	assert.Equal(t, "billing.registry_test_status", raiden.GetTypeQualifiedName(&registryTestStatus{}))
+}